@@ -20,16 +20,20 @@ require (
 	go.opentelemetry.io/collector/exporter/debugexporter v0.117.0
 	go.opentelemetry.io/collector/exporter/nopexporter v0.117.0
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.117.0
+	go.opentelemetry.io/collector/exporter/otlpfileexporter v0.117.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.117.0
 	go.opentelemetry.io/collector/extension v0.117.0
+	go.opentelemetry.io/collector/extension/filestorageextension v0.117.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.117.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.117.0
 	go.opentelemetry.io/collector/otelcol v0.117.0
 	go.opentelemetry.io/collector/processor v0.117.0
 	go.opentelemetry.io/collector/processor/batchprocessor v0.117.0
 	go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.117.0
+	go.opentelemetry.io/collector/processor/rulefilterprocessor v0.117.0
 	go.opentelemetry.io/collector/receiver v0.117.0
 	go.opentelemetry.io/collector/receiver/nopreceiver v0.117.0
+	go.opentelemetry.io/collector/receiver/otlpfilereceiver v0.117.0
 	go.opentelemetry.io/collector/receiver/otlpreceiver v0.117.0
 	golang.org/x/sys v0.29.0
 )
@@ -107,6 +111,7 @@ require (
 	go.opentelemetry.io/collector/extension/extensiontest v0.117.0 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.117.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.23.0 // indirect
+	go.opentelemetry.io/collector/filter v0.117.0 // indirect
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.117.0 // indirect
 	go.opentelemetry.io/collector/internal/memorylimiter v0.117.0 // indirect
 	go.opentelemetry.io/collector/internal/sharedcomponent v0.117.0 // indirect
@@ -230,6 +235,8 @@ replace go.opentelemetry.io/collector/exporter/nopexporter => ../../exporter/nop
 
 replace go.opentelemetry.io/collector/exporter/otlpexporter => ../../exporter/otlpexporter
 
+replace go.opentelemetry.io/collector/exporter/otlpfileexporter => ../../exporter/otlpfileexporter
+
 replace go.opentelemetry.io/collector/exporter/otlphttpexporter => ../../exporter/otlphttpexporter
 
 replace go.opentelemetry.io/collector/extension => ../../extension
@@ -242,6 +249,8 @@ replace go.opentelemetry.io/collector/extension/extensioncapabilities => ../../e
 
 replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
 
+replace go.opentelemetry.io/collector/extension/filestorageextension => ../../extension/filestorageextension
+
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
@@ -250,6 +259,8 @@ replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extensi
 
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
 
+replace go.opentelemetry.io/collector/filter => ../../filter
+
 replace go.opentelemetry.io/collector/internal/memorylimiter => ../../internal/memorylimiter
 
 replace go.opentelemetry.io/collector/internal/fanoutconsumer => ../../internal/fanoutconsumer
@@ -274,6 +285,8 @@ replace go.opentelemetry.io/collector/processor/processortest => ../../processor
 
 replace go.opentelemetry.io/collector/processor/batchprocessor => ../../processor/batchprocessor
 
+replace go.opentelemetry.io/collector/processor/rulefilterprocessor => ../../processor/rulefilterprocessor
+
 replace go.opentelemetry.io/collector/processor/memorylimiterprocessor => ../../processor/memorylimiterprocessor
 
 replace go.opentelemetry.io/collector/processor/xprocessor => ../../processor/xprocessor
@@ -282,6 +295,8 @@ replace go.opentelemetry.io/collector/receiver => ../../receiver
 
 replace go.opentelemetry.io/collector/receiver/nopreceiver => ../../receiver/nopreceiver
 
+replace go.opentelemetry.io/collector/receiver/otlpfilereceiver => ../../receiver/otlpfilereceiver
+
 replace go.opentelemetry.io/collector/receiver/otlpreceiver => ../../receiver/otlpreceiver
 
 replace go.opentelemetry.io/collector/receiver/receivertest => ../../receiver/receivertest
@@ -106,6 +106,14 @@ type ClientConfig struct {
 
 	// Auth configuration for outgoing RPCs.
 	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// Resolver configures how the addresses of the endpoint are resolved.
+	Resolver ResolverConfig `mapstructure:"resolver"`
+
+	// MaxConnectionAge is the maximum time a connection is used for new requests before it is established again,
+	// +/- 10%, so the load is balanced again over the resolved addresses. The requests in flight complete on the
+	// previous connection, which is closed once drained. If 0, the connections are not renewed.
+	MaxConnectionAge time.Duration `mapstructure:"max_connection_age"`
 }
 
 // NewDefaultClientConfig returns a new instance of ClientConfig with default values.
@@ -210,6 +218,17 @@ func (gcs *ClientConfig) Validate() error {
 		}
 	}
 
+	if err := gcs.Resolver.Validate(); err != nil {
+		return err
+	}
+	if gcs.Resolver.RefreshInterval > 0 && !isDNSTarget(gcs.sanitizedEndpoint()) {
+		return fmt.Errorf("resolver refresh_interval requires a DNS endpoint: %s", gcs.Endpoint)
+	}
+
+	if gcs.MaxConnectionAge < 0 {
+		return fmt.Errorf("invalid max_connection_age value: %v", gcs.MaxConnectionAge)
+	}
+
 	return nil
 }
 
@@ -262,8 +281,10 @@ func (gcs *ClientConfig) ToClientConn(
 	if err != nil {
 		return nil, err
 	}
+	target, resolverOpts := gcs.dialTarget()
+	grpcOpts = append(grpcOpts, resolverOpts...)
 	//nolint:staticcheck //SA1019 see https://github.com/open-telemetry/opentelemetry-collector/pull/11575
-	return grpc.DialContext(ctx, gcs.sanitizedEndpoint(), grpcOpts...)
+	return grpc.DialContext(ctx, target, grpcOpts...)
 }
 
 func (gcs *ClientConfig) getGrpcDialOptions(
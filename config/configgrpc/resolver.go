@@ -0,0 +1,324 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+const (
+	// dnsRefreshScheme is the scheme of the resolver re-resolving the endpoint periodically.
+	dnsRefreshScheme = "otelcol-dns"
+	// staticScheme is the scheme of the resolver returning the static addresses.
+	staticScheme = "otelcol-static"
+	// maxAgeScheme is the scheme of the resolver renewing the addresses after the max connection age.
+	maxAgeScheme = "otelcol-max-age"
+	// dnsTargetPrefix is the prefix of the targets resolved with the default DNS server.
+	dnsTargetPrefix = "dns:///"
+)
+
+// ResolverConfig defines how the client resolves the addresses of the endpoint.
+type ResolverConfig struct {
+	// RefreshInterval is the interval at which the host of the endpoint is resolved again with DNS,
+	// in addition to the resolutions on connection failures. It allows load balancing policies like
+	// round_robin to discover new addresses. If 0, the gRPC DNS resolver is used.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// StaticAddresses is the list of host:port addresses the client connects to, instead of resolving
+	// the endpoint. The endpoint is still used as the authority of the requests.
+	StaticAddresses []string `mapstructure:"static_addresses"`
+}
+
+// Validate checks if the ResolverConfig configuration is valid.
+func (rc *ResolverConfig) Validate() error {
+	if rc.RefreshInterval < 0 {
+		return errors.New("invalid resolver refresh_interval: must be non-negative")
+	}
+	if rc.RefreshInterval > 0 && len(rc.StaticAddresses) > 0 {
+		return errors.New("resolver refresh_interval and static_addresses cannot be both set")
+	}
+	for _, addr := range rc.StaticAddresses {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return errors.New("invalid resolver static_addresses: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// target returns the target to dial for the endpoint, and the resolver to use, if not the default one of the target.
+func (rc *ResolverConfig) target(endpoint string) (string, resolver.Builder) {
+	switch {
+	case len(rc.StaticAddresses) > 0:
+		r := manual.NewBuilderWithScheme(staticScheme)
+		addrs := make([]resolver.Address, 0, len(rc.StaticAddresses))
+		for _, addr := range rc.StaticAddresses {
+			addrs = append(addrs, resolver.Address{Addr: addr})
+		}
+		r.InitialState(resolver.State{Addresses: addrs})
+		return staticScheme + ":///" + strings.TrimPrefix(endpoint, dnsTargetPrefix), r
+	case rc.RefreshInterval > 0:
+		return dnsRefreshScheme + ":///" + strings.TrimPrefix(endpoint, dnsTargetPrefix), &dnsRefreshBuilder{interval: rc.RefreshInterval}
+	default:
+		return endpoint, nil
+	}
+}
+
+// dialTarget returns the target to dial and the dial options of the resolvers to use.
+func (gcs *ClientConfig) dialTarget() (string, []grpc.DialOption) {
+	target, builder := gcs.Resolver.target(gcs.sanitizedEndpoint())
+	if gcs.MaxConnectionAge > 0 {
+		builder = newMaxAgeBuilder(target, builder, gcs.MaxConnectionAge)
+		target = maxAgeScheme + ":///" + target
+	}
+	if builder == nil {
+		return target, nil
+	}
+	return target, []grpc.DialOption{grpc.WithResolvers(builder)}
+}
+
+// isDNSTarget returns whether the endpoint is resolved with the default DNS server.
+func isDNSTarget(endpoint string) bool {
+	if strings.HasPrefix(endpoint, dnsTargetPrefix) {
+		return true
+	}
+	return !strings.Contains(endpoint, "://") && !strings.HasPrefix(endpoint, "unix:")
+}
+
+// dnsRefreshBuilder builds the resolvers re-resolving the host of the endpoint with DNS periodically.
+type dnsRefreshBuilder struct {
+	interval time.Duration
+}
+
+func (b *dnsRefreshBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	host, port, err := net.SplitHostPort(target.Endpoint())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &dnsRefreshResolver{
+		host:       host,
+		port:       port,
+		interval:   b.interval,
+		cc:         cc,
+		resolveNow: make(chan struct{}, 1),
+		cancel:     cancel,
+	}
+	r.wg.Add(1)
+	go r.watch(ctx)
+	return r, nil
+}
+
+func (b *dnsRefreshBuilder) Scheme() string {
+	return dnsRefreshScheme
+}
+
+// dnsRefreshResolver resolves the host every interval, and when gRPC asks for it.
+type dnsRefreshResolver struct {
+	host       string
+	port       string
+	interval   time.Duration
+	cc         resolver.ClientConn
+	resolveNow chan struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func (r *dnsRefreshResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+	for {
+		r.resolve(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.resolveNow:
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+func (r *dnsRefreshResolver) resolve(ctx context.Context) {
+	hosts, err := net.DefaultResolver.LookupHost(ctx, r.host)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.cc.ReportError(err)
+		}
+		return
+	}
+	addrs := make([]resolver.Address, 0, len(hosts))
+	for _, host := range hosts {
+		addrs = append(addrs, resolver.Address{Addr: net.JoinHostPort(host, r.port)})
+	}
+	// The error is returned when the addresses are rejected by the balancer, which then asks for a new resolution.
+	_ = r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
+
+func (r *dnsRefreshResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *dnsRefreshResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// maxAgeBuilder builds the resolvers wrapping the resolver of the target, that renew the resolved addresses
+// after the max connection age, +/- 10% to spread the reconnections. The renewed addresses are seen as new
+// addresses by the balancer, which connects to them and drains the previous connections: the requests in
+// flight complete on the previous connections and the new requests are balanced over the resolved addresses.
+type maxAgeBuilder struct {
+	target string
+	inner  resolver.Builder
+	maxAge time.Duration
+}
+
+func newMaxAgeBuilder(target string, inner resolver.Builder, maxAge time.Duration) *maxAgeBuilder {
+	return &maxAgeBuilder{target: target, inner: inner, maxAge: maxAge}
+}
+
+// innerTarget returns the resolver of the wrapped target and its parsed target, like gRPC does when dialing it.
+func (b *maxAgeBuilder) innerTarget() (resolver.Builder, resolver.Target, error) {
+	if u, err := url.Parse(b.target); err == nil {
+		if b.inner != nil && b.inner.Scheme() == u.Scheme {
+			return b.inner, resolver.Target{URL: *u}, nil
+		}
+		if rb := resolver.Get(u.Scheme); rb != nil {
+			return rb, resolver.Target{URL: *u}, nil
+		}
+	}
+	u, err := url.Parse(resolver.GetDefaultScheme() + ":///" + b.target)
+	if err != nil {
+		return nil, resolver.Target{}, err
+	}
+	rb := resolver.Get(u.Scheme)
+	if rb == nil {
+		return nil, resolver.Target{}, fmt.Errorf("no resolver for the default scheme %q", u.Scheme)
+	}
+	return rb, resolver.Target{URL: *u}, nil
+}
+
+func (b *maxAgeBuilder) Build(_ resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	rb, target, err := b.innerTarget()
+	if err != nil {
+		return nil, err
+	}
+	r := &maxAgeResolver{ClientConn: cc, maxAge: b.maxAge}
+	if r.inner, err = rb.Build(target, r, opts); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timer = time.AfterFunc(r.nextAge(), r.renew)
+	return r, nil
+}
+
+func (b *maxAgeBuilder) Scheme() string {
+	return maxAgeScheme
+}
+
+// OverrideAuthority returns the authority of the wrapped target, as if it was dialed directly.
+func (b *maxAgeBuilder) OverrideAuthority(resolver.Target) string {
+	rb, target, err := b.innerTarget()
+	if err != nil {
+		return b.target
+	}
+	if auth, ok := rb.(resolver.AuthorityOverrider); ok {
+		return auth.OverrideAuthority(target)
+	}
+	if endpoint := target.Endpoint(); strings.HasPrefix(endpoint, ":") {
+		return "localhost" + endpoint
+	}
+	return target.Endpoint()
+}
+
+// maxAgeGenerationKey is the key of the address attribute changed every time the addresses are renewed.
+type maxAgeGenerationKey struct{}
+
+// maxAgeResolver is the resolver.ClientConn of the wrapped resolver, it adds the generation of the addresses to
+// the resolved state.
+type maxAgeResolver struct {
+	resolver.ClientConn
+	inner  resolver.Resolver
+	maxAge time.Duration
+
+	mu         sync.Mutex
+	state      *resolver.State
+	generation int
+	timer      *time.Timer
+	closed     bool
+}
+
+func (r *maxAgeResolver) UpdateState(state resolver.State) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state = &state
+	return r.ClientConn.UpdateState(r.withGeneration(state))
+}
+
+// renew updates the state with a new generation of the addresses.
+func (r *maxAgeResolver) renew() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.generation++
+	if r.state != nil {
+		// The error is returned when the addresses are rejected by the balancer, which then asks for a new resolution.
+		_ = r.ClientConn.UpdateState(r.withGeneration(*r.state))
+	}
+	r.timer.Reset(r.nextAge())
+}
+
+// withGeneration returns the state with the current generation set on the addresses. Caller must hold the lock.
+func (r *maxAgeResolver) withGeneration(state resolver.State) resolver.State {
+	setGeneration := func(addrs []resolver.Address) []resolver.Address {
+		renewed := make([]resolver.Address, len(addrs))
+		for i, addr := range addrs {
+			addr.Attributes = addr.Attributes.WithValue(maxAgeGenerationKey{}, r.generation)
+			renewed[i] = addr
+		}
+		return renewed
+	}
+	state.Addresses = setGeneration(state.Addresses)
+	if state.Endpoints != nil {
+		endpoints := make([]resolver.Endpoint, len(state.Endpoints))
+		for i, endpoint := range state.Endpoints {
+			endpoint.Addresses = setGeneration(endpoint.Addresses)
+			endpoints[i] = endpoint
+		}
+		state.Endpoints = endpoints
+	}
+	return state
+}
+
+func (r *maxAgeResolver) nextAge() time.Duration {
+	return r.maxAge + time.Duration((rand.Float64()*0.2-0.1)*float64(r.maxAge))
+}
+
+func (r *maxAgeResolver) ResolveNow(opts resolver.ResolveNowOptions) {
+	r.inner.ResolveNow(opts)
+}
+
+func (r *maxAgeResolver) Close() {
+	r.mu.Lock()
+	r.closed = true
+	r.timer.Stop()
+	r.mu.Unlock()
+	r.inner.Close()
+}
@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestClientConfigValidateResolver(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ClientConfig
+		err      string
+	}{
+		{
+			name: "valid",
+			settings: ClientConfig{
+				Endpoint:         "dns:///localhost:4317",
+				Resolver:         ResolverConfig{RefreshInterval: time.Second},
+				MaxConnectionAge: time.Minute,
+			},
+		},
+		{
+			name: "negative refresh_interval",
+			settings: ClientConfig{
+				Endpoint: "localhost:4317",
+				Resolver: ResolverConfig{RefreshInterval: -time.Second},
+			},
+			err: "invalid resolver refresh_interval: must be non-negative",
+		},
+		{
+			name: "refresh_interval and static_addresses",
+			settings: ClientConfig{
+				Endpoint: "localhost:4317",
+				Resolver: ResolverConfig{RefreshInterval: time.Second, StaticAddresses: []string{"10.0.0.1:4317"}},
+			},
+			err: "resolver refresh_interval and static_addresses cannot be both set",
+		},
+		{
+			name: "invalid static_addresses",
+			settings: ClientConfig{
+				Endpoint: "localhost:4317",
+				Resolver: ResolverConfig{StaticAddresses: []string{"10.0.0.1"}},
+			},
+			err: "invalid resolver static_addresses: address 10.0.0.1: missing port in address",
+		},
+		{
+			name: "refresh_interval with unix endpoint",
+			settings: ClientConfig{
+				Endpoint: "unix:///tmp/otelcol.sock",
+				Resolver: ResolverConfig{RefreshInterval: time.Second},
+			},
+			err: "resolver refresh_interval requires a DNS endpoint: unix:///tmp/otelcol.sock",
+		},
+		{
+			name: "negative max_connection_age",
+			settings: ClientConfig{
+				Endpoint:         "localhost:4317",
+				MaxConnectionAge: -time.Second,
+			},
+			err: "invalid max_connection_age value: -1s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+// countingListener counts the accepted connections.
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int64
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+func startTraceServer(t *testing.T) (*countingListener, *grpcTraceServer) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gts := &grpcTraceServer{}
+	return serveTraces(t, ln, gts), gts
+}
+
+func serveTraces(t *testing.T, ln net.Listener, gts ptraceotlp.GRPCServer) *countingListener {
+	cln := &countingListener{Listener: ln}
+	srv := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(srv, gts)
+	go func() {
+		_ = srv.Serve(cln)
+	}()
+	t.Cleanup(srv.Stop)
+	return cln
+}
+
+// slowTraceServer responds to the exports after a delay.
+type slowTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	delay time.Duration
+}
+
+func (s *slowTraceServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	time.Sleep(s.delay)
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func exportTraces(t *testing.T, gcs *ClientConfig, requests int) {
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+	for i := 0; i < requests; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		_, err = c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		cancel()
+		require.NoError(t, err)
+	}
+}
+
+func TestStaticAddresses(t *testing.T) {
+	for _, maxAge := range []time.Duration{0, time.Minute} {
+		t.Run("max_connection_age="+maxAge.String(), func(t *testing.T) {
+			ln, gts := startTraceServer(t)
+			gcs := &ClientConfig{
+				Endpoint:         "backend.invalid:4317",
+				TLSSetting:       configtls.ClientConfig{Insecure: true},
+				Resolver:         ResolverConfig{StaticAddresses: []string{ln.Addr().String()}},
+				MaxConnectionAge: maxAge,
+			}
+			exportTraces(t, gcs, 1)
+
+			// The endpoint is used as the authority of the requests.
+			md, ok := metadata.FromIncomingContext(gts.recordedContext)
+			require.True(t, ok)
+			assert.Equal(t, []string{"backend.invalid:4317"}, md.Get(":authority"))
+		})
+	}
+}
+
+func TestDNSRefresh(t *testing.T) {
+	ln, _ := startTraceServer(t)
+	gcs := &ClientConfig{
+		Endpoint:     "dns:///" + ln.Addr().String(),
+		TLSSetting:   configtls.ClientConfig{Insecure: true},
+		BalancerName: "round_robin",
+		Resolver:     ResolverConfig{RefreshInterval: 10 * time.Millisecond},
+	}
+	exportTraces(t, gcs, 1)
+}
+
+func TestMaxConnectionAge(t *testing.T) {
+	ln, _ := startTraceServer(t)
+	gcs := &ClientConfig{
+		Endpoint:         ln.Addr().String(),
+		TLSSetting:       configtls.ClientConfig{Insecure: true},
+		MaxConnectionAge: 50 * time.Millisecond,
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	// The connection is established again once too old.
+	assert.Eventually(t, func() bool {
+		_, _ = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		return ln.accepted.Load() >= 2
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestMaxConnectionAgeDrainsConnection(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cln := serveTraces(t, ln, &slowTraceServer{delay: 300 * time.Millisecond})
+	gcs := &ClientConfig{
+		Endpoint:         ln.Addr().String(),
+		TLSSetting:       configtls.ClientConfig{Insecure: true},
+		MaxConnectionAge: 50 * time.Millisecond,
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	// The request in flight completes on the previous connection while the new one is established.
+	_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, cln.accepted.Load(), int64(2))
+}
+
+func TestMaxConnectionAgeUnixSocket(t *testing.T) {
+	socket := tempSocketName(t)
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	cln := serveTraces(t, ln, &grpcTraceServer{})
+	gcs := &ClientConfig{
+		Endpoint:         "unix://" + socket,
+		TLSSetting:       configtls.ClientConfig{Insecure: true},
+		MaxConnectionAge: 50 * time.Millisecond,
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	assert.Eventually(t, func() bool {
+		_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		return err == nil && cln.accepted.Load() >= 2
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// fakeResolverClientConn records the states of the resolver.
+type fakeResolverClientConn struct {
+	resolver.ClientConn
+	mu     sync.Mutex
+	states []resolver.State
+}
+
+func (cc *fakeResolverClientConn) UpdateState(state resolver.State) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.states = append(cc.states, state)
+	return nil
+}
+
+func (cc *fakeResolverClientConn) updates() []resolver.State {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.states
+}
+
+func TestDNSRefreshResolver(t *testing.T) {
+	b := &dnsRefreshBuilder{interval: time.Hour}
+	assert.Equal(t, dnsRefreshScheme, b.Scheme())
+	cc := &fakeResolverClientConn{}
+	r, err := b.Build(resolver.Target{URL: *mustParseURL(t, "otelcol-dns:///127.0.0.1:4317")}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+
+	// The host is resolved when the resolver is built, and when gRPC asks for it.
+	assert.Eventually(t, func() bool { return len(cc.updates()) == 1 }, time.Second, time.Millisecond)
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	assert.Eventually(t, func() bool { return len(cc.updates()) == 2 }, time.Second, time.Millisecond)
+	r.Close()
+	assert.Equal(t, []resolver.Address{{Addr: "127.0.0.1:4317"}}, cc.updates()[1].Addresses)
+
+	// The host is resolved again every interval.
+	b.interval = 10 * time.Millisecond
+	cc = &fakeResolverClientConn{}
+	r, err = b.Build(resolver.Target{URL: *mustParseURL(t, "otelcol-dns:///127.0.0.1:4317")}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(cc.updates()) >= 3 }, time.Second, time.Millisecond)
+	r.Close()
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u
+}
@@ -5,6 +5,7 @@ package configretry // import "go.opentelemetry.io/collector/config/configretry"
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -19,6 +20,7 @@ func NewDefaultBackOffConfig() BackOffConfig {
 		Multiplier:          backoff.DefaultMultiplier,
 		MaxInterval:         30 * time.Second,
 		MaxElapsedTime:      5 * time.Minute,
+		Throttle:            NewDefaultThrottleConfig(),
 	}
 }
 
@@ -40,6 +42,9 @@ type BackOffConfig struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded. If set to 0, the retries are never stopped.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// Throttle defines how the delays requested by the destination, for example with the HTTP `Retry-After`
+	// header or the gRPC `RetryInfo` details, are applied.
+	Throttle ThrottleConfig `mapstructure:"throttle"`
 }
 
 func (bs *BackOffConfig) Validate() error {
@@ -69,5 +74,8 @@ func (bs *BackOffConfig) Validate() error {
 			return errors.New("'max_elapsed_time' must not be less than 'max_interval'")
 		}
 	}
+	if err := bs.Throttle.Validate(); err != nil {
+		return fmt.Errorf("'throttle' is invalid: %w", err)
+	}
 	return nil
 }
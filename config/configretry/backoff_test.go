@@ -22,6 +22,9 @@ func TestNewDefaultBackOffSettings(t *testing.T) {
 			Multiplier:          1.5,
 			MaxInterval:         30 * time.Second,
 			MaxElapsedTime:      5 * time.Minute,
+			Throttle: ThrottleConfig{
+				Mode: ThrottleModeRequest,
+			},
 		}, cfg)
 }
 
@@ -92,3 +95,23 @@ func TestDisabledWithInvalidValues(t *testing.T) {
 	}
 	assert.NoError(t, cfg.Validate())
 }
+
+func TestInvalidThrottle(t *testing.T) {
+	cfg := NewDefaultBackOffConfig()
+	require.NoError(t, cfg.Validate())
+	cfg.Throttle.MaxDelay = -1
+	assert.EqualError(t, cfg.Validate(), "'throttle' is invalid: 'max_delay' must be non-negative")
+}
+
+func TestWithoutThrottleIsValid(t *testing.T) {
+	// Configs created before the throttle settings were added keep validating.
+	cfg := BackOffConfig{
+		Enabled:             true,
+		InitialInterval:     5 * time.Second,
+		RandomizationFactor: 0.5,
+		Multiplier:          1.5,
+		MaxInterval:         30 * time.Second,
+		MaxElapsedTime:      5 * time.Minute,
+	}
+	assert.NoError(t, cfg.Validate())
+}
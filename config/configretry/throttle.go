@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configretry // import "go.opentelemetry.io/collector/config/configretry"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ThrottleMode defines which requests wait for the delay requested by a throttling destination.
+type ThrottleMode string
+
+const (
+	// ThrottleModeRequest only delays the retry of the throttled request.
+	ThrottleModeRequest ThrottleMode = "request"
+	// ThrottleModeExporter pauses all the requests of the exporter until the delay is over,
+	// like an open circuit breaker.
+	ThrottleModeExporter ThrottleMode = "exporter"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *ThrottleMode) UnmarshalText(text []byte) error {
+	switch mode := ThrottleMode(text); mode {
+	case ThrottleModeRequest, ThrottleModeExporter:
+		*m = mode
+		return nil
+	default:
+		return fmt.Errorf("unsupported mode %q, must be one of %q or %q", mode, ThrottleModeRequest, ThrottleModeExporter)
+	}
+}
+
+// NewDefaultThrottleConfig returns the default settings for ThrottleConfig.
+func NewDefaultThrottleConfig() ThrottleConfig {
+	return ThrottleConfig{
+		MaxDelay:            0,
+		RandomizationFactor: 0,
+		Mode:                ThrottleModeRequest,
+	}
+}
+
+// ThrottleConfig defines how the delays requested by a throttling destination are applied.
+type ThrottleConfig struct {
+	// MaxDelay is the upper bound on the delay requested by the destination. If set to 0, the delay is not bounded.
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+	// RandomizationFactor is a random factor used to spread the requests retried after the same requested delay.
+	// Randomized delay = RequestedDelay * (1 + random value in [0, RandomizationFactor])
+	RandomizationFactor float64 `mapstructure:"randomization_factor"`
+	// Mode defines which requests wait for the requested delay. If empty, ThrottleModeRequest is used.
+	Mode ThrottleMode `mapstructure:"mode"`
+}
+
+// Validate checks if the ThrottleConfig configuration is valid.
+func (tc *ThrottleConfig) Validate() error {
+	if tc.MaxDelay < 0 {
+		return errors.New("'max_delay' must be non-negative")
+	}
+	if tc.RandomizationFactor < 0 || tc.RandomizationFactor > 1 {
+		return errors.New("'randomization_factor' must be within [0, 1]")
+	}
+	switch tc.Mode {
+	case "", ThrottleModeRequest, ThrottleModeExporter:
+	default:
+		return fmt.Errorf("unsupported 'mode' %q", tc.Mode)
+	}
+	return nil
+}
+
+// Delay returns the delay to apply for the delay requested by the destination, bounded by MaxDelay and randomized
+// with the given random value within [0, 1).
+func (tc *ThrottleConfig) Delay(requested time.Duration, random float64) time.Duration {
+	if tc.MaxDelay > 0 {
+		requested = min(requested, tc.MaxDelay)
+	}
+	return requested + time.Duration(float64(requested)*tc.RandomizationFactor*random)
+}
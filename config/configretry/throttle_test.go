@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configretry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleConfigValidate(t *testing.T) {
+	cfg := NewDefaultThrottleConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.RandomizationFactor = 1.5
+	require.EqualError(t, cfg.Validate(), "'randomization_factor' must be within [0, 1]")
+
+	cfg = NewDefaultThrottleConfig()
+	cfg.Mode = "pipeline"
+	require.EqualError(t, cfg.Validate(), "unsupported 'mode' \"pipeline\"")
+
+	cfg.Mode = ThrottleModeExporter
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestThrottleModeUnmarshalText(t *testing.T) {
+	var mode ThrottleMode
+	require.NoError(t, mode.UnmarshalText([]byte("exporter")))
+	assert.Equal(t, ThrottleModeExporter, mode)
+	require.EqualError(t, mode.UnmarshalText([]byte("pipeline")),
+		"unsupported mode \"pipeline\", must be one of \"request\" or \"exporter\"")
+}
+
+func TestThrottleConfigDelay(t *testing.T) {
+	cfg := NewDefaultThrottleConfig()
+	assert.Equal(t, time.Hour, cfg.Delay(time.Hour, 0.5))
+
+	cfg.MaxDelay = time.Minute
+	assert.Equal(t, 10*time.Second, cfg.Delay(10*time.Second, 0.5))
+	assert.Equal(t, time.Minute, cfg.Delay(time.Hour, 0.5))
+
+	cfg.RandomizationFactor = 0.5
+	assert.Equal(t, 10*time.Second, cfg.Delay(10*time.Second, 0))
+	assert.Equal(t, 12500*time.Millisecond, cfg.Delay(10*time.Second, 0.5))
+	assert.Equal(t, 90*time.Second, cfg.Delay(time.Hour, 1))
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "errors"
+
+// rejected is an error reporting that only part of the data was rejected.
+type rejected struct {
+	err   error
+	count int
+}
+
+// NewRejected wraps an error to indicate that only the given number of items of the data
+// (spans, data points, log records or profiles) were rejected, the rest of the data being accepted.
+// Receivers use it to report a partial success to their clients.
+func NewRejected(err error, count int) error {
+	return rejected{err: err, count: count}
+}
+
+func (r rejected) Error() string {
+	return r.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (r rejected) Unwrap() error {
+	return r.err
+}
+
+// RejectedCount returns the number of rejected items of an error wrapped with the NewRejected function,
+// and whether the error was wrapped with it.
+func RejectedCount(err error) (int, bool) {
+	var r rejected
+	if err == nil || !errors.As(err, &r) {
+		return 0, false
+	}
+	return r.count, true
+}
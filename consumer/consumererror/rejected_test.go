@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejected(t *testing.T) {
+	err := errors.New("some error")
+	rejectedErr := NewRejected(err, 3)
+	require.EqualError(t, rejectedErr, "some error")
+	require.ErrorIs(t, rejectedErr, err)
+
+	count, ok := RejectedCount(rejectedErr)
+	assert.True(t, ok)
+	assert.Equal(t, 3, count)
+
+	// The count is found in the chain of wrapped errors.
+	count, ok = RejectedCount(NewPermanent(fmt.Errorf("wrapped: %w", rejectedErr)))
+	assert.True(t, ok)
+	assert.Equal(t, 3, count)
+}
+
+func TestRejectedCount_NotRejected(t *testing.T) {
+	_, ok := RejectedCount(nil)
+	assert.False(t, ok)
+	_, ok = RejectedCount(errors.New("some error"))
+	assert.False(t, ok)
+}
@@ -4,10 +4,12 @@
 package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexporter"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/filter"
 )
 
 // supportedLevels in this exporter's configuration.
@@ -18,11 +20,38 @@ var supportedLevels map[configtelemetry.Level]struct{} = map[configtelemetry.Lev
 	configtelemetry.LevelDetailed: {},
 }
 
+// Format defines how the debug exporter outputs the telemetry data.
+type Format string
+
+const (
+	// FormatText outputs the telemetry data as text, as detailed as the verbosity level.
+	FormatText Format = "text"
+	// FormatJSON outputs every batch of telemetry data as an OTLP JSON message.
+	FormatJSON Format = "json"
+	// FormatLogfmt outputs one line of logfmt for every telemetry record.
+	FormatLogfmt Format = "logfmt"
+	// FormatTree outputs the spans grouped by trace, indented under their parent span.
+	// The other signals are output like with FormatText and the normal verbosity level.
+	FormatTree Format = "tree"
+)
+
+// Validate checks if the format is supported.
+func (f Format) Validate() error {
+	switch f {
+	case FormatText, FormatJSON, FormatLogfmt, FormatTree:
+		return nil
+	}
+	return fmt.Errorf("format %q is not supported", f)
+}
+
 // Config defines configuration for debug exporter.
 type Config struct {
 	// Verbosity defines the debug exporter verbosity.
 	Verbosity configtelemetry.Level `mapstructure:"verbosity,omitempty"`
 
+	// Format defines the format of the output. The verbosity only applies to the text format.
+	Format Format `mapstructure:"format,omitempty"`
+
 	// SamplingInitial defines how many samples are initially logged during each second.
 	SamplingInitial int `mapstructure:"sampling_initial"`
 
@@ -31,6 +60,30 @@ type Config struct {
 
 	// UseInternalLogger defines whether the exporter sends the output to the collector's internal logger.
 	UseInternalLogger bool `mapstructure:"use_internal_logger"`
+
+	// Filter selects the telemetry records to output. All the records are output by default.
+	Filter FilterConfig `mapstructure:"filter"`
+
+	// RateLimit defines the maximum number of telemetry records output per second. 0 means no limit.
+	RateLimit int `mapstructure:"rate_limit"`
+}
+
+// FilterConfig defines the telemetry records output by the debug exporter.
+// A record is output if it matches all the configured filters.
+type FilterConfig struct {
+	// ResourceAttributes matches the records of the resources with, for every attribute key,
+	// an attribute value matching one of the filters of the key.
+	ResourceAttributes map[string][]filter.Config `mapstructure:"resource_attributes,omitempty"`
+
+	// SpanNames matches the spans with a name matching one of the filters.
+	SpanNames []filter.Config `mapstructure:"span_names,omitempty"`
+
+	// MetricNames matches the metrics with a name matching one of the filters.
+	MetricNames []filter.Config `mapstructure:"metric_names,omitempty"`
+
+	// LogSeverities matches the log records with a severity text matching one of the filters,
+	// or the name of the severity number (e.g. Warn, Error2) if the severity text is not set.
+	LogSeverities []filter.Config `mapstructure:"log_severities,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -41,5 +94,13 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("verbosity level %q is not supported", cfg.Verbosity)
 	}
 
+	if err := cfg.Format.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.RateLimit < 0 {
+		return errors.New("rate_limit must be non-negative")
+	}
+
 	return nil
 }
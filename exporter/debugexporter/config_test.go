@@ -10,9 +10,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/filter"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -32,10 +34,40 @@ func TestUnmarshalConfig(t *testing.T) {
 			filename: "config_verbosity.yaml",
 			cfg: &Config{
 				Verbosity:          configtelemetry.LevelDetailed,
+				Format:             FormatText,
 				SamplingInitial:    10,
 				SamplingThereafter: 50,
 			},
 		},
+		{
+			filename: "config_format.yaml",
+			cfg: &Config{
+				Verbosity:          configtelemetry.LevelBasic,
+				Format:             FormatTree,
+				SamplingInitial:    2,
+				SamplingThereafter: 1,
+				UseInternalLogger:  true,
+			},
+		},
+		{
+			filename: "config_filter.yaml",
+			cfg: &Config{
+				Verbosity:          configtelemetry.LevelDetailed,
+				Format:             FormatText,
+				SamplingInitial:    2,
+				SamplingThereafter: 1,
+				UseInternalLogger:  true,
+				Filter: FilterConfig{
+					ResourceAttributes: map[string][]filter.Config{
+						"service.name": {{Strict: "checkout"}, {Regex: "^payment-.*"}},
+					},
+					SpanNames:     []filter.Config{{Regex: "^GET /"}},
+					MetricNames:   []filter.Config{{Strict: "http.server.duration"}},
+					LogSeverities: []filter.Config{{Regex: "(?i)^(warn|error|fatal)"}},
+				},
+				RateLimit: 100,
+			},
+		},
 		{
 			filename:    "config_verbosity_typo.yaml",
 			expectedErr: "'' has invalid keys: verBosity",
@@ -102,6 +134,15 @@ func Test_UnmarshalMarshalled(t *testing.T) {
 	}
 }
 
+func TestValidateFilter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filter.SpanNames = []filter.Config{{Regex: "("}}
+	assert.ErrorContains(t, component.ValidateConfig(cfg), "missing closing )")
+	cfg.Filter.SpanNames = nil
+	cfg.Filter.ResourceAttributes = map[string][]filter.Config{"service.name": {{}}}
+	assert.ErrorContains(t, component.ValidateConfig(cfg), "must specify either strict or regex")
+}
+
 func TestValidate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -119,7 +160,25 @@ func TestValidate(t *testing.T) {
 			name: "verbosity detailed",
 			cfg: &Config{
 				Verbosity: configtelemetry.LevelDetailed,
+				Format:    FormatText,
+			},
+		},
+		{
+			name: "format unsupported",
+			cfg: &Config{
+				Verbosity: configtelemetry.LevelBasic,
+				Format:    "yaml",
+			},
+			expectedErr: "format \"yaml\" is not supported",
+		},
+		{
+			name: "negative rate limit",
+			cfg: &Config{
+				Verbosity: configtelemetry.LevelBasic,
+				Format:    FormatText,
+				RateLimit: -1,
 			},
+			expectedErr: "rate_limit must be non-negative",
 		},
 	}
 
@@ -9,8 +9,10 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/debugexporter/internal/logfmt"
 	"go.opentelemetry.io/collector/exporter/debugexporter/internal/normal"
 	"go.opentelemetry.io/collector/exporter/debugexporter/internal/otlptext"
+	"go.opentelemetry.io/collector/exporter/debugexporter/internal/tree"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -18,7 +20,10 @@ import (
 )
 
 type debugExporter struct {
-	verbosity         configtelemetry.Level
+	// outputData is false when only the summary of every batch is logged.
+	outputData        bool
+	filter            *dataFilter
+	limiter           *rateLimiter
 	logger            *zap.Logger
 	logsMarshaler     plog.Marshaler
 	metricsMarshaler  pmetric.Marshaler
@@ -26,24 +31,42 @@ type debugExporter struct {
 	profilesMarshaler pprofile.Marshaler
 }
 
-func newDebugExporter(logger *zap.Logger, verbosity configtelemetry.Level) *debugExporter {
+func newDebugExporter(logger *zap.Logger, cfg *Config) *debugExporter {
 	var logsMarshaler plog.Marshaler
 	var metricsMarshaler pmetric.Marshaler
 	var tracesMarshaler ptrace.Marshaler
 	var profilesMarshaler pprofile.Marshaler
-	if verbosity == configtelemetry.LevelDetailed {
+	switch {
+	case cfg.Format == FormatJSON:
+		logsMarshaler = &plog.JSONMarshaler{}
+		metricsMarshaler = &pmetric.JSONMarshaler{}
+		tracesMarshaler = &ptrace.JSONMarshaler{}
+		profilesMarshaler = &pprofile.JSONMarshaler{}
+	case cfg.Format == FormatLogfmt:
+		logsMarshaler = logfmt.NewLogfmtLogsMarshaler()
+		metricsMarshaler = logfmt.NewLogfmtMetricsMarshaler()
+		tracesMarshaler = logfmt.NewLogfmtTracesMarshaler()
+		profilesMarshaler = logfmt.NewLogfmtProfilesMarshaler()
+	case cfg.Format == FormatTree:
+		logsMarshaler = normal.NewNormalLogsMarshaler()
+		metricsMarshaler = normal.NewNormalMetricsMarshaler()
+		tracesMarshaler = tree.NewTreeTracesMarshaler()
+		profilesMarshaler = normal.NewNormalProfilesMarshaler()
+	case cfg.Verbosity == configtelemetry.LevelDetailed:
 		logsMarshaler = otlptext.NewTextLogsMarshaler()
 		metricsMarshaler = otlptext.NewTextMetricsMarshaler()
 		tracesMarshaler = otlptext.NewTextTracesMarshaler()
 		profilesMarshaler = otlptext.NewTextProfilesMarshaler()
-	} else {
+	default:
 		logsMarshaler = normal.NewNormalLogsMarshaler()
 		metricsMarshaler = normal.NewNormalMetricsMarshaler()
 		tracesMarshaler = normal.NewNormalTracesMarshaler()
 		profilesMarshaler = normal.NewNormalProfilesMarshaler()
 	}
 	return &debugExporter{
-		verbosity:         verbosity,
+		outputData:        cfg.Format != FormatText || cfg.Verbosity != configtelemetry.LevelBasic,
+		filter:            newDataFilter(cfg.Filter),
+		limiter:           newRateLimiter(cfg.RateLimit),
 		logger:            logger,
 		logsMarshaler:     logsMarshaler,
 		metricsMarshaler:  metricsMarshaler,
@@ -53,10 +76,25 @@ func newDebugExporter(logger *zap.Logger, verbosity configtelemetry.Level) *debu
 }
 
 func (s *debugExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	if s.filter != nil || s.limiter != nil {
+		selected := ptrace.NewTraces()
+		td.CopyTo(selected)
+		if s.filter != nil {
+			s.filter.filterTraces(selected)
+		}
+		if s.limiter != nil {
+			limitTraces(selected, s.limiter.take(selected.SpanCount()))
+		}
+		if selected.SpanCount() == 0 {
+			return nil
+		}
+		td = selected
+	}
+
 	s.logger.Info("Traces",
 		zap.Int("resource spans", td.ResourceSpans().Len()),
 		zap.Int("spans", td.SpanCount()))
-	if s.verbosity == configtelemetry.LevelBasic {
+	if !s.outputData {
 		return nil
 	}
 
@@ -69,11 +107,26 @@ func (s *debugExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
 }
 
 func (s *debugExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	if s.filter != nil || s.limiter != nil {
+		selected := pmetric.NewMetrics()
+		md.CopyTo(selected)
+		if s.filter != nil {
+			s.filter.filterMetrics(selected)
+		}
+		if s.limiter != nil {
+			limitMetrics(selected, s.limiter.take(selected.DataPointCount()))
+		}
+		if selected.MetricCount() == 0 {
+			return nil
+		}
+		md = selected
+	}
+
 	s.logger.Info("Metrics",
 		zap.Int("resource metrics", md.ResourceMetrics().Len()),
 		zap.Int("metrics", md.MetricCount()),
 		zap.Int("data points", md.DataPointCount()))
-	if s.verbosity == configtelemetry.LevelBasic {
+	if !s.outputData {
 		return nil
 	}
 
@@ -86,11 +139,26 @@ func (s *debugExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error
 }
 
 func (s *debugExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	if s.filter != nil || s.limiter != nil {
+		selected := plog.NewLogs()
+		ld.CopyTo(selected)
+		if s.filter != nil {
+			s.filter.filterLogs(selected)
+		}
+		if s.limiter != nil {
+			limitLogs(selected, s.limiter.take(selected.LogRecordCount()))
+		}
+		if selected.LogRecordCount() == 0 {
+			return nil
+		}
+		ld = selected
+	}
+
 	s.logger.Info("Logs",
 		zap.Int("resource logs", ld.ResourceLogs().Len()),
 		zap.Int("log records", ld.LogRecordCount()))
 
-	if s.verbosity == configtelemetry.LevelBasic {
+	if !s.outputData {
 		return nil
 	}
 
@@ -103,11 +171,26 @@ func (s *debugExporter) pushLogs(_ context.Context, ld plog.Logs) error {
 }
 
 func (s *debugExporter) pushProfiles(_ context.Context, pd pprofile.Profiles) error {
+	if s.filter != nil || s.limiter != nil {
+		selected := pprofile.NewProfiles()
+		pd.CopyTo(selected)
+		if s.filter != nil {
+			s.filter.filterProfiles(selected)
+		}
+		if s.limiter != nil {
+			limitProfiles(selected, s.limiter.take(selected.SampleCount()))
+		}
+		if selected.ResourceProfiles().Len() == 0 {
+			return nil
+		}
+		pd = selected
+	}
+
 	s.logger.Info("Profiles",
 		zap.Int("resource profiles", pd.ResourceProfiles().Len()),
 		zap.Int("sample records", pd.SampleCount()))
 
-	if s.verbosity == configtelemetry.LevelBasic {
+	if !s.outputData {
 		return nil
 	}
 
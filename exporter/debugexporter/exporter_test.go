@@ -10,10 +10,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/filter"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -85,7 +89,7 @@ func TestProfilesNoErrors(t *testing.T) {
 }
 
 func TestErrors(t *testing.T) {
-	le := newDebugExporter(zaptest.NewLogger(t), configtelemetry.LevelDetailed)
+	le := newDebugExporter(zaptest.NewLogger(t), &Config{Verbosity: configtelemetry.LevelDetailed, Format: FormatText})
 	require.NotNil(t, le)
 
 	errWant := errors.New("my error")
@@ -99,6 +103,91 @@ func TestErrors(t *testing.T) {
 	assert.Equal(t, errWant, le.pushProfiles(context.Background(), pprofile.NewProfiles()))
 }
 
+func TestOutputFormats(t *testing.T) {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("span")
+	span.SetTraceID([16]byte{1})
+	span.SetSpanID([8]byte{2})
+	tests := []struct {
+		name      string
+		verbosity configtelemetry.Level
+		format    Format
+		expected  []string
+	}{
+		{
+			name:      "basic text",
+			verbosity: configtelemetry.LevelBasic,
+			format:    FormatText,
+			expected:  []string{"Traces"},
+		},
+		{
+			name:      "normal text",
+			verbosity: configtelemetry.LevelNormal,
+			format:    FormatText,
+			expected:  []string{"Traces", "span 01000000000000000000000000000000 0200000000000000\n"},
+		},
+		{
+			name:      "json",
+			verbosity: configtelemetry.LevelBasic,
+			format:    FormatJSON,
+			expected: []string{"Traces", `{"resourceSpans":[{"resource":{},"scopeSpans":[{"scope":{},"spans":[` +
+				`{"traceId":"01000000000000000000000000000000","spanId":"0200000000000000","parentSpanId":"","name":"span","status":{}}]}]}]}`},
+		},
+		{
+			name:      "logfmt",
+			verbosity: configtelemetry.LevelBasic,
+			format:    FormatLogfmt,
+			expected:  []string{"Traces", "name=span trace_id=01000000000000000000000000000000 span_id=0200000000000000 kind=Unspecified duration=0s\n"},
+		},
+		{
+			name:      "tree",
+			verbosity: configtelemetry.LevelBasic,
+			format:    FormatTree,
+			expected:  []string{"Traces", "Trace 01000000000000000000000000000000 (1 spans)\n  span 0200000000000000 0s\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			exp := newDebugExporter(zap.New(core), &Config{Verbosity: tt.verbosity, Format: tt.format})
+			require.NoError(t, exp.pushTraces(context.Background(), td))
+			var messages []string
+			for _, entry := range logs.All() {
+				messages = append(messages, entry.Message)
+			}
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestFilterAndRateLimit(t *testing.T) {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET /a", "query", "GET /b", "GET /c"} {
+		spans.AppendEmpty().SetName(name)
+	}
+	core, logs := observer.New(zapcore.InfoLevel)
+	exp := newDebugExporter(zap.New(core), &Config{
+		Verbosity: configtelemetry.LevelBasic,
+		Format:    FormatText,
+		Filter:    FilterConfig{SpanNames: []filter.Config{{Regex: "^GET /"}}},
+		RateLimit: 2,
+	})
+
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	// The data is not modified.
+	assert.Equal(t, 4, td.SpanCount())
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, int64(2), logs.All()[0].ContextMap()["spans"])
+
+	// Nothing is logged once the rate limit is reached, or if no record matches the filter.
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	exp.limiter = nil
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, 1, logs.Len())
+}
+
 type testCase struct {
 	name   string
 	config *Config
@@ -120,6 +209,30 @@ func createTestCases() []testCase {
 				return cfg
 			}(),
 		},
+		{
+			name: "json format",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Format = FormatJSON
+				return cfg
+			}(),
+		},
+		{
+			name: "logfmt format",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Format = FormatLogfmt
+				return cfg
+			}(),
+		},
+		{
+			name: "tree format",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Format = FormatTree
+				return cfg
+			}(),
+		},
 	}
 }
 
@@ -44,6 +44,7 @@ func NewFactory() exporter.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		Verbosity:          configtelemetry.LevelBasic,
+		Format:             FormatText,
 		SamplingInitial:    defaultSamplingInitial,
 		SamplingThereafter: defaultSamplingThereafter,
 		UseInternalLogger:  true,
@@ -53,7 +54,7 @@ func createDefaultConfig() component.Config {
 func createTraces(ctx context.Context, set exporter.Settings, config component.Config) (exporter.Traces, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debug := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debug := newDebugExporter(exporterLogger, cfg)
 	return exporterhelper.NewTraces(ctx, set, config,
 		debug.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -65,7 +66,7 @@ func createTraces(ctx context.Context, set exporter.Settings, config component.C
 func createMetrics(ctx context.Context, set exporter.Settings, config component.Config) (exporter.Metrics, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debug := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debug := newDebugExporter(exporterLogger, cfg)
 	return exporterhelper.NewMetrics(ctx, set, config,
 		debug.pushMetrics,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -77,7 +78,7 @@ func createMetrics(ctx context.Context, set exporter.Settings, config component.
 func createLogs(ctx context.Context, set exporter.Settings, config component.Config) (exporter.Logs, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debug := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debug := newDebugExporter(exporterLogger, cfg)
 	return exporterhelper.NewLogs(ctx, set, config,
 		debug.pushLogs,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -89,7 +90,7 @@ func createLogs(ctx context.Context, set exporter.Settings, config component.Con
 func createProfiles(ctx context.Context, set exporter.Settings, config component.Config) (xexporter.Profiles, error) {
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
-	debug := newDebugExporter(exporterLogger, cfg.Verbosity)
+	debug := newDebugExporter(exporterLogger, cfg)
 	return xexporterhelper.NewProfilesExporter(ctx, set, config,
 		debug.pushProfiles,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
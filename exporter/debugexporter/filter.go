@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexporter"
+
+import (
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// dataFilter removes the telemetry records which do not match the filter configuration.
+type dataFilter struct {
+	resourceAttributes map[string]filter.Filter
+	// The filters are nil when not configured, matching all the records.
+	spanNames     filter.Filter
+	metricNames   filter.Filter
+	logSeverities filter.Filter
+}
+
+// newDataFilter returns nil if no filter is configured.
+func newDataFilter(cfg FilterConfig) *dataFilter {
+	if len(cfg.ResourceAttributes) == 0 && len(cfg.SpanNames) == 0 && len(cfg.MetricNames) == 0 && len(cfg.LogSeverities) == 0 {
+		return nil
+	}
+	df := &dataFilter{
+		resourceAttributes: make(map[string]filter.Filter, len(cfg.ResourceAttributes)),
+		spanNames:          createFilter(cfg.SpanNames),
+		metricNames:        createFilter(cfg.MetricNames),
+		logSeverities:      createFilter(cfg.LogSeverities),
+	}
+	for key, configs := range cfg.ResourceAttributes {
+		df.resourceAttributes[key] = filter.CreateFilter(configs)
+	}
+	return df
+}
+
+func createFilter(configs []filter.Config) filter.Filter {
+	if len(configs) == 0 {
+		return nil
+	}
+	return filter.CreateFilter(configs)
+}
+
+func matches(f filter.Filter, value string) bool {
+	return f == nil || f.Matches(value)
+}
+
+func (df *dataFilter) matchesResource(resource pcommon.Resource) bool {
+	for key, f := range df.resourceAttributes {
+		value, ok := resource.Attributes().Get(key)
+		if !ok || !f.Matches(value.AsString()) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTraces modifies the traces in place.
+func (df *dataFilter) filterTraces(td ptrace.Traces) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		if !df.matchesResource(rs.Resource()) {
+			return true
+		}
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !matches(df.spanNames, span.Name())
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+// filterMetrics modifies the metrics in place.
+func (df *dataFilter) filterMetrics(md pmetric.Metrics) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if !df.matchesResource(rm.Resource()) {
+			return true
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+				return !matches(df.metricNames, metric.Name())
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// filterLogs modifies the logs in place.
+func (df *dataFilter) filterLogs(ld plog.Logs) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		if !df.matchesResource(rl.Resource()) {
+			return true
+		}
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				severity := lr.SeverityText()
+				if severity == "" {
+					severity = lr.SeverityNumber().String()
+				}
+				return !matches(df.logSeverities, severity)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
+
+// filterProfiles modifies the profiles in place. The profiles are only filtered by resource attributes.
+func (df *dataFilter) filterProfiles(pd pprofile.Profiles) {
+	pd.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		return !df.matchesResource(rp.Resource())
+	})
+}
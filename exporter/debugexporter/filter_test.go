@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package debugexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewDataFilterNotConfigured(t *testing.T) {
+	assert.Nil(t, newDataFilter(FilterConfig{}))
+}
+
+func TestFilterTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"checkout", "payment-api", "cart"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		spans.AppendEmpty().SetName("GET /" + service)
+		spans.AppendEmpty().SetName("query")
+	}
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /unknown")
+
+	newDataFilter(FilterConfig{
+		ResourceAttributes: map[string][]filter.Config{
+			"service.name": {{Strict: "checkout"}, {Regex: "^payment-"}},
+		},
+		SpanNames: []filter.Config{{Regex: "^GET /"}},
+	}).filterTraces(td)
+
+	var names []string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		spans := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			names = append(names, spans.At(j).Name())
+		}
+	}
+	assert.Equal(t, []string{"GET /checkout", "GET /payment-api"}, names)
+}
+
+func TestFilterMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetName("http.server.duration")
+	metrics.AppendEmpty().SetName("process.cpu.time")
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("system.memory.usage")
+
+	newDataFilter(FilterConfig{
+		MetricNames: []filter.Config{{Strict: "http.server.duration"}},
+	}).filterMetrics(md)
+
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, 1, md.MetricCount())
+	assert.Equal(t, "http.server.duration", md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestFilterLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	logRecords := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	logRecords.AppendEmpty().SetSeverityText("INFO")
+	logRecords.AppendEmpty().SetSeverityText("ERROR")
+	// The name of the severity number is matched if the severity text is not set.
+	logRecords.AppendEmpty().SetSeverityNumber(plog.SeverityNumberWarn2)
+	logRecords.AppendEmpty().SetSeverityNumber(plog.SeverityNumberDebug)
+
+	newDataFilter(FilterConfig{
+		LogSeverities: []filter.Config{{Regex: "(?i)^(warn|error)"}},
+	}).filterLogs(ld)
+
+	assert.Equal(t, 2, ld.LogRecordCount())
+	assert.Equal(t, "ERROR", logRecords.At(0).SeverityText())
+	assert.Equal(t, plog.SeverityNumberWarn2, logRecords.At(1).SeverityNumber())
+}
+
+func TestFilterProfiles(t *testing.T) {
+	pd := pprofile.NewProfiles()
+	pd.ResourceProfiles().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	pd.ResourceProfiles().AppendEmpty().Resource().Attributes().PutStr("service.name", "cart")
+	pd.ResourceProfiles().AppendEmpty()
+
+	newDataFilter(FilterConfig{
+		ResourceAttributes: map[string][]filter.Config{"service.name": {{Strict: "checkout"}}},
+	}).filterProfiles(pd)
+
+	assert.Equal(t, 1, pd.ResourceProfiles().Len())
+	service, _ := pd.ResourceProfiles().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.Str())
+}
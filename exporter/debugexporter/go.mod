@@ -13,6 +13,7 @@ require (
 	go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper v0.117.0
 	go.opentelemetry.io/collector/exporter/exportertest v0.117.0
 	go.opentelemetry.io/collector/exporter/xexporter v0.117.0
+	go.opentelemetry.io/collector/filter v0.117.0
 	go.opentelemetry.io/collector/pdata v1.23.0
 	go.opentelemetry.io/collector/pdata/pprofile v0.117.0
 	go.opentelemetry.io/collector/pdata/testdata v0.117.0
@@ -39,6 +40,8 @@ require (
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/collector/client v1.23.0 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.117.0 // indirect
 	go.opentelemetry.io/collector/config/configretry v1.23.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumererror v0.117.0 // indirect
 	go.opentelemetry.io/collector/consumer/consumererror/xconsumererror v0.117.0 // indirect
@@ -68,6 +71,8 @@ require (
 
 replace go.opentelemetry.io/collector/component => ../../component
 
+replace go.opentelemetry.io/collector/component/componentstatus => ../../component/componentstatus
+
 replace go.opentelemetry.io/collector/component/componenttest => ../../component/componenttest
 
 replace go.opentelemetry.io/collector/confmap => ../../confmap
@@ -117,3 +122,7 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
+
+replace go.opentelemetry.io/collector/client => ../../client
+
+replace go.opentelemetry.io/collector/filter => ../../filter
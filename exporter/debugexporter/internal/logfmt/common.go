@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt // import "go.opentelemetry.io/collector/exporter/debugexporter/internal/logfmt"
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// lineWriter writes the key=value pairs of a record on a single line.
+type lineWriter struct {
+	buffer *bytes.Buffer
+	empty  bool
+}
+
+func newLine(buffer *bytes.Buffer) *lineWriter {
+	return &lineWriter{buffer: buffer, empty: true}
+}
+
+// pair writes a key=value pair, quoting the value if needed.
+func (l *lineWriter) pair(key string, value string) {
+	if !l.empty {
+		l.buffer.WriteByte(' ')
+	}
+	l.empty = false
+	l.buffer.WriteString(formatKey(key))
+	l.buffer.WriteByte('=')
+	l.buffer.WriteString(formatValue(value))
+}
+
+// timestamp writes the time in RFC 3339 format, unless it is not set.
+func (l *lineWriter) timestamp(key string, ts pcommon.Timestamp) {
+	if ts == 0 {
+		return
+	}
+	l.pair(key, ts.AsTime().UTC().Format(time.RFC3339Nano))
+}
+
+// attributes writes the attributes, with their keys prefixed.
+func (l *lineWriter) attributes(prefix string, attributes pcommon.Map) {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		l.pair(prefix+k, v.AsString())
+		return true
+	})
+}
+
+// scope writes the name and version of the instrumentation scope, if set.
+func (l *lineWriter) scope(scope pcommon.InstrumentationScope) {
+	if scope.Name() != "" {
+		l.pair("scope", scope.Name())
+	}
+	if scope.Version() != "" {
+		l.pair("scope_version", scope.Version())
+	}
+}
+
+// end terminates the line.
+func (l *lineWriter) end() {
+	l.buffer.WriteByte('\n')
+}
+
+// formatKey replaces the characters which cannot be part of a key.
+func formatKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// formatValue quotes the value if it is empty or contains spaces, quotes, equal signs or non printable characters.
+func formatValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if !utf8.ValidString(value) || strings.IndexFunc(value, func(r rune) bool {
+		return r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r)
+	}) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "", expected: `""`},
+		{value: "value", expected: "value"},
+		{value: "/api/v1?a:b", expected: "/api/v1?a:b"},
+		{value: "two words", expected: `"two words"`},
+		{value: "a=b", expected: `"a=b"`},
+		{value: `say "hi"`, expected: `"say \"hi\""`},
+		{value: "line\nbreak", expected: `"line\nbreak"`},
+		{value: "\xff", expected: `"\xff"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatValue(tt.value))
+		})
+	}
+}
+
+func TestFormatKey(t *testing.T) {
+	assert.Equal(t, "attr.http.method", formatKey("attr.http.method"))
+	assert.Equal(t, "attr.my_key_a_b", formatKey(`attr.my key=a"b`))
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt // import "go.opentelemetry.io/collector/exporter/debugexporter/internal/logfmt"
+
+import (
+	"bytes"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logfmtLogsMarshaler struct{}
+
+// Ensure logfmtLogsMarshaler implements interface plog.Marshaler
+var _ plog.Marshaler = logfmtLogsMarshaler{}
+
+// NewLogfmtLogsMarshaler returns a plog.Marshaler writing one line of logfmt per log record.
+func NewLogfmtLogsMarshaler() plog.Marshaler {
+	return logfmtLogsMarshaler{}
+}
+
+func (logfmtLogsMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	var buffer bytes.Buffer
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLogs := ld.ResourceLogs().At(i)
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logRecord := scopeLogs.LogRecords().At(k)
+				line := newLine(&buffer)
+				ts := logRecord.Timestamp()
+				if ts == 0 {
+					ts = logRecord.ObservedTimestamp()
+				}
+				line.timestamp("time", ts)
+				switch {
+				case logRecord.SeverityText() != "":
+					line.pair("severity", logRecord.SeverityText())
+				case logRecord.SeverityNumber() != plog.SeverityNumberUnspecified:
+					line.pair("severity", logRecord.SeverityNumber().String())
+				}
+				line.pair("body", logRecord.Body().AsString())
+				if !logRecord.TraceID().IsEmpty() {
+					line.pair("trace_id", logRecord.TraceID().String())
+				}
+				if !logRecord.SpanID().IsEmpty() {
+					line.pair("span_id", logRecord.SpanID().String())
+				}
+				line.attributes("attr.", logRecord.Attributes())
+				line.scope(scopeLogs.Scope())
+				line.attributes("resource.", resourceLogs.Resource().Attributes())
+				line.end()
+			}
+		}
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestMarshalLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    plog.Logs
+		expected string
+	}{
+		{
+			name:     "empty logs",
+			input:    plog.NewLogs(),
+			expected: "",
+		},
+		{
+			name: "two log records",
+			input: func() plog.Logs {
+				logs := plog.NewLogs()
+				resourceLogs := logs.ResourceLogs().AppendEmpty()
+				resourceLogs.Resource().Attributes().PutStr("service.name", "checkout")
+				logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+				logRecord := logRecords.AppendEmpty()
+				logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+				logRecord.SetSeverityText("WARN")
+				logRecord.SetSeverityNumber(plog.SeverityNumberWarn)
+				logRecord.Body().SetStr("cart is empty")
+				logRecord.SetTraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+				logRecord.SetSpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18})
+				logRecord.Attributes().PutStr("user", "alice")
+				// The observed time and the severity number are used if the time and the severity text are not set.
+				logRecord = logRecords.AppendEmpty()
+				logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC)))
+				logRecord.SetSeverityNumber(plog.SeverityNumberError)
+				logRecord.Body().SetEmptyMap().PutStr("event", "checkout")
+				return logs
+			}(),
+			expected: `time=2024-01-02T03:04:05Z severity=WARN body="cart is empty" trace_id=0102030405060708090a0b0c0d0e0f10 span_id=1112131415161718 attr.user=alice resource.service.name=checkout
+time=2024-01-02T03:04:06Z severity=Error body="{\"event\":\"checkout\"}" resource.service.name=checkout
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := NewLogfmtLogsMarshaler().MarshalLogs(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(output))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt // import "go.opentelemetry.io/collector/exporter/debugexporter/internal/logfmt"
+
+import (
+	"bytes"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type logfmtMetricsMarshaler struct{}
+
+// Ensure logfmtMetricsMarshaler implements interface pmetric.Marshaler
+var _ pmetric.Marshaler = logfmtMetricsMarshaler{}
+
+// NewLogfmtMetricsMarshaler returns a pmetric.Marshaler writing one line of logfmt per data point.
+func NewLogfmtMetricsMarshaler() pmetric.Marshaler {
+	return logfmtMetricsMarshaler{}
+}
+
+func (logfmtMetricsMarshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	var buffer bytes.Buffer
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		resourceMetrics := md.ResourceMetrics().At(i)
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := resourceMetrics.ScopeMetrics().At(j)
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				metric := scopeMetrics.Metrics().At(k)
+				// writeDataPoint writes a line with the fields common to all the data points around the values.
+				writeDataPoint := func(ts pcommon.Timestamp, attributes pcommon.Map, values func(line *lineWriter)) {
+					line := newLine(&buffer)
+					line.pair("metric", metric.Name())
+					line.pair("type", metric.Type().String())
+					if metric.Unit() != "" {
+						line.pair("unit", metric.Unit())
+					}
+					line.timestamp("time", ts)
+					values(line)
+					line.attributes("attr.", attributes)
+					line.scope(scopeMetrics.Scope())
+					line.attributes("resource.", resourceMetrics.Resource().Attributes())
+					line.end()
+				}
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					writeNumberDataPoints(metric.Gauge().DataPoints(), writeDataPoint)
+				case pmetric.MetricTypeSum:
+					writeNumberDataPoints(metric.Sum().DataPoints(), writeDataPoint)
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < metric.Histogram().DataPoints().Len(); l++ {
+						dataPoint := metric.Histogram().DataPoints().At(l)
+						writeDataPoint(dataPoint.Timestamp(), dataPoint.Attributes(), func(line *lineWriter) {
+							line.pair("count", strconv.FormatUint(dataPoint.Count(), 10))
+							if dataPoint.HasSum() {
+								line.pair("sum", formatFloat(dataPoint.Sum()))
+							}
+							if dataPoint.HasMin() {
+								line.pair("min", formatFloat(dataPoint.Min()))
+							}
+							if dataPoint.HasMax() {
+								line.pair("max", formatFloat(dataPoint.Max()))
+							}
+						})
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for l := 0; l < metric.ExponentialHistogram().DataPoints().Len(); l++ {
+						dataPoint := metric.ExponentialHistogram().DataPoints().At(l)
+						writeDataPoint(dataPoint.Timestamp(), dataPoint.Attributes(), func(line *lineWriter) {
+							line.pair("count", strconv.FormatUint(dataPoint.Count(), 10))
+							if dataPoint.HasSum() {
+								line.pair("sum", formatFloat(dataPoint.Sum()))
+							}
+							if dataPoint.HasMin() {
+								line.pair("min", formatFloat(dataPoint.Min()))
+							}
+							if dataPoint.HasMax() {
+								line.pair("max", formatFloat(dataPoint.Max()))
+							}
+						})
+					}
+				case pmetric.MetricTypeSummary:
+					for l := 0; l < metric.Summary().DataPoints().Len(); l++ {
+						dataPoint := metric.Summary().DataPoints().At(l)
+						writeDataPoint(dataPoint.Timestamp(), dataPoint.Attributes(), func(line *lineWriter) {
+							line.pair("count", strconv.FormatUint(dataPoint.Count(), 10))
+							line.pair("sum", formatFloat(dataPoint.Sum()))
+						})
+					}
+				}
+			}
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeNumberDataPoints(dataPoints pmetric.NumberDataPointSlice, writeDataPoint func(pcommon.Timestamp, pcommon.Map, func(*lineWriter))) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dataPoint := dataPoints.At(i)
+		writeDataPoint(dataPoint.Timestamp(), dataPoint.Attributes(), func(line *lineWriter) {
+			switch dataPoint.ValueType() {
+			case pmetric.NumberDataPointValueTypeInt:
+				line.pair("value", strconv.FormatInt(dataPoint.IntValue(), 10))
+			case pmetric.NumberDataPointValueTypeDouble:
+				line.pair("value", formatFloat(dataPoint.DoubleValue()))
+			}
+		})
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMarshalMetrics(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	tests := []struct {
+		name     string
+		input    pmetric.Metrics
+		expected string
+	}{
+		{
+			name:     "empty metrics",
+			input:    pmetric.NewMetrics(),
+			expected: "",
+		},
+		{
+			name: "all metric types",
+			input: func() pmetric.Metrics {
+				metrics := pmetric.NewMetrics()
+				resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+				resourceMetrics.Resource().Attributes().PutStr("service.name", "checkout")
+				ms := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics()
+
+				gauge := ms.AppendEmpty()
+				gauge.SetName("cpu")
+				gauge.SetUnit("1")
+				dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(0.5)
+				dp.Attributes().PutStr("core", "0")
+
+				sum := ms.AppendEmpty()
+				sum.SetName("requests")
+				sum.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(42)
+
+				histogram := ms.AppendEmpty()
+				histogram.SetName("latency")
+				hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+				hdp.SetCount(3)
+				hdp.SetSum(1.5)
+				hdp.SetMin(0.25)
+				hdp.SetMax(1)
+
+				exponentialHistogram := ms.AppendEmpty()
+				exponentialHistogram.SetName("size")
+				edp := exponentialHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+				edp.SetCount(2)
+				edp.SetSum(10)
+
+				summary := ms.AppendEmpty()
+				summary.SetName("duration")
+				sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+				sdp.SetCount(4)
+				sdp.SetSum(8)
+				return metrics
+			}(),
+			expected: `metric=cpu type=Gauge unit=1 time=2024-01-02T03:04:05Z value=0.5 attr.core=0 resource.service.name=checkout
+metric=requests type=Sum value=42 resource.service.name=checkout
+metric=latency type=Histogram count=3 sum=1.5 min=0.25 max=1 resource.service.name=checkout
+metric=size type=ExponentialHistogram count=2 sum=10 resource.service.name=checkout
+metric=duration type=Summary count=4 sum=8 resource.service.name=checkout
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := NewLogfmtMetricsMarshaler().MarshalMetrics(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(output))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt // import "go.opentelemetry.io/collector/exporter/debugexporter/internal/logfmt"
+
+import (
+	"bytes"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+type logfmtProfilesMarshaler struct{}
+
+// Ensure logfmtProfilesMarshaler implements interface pprofile.Marshaler
+var _ pprofile.Marshaler = logfmtProfilesMarshaler{}
+
+// NewLogfmtProfilesMarshaler returns a pprofile.Marshaler writing one line of logfmt per profile.
+func NewLogfmtProfilesMarshaler() pprofile.Marshaler {
+	return logfmtProfilesMarshaler{}
+}
+
+func (logfmtProfilesMarshaler) MarshalProfiles(pd pprofile.Profiles) ([]byte, error) {
+	var buffer bytes.Buffer
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		resourceProfiles := pd.ResourceProfiles().At(i)
+		for j := 0; j < resourceProfiles.ScopeProfiles().Len(); j++ {
+			scopeProfiles := resourceProfiles.ScopeProfiles().At(j)
+			for k := 0; k < scopeProfiles.Profiles().Len(); k++ {
+				profile := scopeProfiles.Profiles().At(k)
+				line := newLine(&buffer)
+				line.pair("profile_id", profile.ProfileID().String())
+				line.timestamp("time", profile.Time())
+				line.pair("samples", strconv.Itoa(profile.Sample().Len()))
+				for _, index := range profile.AttributeIndices().AsRaw() {
+					if int(index) < profile.AttributeTable().Len() {
+						attribute := profile.AttributeTable().At(int(index))
+						line.pair("attr."+attribute.Key(), attribute.Value().AsString())
+					}
+				}
+				line.scope(scopeProfiles.Scope())
+				line.attributes("resource.", resourceProfiles.Resource().Attributes())
+				line.end()
+			}
+		}
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt // import "go.opentelemetry.io/collector/exporter/debugexporter/internal/logfmt"
+
+import (
+	"bytes"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type logfmtTracesMarshaler struct{}
+
+// Ensure logfmtTracesMarshaler implements interface ptrace.Marshaler
+var _ ptrace.Marshaler = logfmtTracesMarshaler{}
+
+// NewLogfmtTracesMarshaler returns a ptrace.Marshaler writing one line of logfmt per span.
+func NewLogfmtTracesMarshaler() ptrace.Marshaler {
+	return logfmtTracesMarshaler{}
+}
+
+func (logfmtTracesMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	var buffer bytes.Buffer
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		resourceSpans := td.ResourceSpans().At(i)
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			scopeSpans := resourceSpans.ScopeSpans().At(j)
+			for k := 0; k < scopeSpans.Spans().Len(); k++ {
+				span := scopeSpans.Spans().At(k)
+				line := newLine(&buffer)
+				line.pair("name", span.Name())
+				line.pair("trace_id", span.TraceID().String())
+				line.pair("span_id", span.SpanID().String())
+				if !span.ParentSpanID().IsEmpty() {
+					line.pair("parent_span_id", span.ParentSpanID().String())
+				}
+				line.pair("kind", span.Kind().String())
+				line.timestamp("start", span.StartTimestamp())
+				if span.EndTimestamp() >= span.StartTimestamp() {
+					line.pair("duration", time.Duration(span.EndTimestamp()-span.StartTimestamp()).String())
+				}
+				if span.Status().Code() != ptrace.StatusCodeUnset {
+					line.pair("status", span.Status().Code().String())
+				}
+				if span.Status().Message() != "" {
+					line.pair("status_message", span.Status().Message())
+				}
+				line.attributes("attr.", span.Attributes())
+				line.scope(scopeSpans.Scope())
+				line.attributes("resource.", resourceSpans.Resource().Attributes())
+				line.end()
+			}
+		}
+	}
+	return buffer.Bytes(), nil
+}
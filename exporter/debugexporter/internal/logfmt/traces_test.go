@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestMarshalTraces(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ptrace.Traces
+		expected string
+	}{
+		{
+			name:     "empty traces",
+			input:    ptrace.NewTraces(),
+			expected: "",
+		},
+		{
+			name: "two spans",
+			input: func() ptrace.Traces {
+				traces := ptrace.NewTraces()
+				resourceSpans := traces.ResourceSpans().AppendEmpty()
+				resourceSpans.Resource().Attributes().PutStr("service.name", "checkout")
+				scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+				scopeSpans.Scope().SetName("tracer")
+				scopeSpans.Scope().SetVersion("1.0.0")
+				start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+				span := scopeSpans.Spans().AppendEmpty()
+				span.SetName("GET /cart")
+				span.SetTraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+				span.SetSpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18})
+				span.SetKind(ptrace.SpanKindServer)
+				span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+				span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(1500 * time.Microsecond)))
+				span.Attributes().PutInt("http.status_code", 500)
+				span.Status().SetCode(ptrace.StatusCodeError)
+				span.Status().SetMessage("internal error")
+				child := scopeSpans.Spans().AppendEmpty()
+				child.SetName("query")
+				child.SetTraceID(span.TraceID())
+				child.SetSpanID([8]byte{0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28})
+				child.SetParentSpanID(span.SpanID())
+				return traces
+			}(),
+			expected: `name="GET /cart" trace_id=0102030405060708090a0b0c0d0e0f10 span_id=1112131415161718 kind=Server start=2024-01-02T03:04:05Z duration=1.5ms status=Error status_message="internal error" attr.http.status_code=500 scope=tracer scope_version=1.0.0 resource.service.name=checkout
+name=query trace_id=0102030405060708090a0b0c0d0e0f10 span_id=2122232425262728 parent_span_id=1112131415161718 kind=Unspecified duration=0s scope=tracer scope_version=1.0.0 resource.service.name=checkout
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := NewLogfmtTracesMarshaler().MarshalTraces(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(output))
+		})
+	}
+}
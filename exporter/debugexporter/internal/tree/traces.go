@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tree // import "go.opentelemetry.io/collector/exporter/debugexporter/internal/tree"
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type treeTracesMarshaler struct{}
+
+// Ensure treeTracesMarshaler implements interface ptrace.Marshaler
+var _ ptrace.Marshaler = treeTracesMarshaler{}
+
+// NewTreeTracesMarshaler returns a ptrace.Marshaler writing the spans grouped by trace,
+// each span being indented under its parent span.
+func NewTreeTracesMarshaler() ptrace.Marshaler {
+	return treeTracesMarshaler{}
+}
+
+// node is a span of a trace, with the service of its resource.
+type node struct {
+	span    ptrace.Span
+	service string
+}
+
+// trace holds the spans of a trace, in the order they are received.
+type trace struct {
+	id    pcommon.TraceID
+	nodes []node
+}
+
+func (treeTracesMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	var traces []*trace
+	traceByID := map[pcommon.TraceID]*trace{}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		resourceSpans := td.ResourceSpans().At(i)
+		var service string
+		if v, ok := resourceSpans.Resource().Attributes().Get("service.name"); ok {
+			service = v.AsString()
+		}
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				t, ok := traceByID[span.TraceID()]
+				if !ok {
+					t = &trace{id: span.TraceID()}
+					traceByID[span.TraceID()] = t
+					traces = append(traces, t)
+				}
+				t.nodes = append(t.nodes, node{span: span, service: service})
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	for _, t := range traces {
+		writeTrace(&buffer, t)
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeTrace(buffer *bytes.Buffer, t *trace) {
+	fmt.Fprintf(buffer, "Trace %s (%d spans)\n", t.id, len(t.nodes))
+
+	spanIDs := map[pcommon.SpanID]bool{}
+	for _, n := range t.nodes {
+		spanIDs[n.span.SpanID()] = true
+	}
+	// The spans are listed under their parent by start time, the spans without a parent in the batch being roots.
+	var roots []int
+	children := map[pcommon.SpanID][]int{}
+	for i, n := range t.nodes {
+		parentID := n.span.ParentSpanID()
+		if parentID.IsEmpty() || !spanIDs[parentID] {
+			roots = append(roots, i)
+			continue
+		}
+		children[parentID] = append(children[parentID], i)
+	}
+	byStart := func(indexes []int) {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return t.nodes[indexes[a]].span.StartTimestamp() < t.nodes[indexes[b]].span.StartTimestamp()
+		})
+	}
+	byStart(roots)
+	for _, indexes := range children {
+		byStart(indexes)
+	}
+
+	written := make([]bool, len(t.nodes))
+	var writeSpan func(index int, depth int)
+	writeSpan = func(index int, depth int) {
+		if written[index] {
+			return
+		}
+		written[index] = true
+		writeNode(buffer, t.nodes[index], depth)
+		for _, child := range children[t.nodes[index].span.SpanID()] {
+			writeSpan(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		writeSpan(root, 1)
+	}
+	// The spans whose parents form a cycle are not reachable from a root.
+	for i := range t.nodes {
+		writeSpan(i, 1)
+	}
+}
+
+func writeNode(buffer *bytes.Buffer, n node, depth int) {
+	span := n.span
+	buffer.WriteString(strings.Repeat("  ", depth))
+	buffer.WriteString(span.Name())
+	buffer.WriteString(" ")
+	buffer.WriteString(span.SpanID().String())
+	buffer.WriteString(" ")
+	var duration time.Duration
+	if span.EndTimestamp() > span.StartTimestamp() {
+		duration = time.Duration(span.EndTimestamp() - span.StartTimestamp())
+	}
+	buffer.WriteString(duration.String())
+	if n.service != "" {
+		buffer.WriteString(" service=")
+		buffer.WriteString(n.service)
+	}
+	if span.Status().Code() == ptrace.StatusCodeError {
+		buffer.WriteString(" status=Error")
+	}
+	if parentID := span.ParentSpanID(); depth == 1 && !parentID.IsEmpty() {
+		buffer.WriteString(" parent=")
+		buffer.WriteString(parentID.String())
+	}
+	buffer.WriteString("\n")
+}
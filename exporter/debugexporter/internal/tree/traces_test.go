@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	traceID1 = pcommon.TraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+	traceID2 = pcommon.TraceID([16]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E, 0x1F, 0x20})
+)
+
+func appendSpan(spans ptrace.SpanSlice, traceID pcommon.TraceID, name string, id byte, parentID byte, start time.Duration, duration time.Duration) ptrace.Span {
+	span := spans.AppendEmpty()
+	span.SetName(name)
+	span.SetTraceID(traceID)
+	span.SetSpanID([8]byte{id})
+	if parentID != 0 {
+		span.SetParentSpanID([8]byte{parentID})
+	}
+	span.SetStartTimestamp(pcommon.Timestamp(start))
+	span.SetEndTimestamp(pcommon.Timestamp(start + duration))
+	return span
+}
+
+func TestMarshalTraces(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ptrace.Traces
+		expected string
+	}{
+		{
+			name:     "empty traces",
+			input:    ptrace.NewTraces(),
+			expected: "",
+		},
+		{
+			name: "spans of two traces",
+			input: func() ptrace.Traces {
+				traces := ptrace.NewTraces()
+				frontend := traces.ResourceSpans().AppendEmpty()
+				frontend.Resource().Attributes().PutStr("service.name", "frontend")
+				spans := frontend.ScopeSpans().AppendEmpty().Spans()
+				appendSpan(spans, traceID1, "GET /checkout", 1, 0, 0, 50*time.Millisecond)
+				appendSpan(spans, traceID2, "GET /health", 5, 0, 0, time.Millisecond)
+				appendSpan(spans, traceID1, "render", 2, 1, 30*time.Millisecond, 20*time.Millisecond)
+				backend := traces.ResourceSpans().AppendEmpty()
+				backend.Resource().Attributes().PutStr("service.name", "backend")
+				spans = backend.ScopeSpans().AppendEmpty().Spans()
+				appendSpan(spans, traceID1, "checkout", 3, 1, 5*time.Millisecond, 20*time.Millisecond)
+				query := appendSpan(spans, traceID1, "query", 4, 3, 10*time.Millisecond, 5*time.Millisecond)
+				query.Status().SetCode(ptrace.StatusCodeError)
+				return traces
+			}(),
+			expected: `Trace 0102030405060708090a0b0c0d0e0f10 (4 spans)
+  GET /checkout 0100000000000000 50ms service=frontend
+    checkout 0300000000000000 20ms service=backend
+      query 0400000000000000 5ms service=backend status=Error
+    render 0200000000000000 20ms service=frontend
+Trace 1112131415161718191a1b1c1d1e1f20 (1 spans)
+  GET /health 0500000000000000 1ms service=frontend
+`,
+		},
+		{
+			name: "spans without parent in the batch",
+			input: func() ptrace.Traces {
+				traces := ptrace.NewTraces()
+				spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+				appendSpan(spans, traceID1, "orphan", 2, 9, 0, time.Second)
+				appendSpan(spans, traceID1, "child", 3, 2, 0, 0)
+				// The spans of a cycle are written as roots.
+				appendSpan(spans, traceID1, "cycle-a", 4, 5, 0, time.Second)
+				appendSpan(spans, traceID1, "cycle-b", 5, 4, 0, time.Second)
+				return traces
+			}(),
+			expected: `Trace 0102030405060708090a0b0c0d0e0f10 (4 spans)
+  orphan 0200000000000000 1s parent=0900000000000000
+    child 0300000000000000 0s
+  cycle-a 0400000000000000 1s parent=0500000000000000
+    cycle-b 0500000000000000 1s
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := NewTreeTracesMarshaler().MarshalTraces(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(output))
+		})
+	}
+}
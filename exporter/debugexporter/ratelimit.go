@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package debugexporter // import "go.opentelemetry.io/collector/exporter/debugexporter"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// rateLimiter limits the number of records output per second, allowing bursts of up to one second of records.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newRateLimiter returns nil if the rate is not limited.
+func newRateLimiter(rate int) *rateLimiter {
+	if rate == 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		now:    time.Now,
+	}
+}
+
+// take returns how many of the n records can be output.
+func (l *rateLimiter) take(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	allowed := min(n, int(l.tokens))
+	l.tokens -= float64(allowed)
+	return allowed
+}
+
+// budget is the number of records which can still be output.
+type budget int
+
+// take returns true if a record of the given size fits in the budget, consuming it.
+func (b *budget) take(size int) bool {
+	if size > int(*b) {
+		return false
+	}
+	*b -= budget(size)
+	return true
+}
+
+// limitTraces keeps the first n spans of the traces.
+func limitTraces(td ptrace.Traces, n int) {
+	b := budget(n)
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(ptrace.Span) bool {
+				return !b.take(1)
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+// limitMetrics keeps the first n data points of the metrics.
+func limitMetrics(md pmetric.Metrics, n int) {
+	b := budget(n)
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+				return limitDataPoints(metric, &b)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// limitDataPoints removes the data points of the metric beyond the budget,
+// returning true if all the data points of the metric are removed.
+func limitDataPoints(metric pmetric.Metric, b *budget) bool {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return limitSlice(metric.Gauge().DataPoints(), b)
+	case pmetric.MetricTypeSum:
+		return limitSlice(metric.Sum().DataPoints(), b)
+	case pmetric.MetricTypeHistogram:
+		return limitSlice(metric.Histogram().DataPoints(), b)
+	case pmetric.MetricTypeExponentialHistogram:
+		return limitSlice(metric.ExponentialHistogram().DataPoints(), b)
+	case pmetric.MetricTypeSummary:
+		return limitSlice(metric.Summary().DataPoints(), b)
+	}
+	return false
+}
+
+func limitSlice[T any](dataPoints interface {
+	Len() int
+	RemoveIf(func(T) bool)
+}, b *budget,
+) bool {
+	if dataPoints.Len() == 0 {
+		return false
+	}
+	dataPoints.RemoveIf(func(T) bool {
+		return !b.take(1)
+	})
+	return dataPoints.Len() == 0
+}
+
+// limitLogs keeps the first n log records of the logs.
+func limitLogs(ld plog.Logs, n int) {
+	b := budget(n)
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+				return !b.take(1)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
+
+// limitProfiles keeps the profiles whose samples fit in the first n samples, profiles being kept or removed as a whole.
+func limitProfiles(pd pprofile.Profiles, n int) {
+	b := budget(n)
+	pd.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		rp.ScopeProfiles().RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+			sp.Profiles().RemoveIf(func(profile pprofile.Profile) bool {
+				return !b.take(profile.Sample().Len())
+			})
+			return sp.Profiles().Len() == 0
+		})
+		return rp.ScopeProfiles().Len() == 0
+	})
+}
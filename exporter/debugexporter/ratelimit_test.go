@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package debugexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestRateLimiter(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0))
+
+	now := time.Now()
+	l := newRateLimiter(10)
+	l.now = func() time.Time { return now }
+	// Up to one second of records is output at once.
+	assert.Equal(t, 8, l.take(8))
+	assert.Equal(t, 2, l.take(5))
+	assert.Equal(t, 0, l.take(1))
+
+	now = now.Add(300 * time.Millisecond)
+	assert.Equal(t, 3, l.take(5))
+
+	// The unused records do not accumulate beyond one second.
+	now = now.Add(time.Hour)
+	assert.Equal(t, 10, l.take(20))
+}
+
+func TestLimitTraces(t *testing.T) {
+	td := testdata.GenerateTraces(5)
+	limitTraces(td, 3)
+	assert.Equal(t, 3, td.SpanCount())
+
+	limitTraces(td, 0)
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestLimitMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsAllTypes()
+	dataPoints := md.DataPointCount()
+	limitMetrics(md, dataPoints-3)
+	assert.Equal(t, dataPoints-3, md.DataPointCount())
+
+	// The metrics without data points are kept, unless their data points are all removed.
+	md = pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyGauge()
+	metrics.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	limitMetrics(md, 0)
+	assert.Equal(t, 1, md.MetricCount())
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics.At(0).Type())
+}
+
+func TestLimitLogs(t *testing.T) {
+	ld := testdata.GenerateLogs(5)
+	limitLogs(ld, 2)
+	assert.Equal(t, 2, ld.LogRecordCount())
+
+	limitLogs(ld, 0)
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+}
+
+func TestLimitProfiles(t *testing.T) {
+	pd := pprofile.NewProfiles()
+	profiles := pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles()
+	for _, samples := range []int{2, 3, 1} {
+		profile := profiles.AppendEmpty()
+		for i := 0; i < samples; i++ {
+			profile.Sample().AppendEmpty()
+		}
+	}
+
+	// The profiles are kept as a whole, if all their samples fit in the limit.
+	limitProfiles(pd, 4)
+	assert.Equal(t, 2, profiles.Len())
+	assert.Equal(t, 3, pd.SampleCount())
+}
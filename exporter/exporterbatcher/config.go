@@ -5,11 +5,13 @@ package exporterbatcher // import "go.opentelemetry.io/collector/exporter/export
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
-// Config defines a configuration for batching requests based on a timeout and a minimum number of items.
-// MaxSizeItems defines batch splitting functionality if it's more than zero.
+// Config defines a configuration for batching requests based on a timeout and a minimum number of items or bytes.
+// MaxSizeItems or MaxSizeBytes defines batch splitting functionality if it's more than zero.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 type Config struct {
@@ -21,9 +23,12 @@ type Config struct {
 
 	MinSizeConfig `mapstructure:",squash"`
 	MaxSizeConfig `mapstructure:",squash"`
+
+	// Partition defines how the requests are grouped into separate batches.
+	Partition PartitionConfig `mapstructure:"partition"`
 }
 
-// MinSizeConfig defines the configuration for the minimum number of items in a batch.
+// MinSizeConfig defines the configuration for the minimum number of items or bytes in a batch.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 type MinSizeConfig struct {
@@ -31,9 +36,15 @@ type MinSizeConfig struct {
 	// sent regardless of the timeout. There is no guarantee that the batch size always greater than this value.
 	// This option requires the Request to implement RequestItemsCounter interface. Otherwise, it will be ignored.
 	MinSizeItems int `mapstructure:"min_size_items"`
+
+	// MinSizeBytes is the serialized size in bytes at which the batch should be sent regardless of the timeout.
+	// If greater than zero, it takes precedence over MinSizeItems.
+	// This option requires the Request to implement RequestByteSizer interface. Otherwise, the batch is only
+	// sent on timeout.
+	MinSizeBytes int `mapstructure:"min_size_bytes"`
 }
 
-// MaxSizeConfig defines the configuration for the maximum number of items in a batch.
+// MaxSizeConfig defines the configuration for the maximum number of items or bytes in a batch.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 type MaxSizeConfig struct {
@@ -41,6 +52,33 @@ type MaxSizeConfig struct {
 	// If the batch size exceeds this value, it will be broken up into smaller batches if possible.
 	// Setting this value to zero disables the maximum size limit.
 	MaxSizeItems int `mapstructure:"max_size_items"`
+
+	// MaxSizeBytes is the maximum serialized size of the batch in bytes, e.g. the OTLP protobuf size for OTLP.
+	// If the batch size exceeds this value, it will be broken up into smaller batches if possible.
+	// If greater than zero, it takes precedence over MaxSizeItems.
+	// Setting this value to zero disables the maximum size limit in bytes.
+	MaxSizeBytes int `mapstructure:"max_size_bytes"`
+}
+
+// PartitionConfig defines the configuration for grouping requests into separate batches, so that requests with
+// different partition values, e.g. from different tenants, are never mixed in the same batch.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type PartitionConfig struct {
+	// MetadataKeys is a list of client.Metadata keys used to partition the requests. Each distinct combination
+	// of values for these keys is batched separately, and the batches are exported with a context carrying only
+	// these metadata keys. The keys are case-insensitive.
+	// Requires the sending queue to keep the request context, so it cannot be used with the persistent queue.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// ResourceAttributes is a list of resource attribute keys used to partition the requests. Each distinct
+	// combination of values for these attributes is batched separately.
+	// This option requires the Request to implement RequestResourcePartitioner interface. Otherwise, it will be ignored.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// CardinalityLimit is the maximum number of partitions batched at the same time. When the limit is reached,
+	// the oldest pending batch is sent to make room for a new partition. Setting this value to zero disables the limit.
+	CardinalityLimit int `mapstructure:"cardinality_limit"`
 }
 
 func (c Config) Validate() error {
@@ -53,12 +91,48 @@ func (c Config) Validate() error {
 	if c.MaxSizeItems != 0 && c.MaxSizeItems < c.MinSizeItems {
 		return errors.New("max_size_items must be greater than or equal to min_size_items")
 	}
+	if c.MinSizeBytes < 0 {
+		return errors.New("min_size_bytes must be greater than or equal to zero")
+	}
+	if c.MaxSizeBytes < 0 {
+		return errors.New("max_size_bytes must be greater than or equal to zero")
+	}
+	if c.MaxSizeBytes != 0 && c.MaxSizeBytes < c.MinSizeBytes {
+		return errors.New("max_size_bytes must be greater than or equal to min_size_bytes")
+	}
 	if c.FlushTimeout <= 0 {
 		return errors.New("timeout must be greater than zero")
 	}
+	return c.Partition.Validate()
+}
+
+func (c PartitionConfig) Validate() error {
+	if c.CardinalityLimit < 0 {
+		return errors.New("partition::cardinality_limit must be greater than or equal to zero")
+	}
+	uniq := map[string]struct{}{}
+	for _, k := range c.MetadataKeys {
+		l := strings.ToLower(k)
+		if _, has := uniq[l]; has {
+			return fmt.Errorf("partition::metadata_keys contains duplicate key %q", k)
+		}
+		uniq[l] = struct{}{}
+	}
+	uniq = map[string]struct{}{}
+	for _, k := range c.ResourceAttributes {
+		if _, has := uniq[k]; has {
+			return fmt.Errorf("partition::resource_attributes contains duplicate key %q", k)
+		}
+		uniq[k] = struct{}{}
+	}
 	return nil
 }
 
+// Enabled returns true if any partitioning key is configured.
+func (c PartitionConfig) Enabled() bool {
+	return len(c.MetadataKeys) > 0 || len(c.ResourceAttributes) > 0
+}
+
 func NewDefaultConfig() Config {
 	return Config{
 		Enabled:      true,
@@ -28,4 +28,43 @@ func TestConfig_Validate(t *testing.T) {
 	cfg.MaxSizeItems = 20000
 	cfg.MinSizeItems = 20001
 	assert.EqualError(t, cfg.Validate(), "max_size_items must be greater than or equal to min_size_items")
+
+	cfg = NewDefaultConfig()
+	cfg.MinSizeBytes = -1
+	require.EqualError(t, cfg.Validate(), "min_size_bytes must be greater than or equal to zero")
+
+	cfg = NewDefaultConfig()
+	cfg.MaxSizeBytes = -1
+	require.EqualError(t, cfg.Validate(), "max_size_bytes must be greater than or equal to zero")
+
+	cfg = NewDefaultConfig()
+	cfg.MaxSizeBytes = 4 << 20
+	cfg.MinSizeBytes = 4<<20 + 1
+	require.EqualError(t, cfg.Validate(), "max_size_bytes must be greater than or equal to min_size_bytes")
+
+	// Byte-based limits are validated independently of the item-based ones.
+	cfg = NewDefaultConfig()
+	cfg.MaxSizeBytes = 4 << 20
+	cfg.MinSizeBytes = 1 << 20
+	assert.NoError(t, cfg.Validate())
+
+	cfg = NewDefaultConfig()
+	cfg.Partition.CardinalityLimit = -1
+	require.EqualError(t, cfg.Validate(), "partition::cardinality_limit must be greater than or equal to zero")
+
+	cfg = NewDefaultConfig()
+	cfg.Partition.MetadataKeys = []string{"tenant", "Tenant"}
+	require.EqualError(t, cfg.Validate(), "partition::metadata_keys contains duplicate key \"Tenant\"")
+
+	cfg = NewDefaultConfig()
+	cfg.Partition.ResourceAttributes = []string{"service.name", "service.name"}
+	require.EqualError(t, cfg.Validate(), "partition::resource_attributes contains duplicate key \"service.name\"")
+
+	cfg = NewDefaultConfig()
+	assert.False(t, cfg.Partition.Enabled())
+	cfg.Partition.MetadataKeys = []string{"tenant"}
+	cfg.Partition.ResourceAttributes = []string{"service.name"}
+	cfg.Partition.CardinalityLimit = 10
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.Partition.Enabled())
 }
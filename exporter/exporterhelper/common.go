@@ -39,6 +39,12 @@ func WithRetry(config configretry.BackOffConfig) Option {
 	return internal.WithRetry(config)
 }
 
+// WithCircuitBreaker enables the circuit breaker stopping the export attempts when too many of them fail.
+// The default CircuitBreakerConfig is to disable the circuit breaker.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return internal.WithCircuitBreaker(config)
+}
+
 // WithQueue overrides the default QueueConfig for an exporter.
 // The default QueueConfig is to disable queueing.
 // This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
@@ -54,6 +60,13 @@ func WithRequestQueue(cfg exporterqueue.Config, queueFactory exporterqueue.Facto
 	return internal.WithRequestQueue(cfg, queueFactory)
 }
 
+// WithDeadLetter enables storing the requests that failed permanently, or after all the retries, in a storage extension.
+// The stored requests can be sent again through the exporter by enabling DeadLetterConfig.ReplayOnStart.
+// This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
+func WithDeadLetter(config DeadLetterConfig) Option {
+	return internal.WithDeadLetter(config)
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -0,0 +1,14 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+// DeadLetterConfig defines configuration for storing the requests that failed permanently.
+type DeadLetterConfig = internal.DeadLetterConfig
+
+// NewDefaultDeadLetterConfig returns the default config for DeadLetterConfig.
+func NewDefaultDeadLetterConfig() DeadLetterConfig {
+	return internal.NewDefaultDeadLetterConfig()
+}
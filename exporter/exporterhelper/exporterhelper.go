@@ -17,3 +17,15 @@ type Request = internal.Request
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 type RequestErrorHandler = internal.RequestErrorHandler
+
+// RequestByteSizer is an optional interface that can be implemented by Request to report its serialized size in bytes.
+// It is required for the byte-based limits of the batcher and the "bytes" sizer of the queue.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestByteSizer = internal.RequestByteSizer
+
+// RequestResourcePartitioner is an optional interface that can be implemented by Request to split it by the values
+// of resource attributes. It is required to partition the batches by resource attributes.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestResourcePartitioner = internal.RequestResourcePartitioner
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// latencyWeight is the weight of a new export latency in the average latency used as a baseline.
+const latencyWeight = 0.01
+
+// AdaptiveConcurrencyConfig defines the adaptive concurrency of the exports from the queue.
+// When enabled, the number of concurrent exports is adapted between MinConsumers and `num_consumers`:
+// it grows by one every time as many exports as the current limit succeed, and is multiplied by DecreaseRatio
+// when an export fails with a retryable error or takes longer than LatencyTolerance times the average latency.
+type AdaptiveConcurrencyConfig struct {
+	// Enabled indicates whether to adapt the number of concurrent exports.
+	Enabled bool `mapstructure:"enabled"`
+	// MinConsumers is the minimum number of concurrent exports, and the initial one.
+	MinConsumers int `mapstructure:"min_consumers"`
+	// DecreaseRatio is the ratio applied to the number of concurrent exports when the backend is overloaded.
+	DecreaseRatio float64 `mapstructure:"decrease_ratio"`
+	// LatencyTolerance is the ratio to the average export latency above which the backend is considered overloaded.
+	LatencyTolerance float64 `mapstructure:"latency_tolerance"`
+}
+
+// NewDefaultAdaptiveConcurrencyConfig returns the default config for AdaptiveConcurrencyConfig.
+func NewDefaultAdaptiveConcurrencyConfig() AdaptiveConcurrencyConfig {
+	return AdaptiveConcurrencyConfig{
+		Enabled:          false,
+		MinConsumers:     1,
+		DecreaseRatio:    0.5,
+		LatencyTolerance: 2,
+	}
+}
+
+// Validate checks if the AdaptiveConcurrencyConfig configuration is valid.
+func (cfg *AdaptiveConcurrencyConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MinConsumers <= 0 {
+		return errors.New("`min_consumers` must be positive")
+	}
+	if cfg.DecreaseRatio <= 0 || cfg.DecreaseRatio >= 1 {
+		return errors.New("`decrease_ratio` must be greater than 0 and less than 1")
+	}
+	if cfg.LatencyTolerance <= 1 {
+		return errors.New("`latency_tolerance` must be greater than 1")
+	}
+	return nil
+}
+
+// adaptiveConcurrency limits the number of concurrent exports with an additive increase, multiplicative
+// decrease (AIMD) algorithm.
+type adaptiveConcurrency struct {
+	cfg            AdaptiveConcurrencyConfig
+	maxConcurrency int
+
+	// stopped is closed on shutdown to stop limiting the exports, so the queue can be drained.
+	stopped  chan struct{}
+	stopOnce sync.Once
+
+	mu sync.Mutex
+	// slotFreed is closed and replaced every time an export finishes.
+	slotFreed  chan struct{}
+	limit      float64
+	inFlight   int
+	avgLatency time.Duration
+}
+
+func newAdaptiveConcurrency(cfg AdaptiveConcurrencyConfig, maxConcurrency int) *adaptiveConcurrency {
+	ac := &adaptiveConcurrency{
+		cfg:            cfg,
+		maxConcurrency: maxConcurrency,
+		limit:          float64(min(cfg.MinConsumers, maxConcurrency)),
+		stopped:        make(chan struct{}),
+		slotFreed:      make(chan struct{}),
+	}
+	return ac
+}
+
+// export calls exportFunc once the number of in-flight exports is below the current limit,
+// and updates the limit with the result of the export. It returns the context error if the context
+// is done before a slot is available.
+func (ac *adaptiveConcurrency) export(ctx context.Context, exportFunc func(context.Context) error) error {
+	if err := ac.acquire(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err := exportFunc(ctx)
+	ac.onExportFinished(time.Since(start), err)
+	return err
+}
+
+// acquire waits until the number of in-flight exports is below the current limit, or the limiter is stopped.
+func (ac *adaptiveConcurrency) acquire(ctx context.Context) error {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	for ac.inFlight >= int(ac.limit) {
+		slotFreed := ac.slotFreed
+		ac.mu.Unlock()
+		select {
+		case <-slotFreed:
+		case <-ac.stopped:
+			ac.mu.Lock()
+			ac.inFlight++
+			return nil
+		case <-ctx.Done():
+			ac.mu.Lock()
+			return ctx.Err()
+		}
+		ac.mu.Lock()
+	}
+	ac.inFlight++
+	return nil
+}
+
+func (ac *adaptiveConcurrency) onExportFinished(latency time.Duration, err error) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.inFlight--
+	close(ac.slotFreed)
+	ac.slotFreed = make(chan struct{})
+
+	// Permanent errors are caused by the data, not by an overloaded backend.
+	overloaded := err != nil && !consumererror.IsPermanent(err)
+	if ac.avgLatency == 0 {
+		ac.avgLatency = latency
+	} else {
+		overloaded = overloaded || float64(latency) > ac.cfg.LatencyTolerance*float64(ac.avgLatency)
+		ac.avgLatency += time.Duration(latencyWeight * float64(latency-ac.avgLatency))
+	}
+
+	if overloaded {
+		ac.limit = max(ac.limit*ac.cfg.DecreaseRatio, float64(ac.cfg.MinConsumers))
+		return
+	}
+	if err == nil {
+		ac.limit = min(ac.limit+1/ac.limit, float64(ac.maxConcurrency))
+	}
+}
+
+// stop stops limiting the exports, the waiting exports are started.
+func (ac *adaptiveConcurrency) stop() {
+	ac.stopOnce.Do(func() { close(ac.stopped) })
+}
+
+// Limit returns the current maximum number of concurrent exports.
+func (ac *adaptiveConcurrency) Limit() int64 {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return int64(ac.limit)
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestAdaptiveConcurrencyConfig_Validate(t *testing.T) {
+	cfg := NewDefaultAdaptiveConcurrencyConfig()
+	require.NoError(t, cfg.Validate())
+
+	// Disabled configs are not validated.
+	cfg.MinConsumers = 0
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.EqualError(t, cfg.Validate(), "`min_consumers` must be positive")
+
+	cfg = NewDefaultAdaptiveConcurrencyConfig()
+	cfg.Enabled = true
+	cfg.DecreaseRatio = 1
+	require.EqualError(t, cfg.Validate(), "`decrease_ratio` must be greater than 0 and less than 1")
+
+	cfg = NewDefaultAdaptiveConcurrencyConfig()
+	cfg.Enabled = true
+	cfg.LatencyTolerance = 1
+	require.EqualError(t, cfg.Validate(), "`latency_tolerance` must be greater than 1")
+}
+
+func TestQueueConfig_ValidateAdaptiveConcurrency(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.AdaptiveConcurrency.Enabled = true
+	require.NoError(t, qCfg.Validate())
+
+	qCfg.AdaptiveConcurrency.DecreaseRatio = 0
+	require.ErrorContains(t, qCfg.Validate(), "`adaptive_concurrency` is invalid")
+
+	qCfg.AdaptiveConcurrency.DecreaseRatio = 0.5
+	qCfg.AdaptiveConcurrency.MinConsumers = qCfg.NumConsumers + 1
+	require.EqualError(t, qCfg.Validate(), "`adaptive_concurrency::min_consumers` must not be greater than `num_consumers`")
+}
+
+func TestAdaptiveConcurrency_IncreaseAndDecrease(t *testing.T) {
+	cfg := NewDefaultAdaptiveConcurrencyConfig()
+	cfg.Enabled = true
+	ac := newAdaptiveConcurrency(cfg, 4)
+	assert.Equal(t, int64(1), ac.Limit())
+
+	// The limit grows by one every time as many exports as the limit succeed.
+	ac.inFlight++
+	ac.onExportFinished(time.Millisecond, nil)
+	assert.Equal(t, int64(2), ac.Limit())
+	for i := 0; i < 2; i++ {
+		ac.inFlight++
+		ac.onExportFinished(time.Millisecond, nil)
+	}
+	assert.Equal(t, int64(2), ac.Limit())
+
+	// The limit never exceeds the number of consumers.
+	for i := 0; i < 10; i++ {
+		ac.inFlight++
+		ac.onExportFinished(time.Millisecond, nil)
+	}
+	assert.Equal(t, int64(4), ac.Limit())
+
+	// Retryable errors decrease the limit.
+	ac.inFlight++
+	ac.onExportFinished(time.Millisecond, errors.New("unavailable"))
+	assert.Equal(t, int64(2), ac.Limit())
+
+	// Permanent errors do not change the limit.
+	ac.inFlight++
+	ac.onExportFinished(time.Millisecond, consumererror.NewPermanent(errors.New("bad data")))
+	assert.Equal(t, int64(2), ac.Limit())
+
+	// Slow exports decrease the limit, down to the minimum.
+	for i := 0; i < 3; i++ {
+		ac.inFlight++
+		ac.onExportFinished(time.Second, nil)
+	}
+	assert.Equal(t, int64(1), ac.Limit())
+	assert.Equal(t, 0, ac.inFlight)
+}
+
+func TestAdaptiveConcurrency_ExportWaitsForSlot(t *testing.T) {
+	cfg := NewDefaultAdaptiveConcurrencyConfig()
+	cfg.Enabled = true
+	ac := newAdaptiveConcurrency(cfg, 4)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	exportFunc := func(context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, ac.export(context.Background(), exportFunc))
+		}()
+	}
+
+	// Only one export is allowed at the start.
+	<-started
+	select {
+	case <-started:
+		t.Fatal("second export must wait for a slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+	wg.Wait()
+	assert.Equal(t, int64(2), ac.Limit())
+}
+
+func TestAdaptiveConcurrency_ExportWaitStops(t *testing.T) {
+	cfg := NewDefaultAdaptiveConcurrencyConfig()
+	cfg.Enabled = true
+	ac := newAdaptiveConcurrency(cfg, 4)
+
+	release := make(chan struct{})
+	go func() {
+		assert.NoError(t, ac.export(context.Background(), func(context.Context) error {
+			<-release
+			return nil
+		}))
+	}()
+	require.Eventually(t, func() bool {
+		ac.mu.Lock()
+		defer ac.mu.Unlock()
+		return ac.inFlight == 1
+	}, time.Second, time.Millisecond)
+
+	// The wait for a slot returns once the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	exported := false
+	require.ErrorIs(t, ac.export(ctx, func(context.Context) error {
+		exported = true
+		return nil
+	}), context.DeadlineExceeded)
+	assert.False(t, exported)
+
+	// Once stopped, the exports don't wait for a slot.
+	ac.stop()
+	require.NoError(t, ac.export(context.Background(), func(context.Context) error {
+		exported = true
+		return nil
+	}))
+	assert.True(t, exported)
+	close(release)
+}
+
+func TestQueuedRetry_AdaptiveConcurrencyMetricReported(t *testing.T) {
+	runTest := func(testName string, enableQueueBatcher bool) {
+		t.Run(testName, func(t *testing.T) {
+			defer setFeatureGateForTest(t, usePullingBasedExporterQueueBatcher, enableQueueBatcher)()
+			tt, err := componenttest.SetupTelemetry(defaultID)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+			qCfg := NewDefaultQueueConfig()
+			qCfg.AdaptiveConcurrency.Enabled = true
+			qCfg.AdaptiveConcurrency.MinConsumers = 2
+			set := exporter.Settings{ID: defaultID, TelemetrySettings: tt.TelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()}
+			be, err := NewBaseExporter(set, pipeline.SignalLogs, newObservabilityConsumerSender,
+				WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithRetry(configretry.NewDefaultBackOffConfig()), WithQueue(qCfg))
+			require.NoError(t, err)
+			require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+			require.NoError(t, tt.CheckExporterMetricGauge("otelcol_exporter_queue_concurrency", int64(2),
+				attribute.String(DataTypeKey, pipeline.SignalLogs.String())))
+
+			require.NoError(t, be.Shutdown(context.Background()))
+			require.Error(t, tt.CheckExporterMetricGauge("otelcol_exporter_queue_concurrency", int64(2),
+				attribute.String(DataTypeKey, pipeline.SignalLogs.String())))
+		})
+	}
+	runTest("enable_queue_batcher", true)
+	runTest("disable_queue_batcher", false)
+}
@@ -53,17 +53,24 @@ type BaseExporter struct {
 	// Chain of senders that the exporter helper applies before passing the data to the actual exporter.
 	// The data is handled by each sender in the respective order starting from the queueSender.
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
-	BatchSender   Sender[internal.Request]
-	QueueSender   Sender[internal.Request]
-	ObsrepSender  Sender[internal.Request]
-	RetrySender   Sender[internal.Request]
-	TimeoutSender *TimeoutSender // TimeoutSender is always initialized.
+	BatchSender          Sender[internal.Request]
+	QueueSender          Sender[internal.Request]
+	ObsrepSender         Sender[internal.Request]
+	DeadLetterSender     Sender[internal.Request]
+	RetrySender          Sender[internal.Request]
+	CircuitBreakerSender Sender[internal.Request]
+	TimeoutSender        *TimeoutSender // TimeoutSender is always initialized.
 
 	ConsumerOptions []consumer.Option
 
 	queueCfg     exporterqueue.Config
 	queueFactory exporterqueue.Factory[internal.Request]
-	BatcherCfg   exporterbatcher.Config
+	// queueStorageID is the storage of the persistent queue, nil if the queue is not persisted.
+	queueStorageID *component.ID
+	// concurrencyCfg is the adaptive concurrency of the exports from the queue.
+	concurrencyCfg AdaptiveConcurrencyConfig
+	BatcherCfg     exporterbatcher.Config
+	deadLetterCfg  DeadLetterConfig
 }
 
 func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, osf ObsrepSenderFactory, options ...Option) (*BaseExporter, error) {
@@ -73,11 +80,13 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, osf ObsrepSe
 	}
 
 	be := &BaseExporter{
-		BatchSender:   &BaseSender[internal.Request]{},
-		QueueSender:   &BaseSender[internal.Request]{},
-		ObsrepSender:  osf(obsReport),
-		RetrySender:   &BaseSender[internal.Request]{},
-		TimeoutSender: &TimeoutSender{cfg: NewDefaultTimeoutConfig()},
+		BatchSender:          &BaseSender[internal.Request]{},
+		QueueSender:          &BaseSender[internal.Request]{},
+		ObsrepSender:         osf(obsReport),
+		DeadLetterSender:     &BaseSender[internal.Request]{},
+		RetrySender:          &BaseSender[internal.Request]{},
+		CircuitBreakerSender: &BaseSender[internal.Request]{},
+		TimeoutSender:        &TimeoutSender{cfg: NewDefaultTimeoutConfig()},
 
 		Set:    set,
 		Obsrep: obsReport,
@@ -90,6 +99,21 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, osf ObsrepSe
 		return nil, err
 	}
 
+	if be.BatcherCfg.Enabled && be.BatcherCfg.Partition.Enabled() &&
+		(!usePullingBasedExporterQueueBatcher.IsEnabled() || !be.queueCfg.Enabled) {
+		return nil, errors.New("batcher partitioning requires the sending queue to be enabled and the " +
+			usePullingBasedExporterQueueBatcher.ID() + " feature gate")
+	}
+
+	// The persistent queue does not keep the request context, the client metadata is lost.
+	if be.BatcherCfg.Enabled && len(be.BatcherCfg.Partition.MetadataKeys) > 0 && be.queueStorageID != nil {
+		return nil, errors.New("batcher partitioning by `metadata_keys` is not supported with the persistent queue `storage`")
+	}
+
+	if be.deadLetterCfg.Enabled {
+		be.DeadLetterSender = newDeadLetterSender(be.deadLetterCfg, signal, be.Set, be.Marshaler, be.Unmarshaler)
+	}
+
 	if be.queueCfg.Enabled {
 		q := be.queueFactory(
 			context.Background(),
@@ -98,7 +122,7 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, osf ObsrepSe
 				ExporterSettings: be.Set,
 			},
 			be.queueCfg)
-		be.QueueSender = NewQueueSender(q, be.Set, be.queueCfg.NumConsumers, be.ExportFailureMessage, be.Obsrep, be.BatcherCfg)
+		be.QueueSender = NewQueueSender(q, be.Set, be.queueCfg.NumConsumers, be.concurrencyCfg, be.ExportFailureMessage, be.Obsrep, be.BatcherCfg)
 	}
 
 	if !usePullingBasedExporterQueueBatcher.IsEnabled() && be.BatcherCfg.Enabled ||
@@ -135,8 +159,10 @@ func (be *BaseExporter) Send(ctx context.Context, req internal.Request) error {
 func (be *BaseExporter) connectSenders() {
 	be.QueueSender.SetNextSender(be.BatchSender)
 	be.BatchSender.SetNextSender(be.ObsrepSender)
-	be.ObsrepSender.SetNextSender(be.RetrySender)
-	be.RetrySender.SetNextSender(be.TimeoutSender)
+	be.ObsrepSender.SetNextSender(be.DeadLetterSender)
+	be.DeadLetterSender.SetNextSender(be.RetrySender)
+	be.RetrySender.SetNextSender(be.CircuitBreakerSender)
+	be.CircuitBreakerSender.SetNextSender(be.TimeoutSender)
 }
 
 func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
@@ -145,23 +171,49 @@ func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
-	// If no error then start the BatchSender.
+	// If no error then start the CircuitBreakerSender.
+	if err := be.CircuitBreakerSender.Start(ctx, host); err != nil {
+		return err
+	}
+
+	// Then start the DeadLetterSender.
+	if err := be.DeadLetterSender.Start(ctx, host); err != nil {
+		return err
+	}
+
+	// Then start the BatchSender.
 	if err := be.BatchSender.Start(ctx, host); err != nil {
 		return err
 	}
 
 	// Last start the queueSender.
-	return be.QueueSender.Start(ctx, host)
+	if err := be.QueueSender.Start(ctx, host); err != nil {
+		return err
+	}
+
+	// Once all the senders are started, replay the requests from the dead letter storage if configured.
+	if dls, ok := be.DeadLetterSender.(*deadLetterSender); ok {
+		dls.startReplay(be.Send)
+	}
+	return nil
 }
 
 func (be *BaseExporter) Shutdown(ctx context.Context) error {
+	// First stop replaying the requests from the dead letter storage, so nothing is sent to the stopped senders.
+	if dls, ok := be.DeadLetterSender.(*deadLetterSender); ok {
+		dls.shutdownReplay()
+	}
 	return multierr.Combine(
 		// First shutdown the retry sender, so the queue sender can flush the queue without retries.
 		be.RetrySender.Shutdown(ctx),
+		// Then shutdown the circuit breaker sender, so the requests waiting for the circuit to close are returned.
+		be.CircuitBreakerSender.Shutdown(ctx),
 		// Then shutdown the batch sender
 		be.BatchSender.Shutdown(ctx),
 		// Then shutdown the queue sender.
 		be.QueueSender.Shutdown(ctx),
+		// Then shutdown the dead letter sender, after the queue is drained.
+		be.DeadLetterSender.Shutdown(ctx),
 		// Last shutdown the wrapped exporter itself.
 		be.ShutdownFunc.Shutdown(ctx))
 }
@@ -201,7 +253,19 @@ func WithRetry(config configretry.BackOffConfig) Option {
 			o.ExportFailureMessage += " Try enabling retry_on_failure config option to retry on retryable errors."
 			return nil
 		}
-		o.RetrySender = newRetrySender(config, o.Set)
+		o.RetrySender = newRetrySender(config, o.Set, o.Obsrep)
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables the circuit breaker stopping the export attempts when too many of them fail.
+// The default CircuitBreakerConfig is to disable the circuit breaker.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(o *BaseExporter) error {
+		if !config.Enabled {
+			return nil
+		}
+		o.CircuitBreakerSender = newCircuitBreakerSender(config, o.Set)
 		return nil
 	}
 }
@@ -222,8 +286,20 @@ func WithQueue(config QueueConfig) Option {
 			Enabled:      config.Enabled,
 			NumConsumers: config.NumConsumers,
 			QueueSize:    config.QueueSize,
+			Sizer:        config.Sizer,
 			Blocking:     config.Blocking,
 		}
+		o.concurrencyCfg = config.AdaptiveConcurrency
+		if config.Priority.Enabled() {
+			// The default lane, for the requests matching no configured lane, has the lowest priority.
+			o.queueFactory = exporterqueue.NewPriorityMemoryQueueFactory[internal.Request](exporterqueue.PriorityQueueSettings[internal.Request]{
+				Lanes:           len(config.Priority.Lanes) + 1,
+				Selector:        newPrioritySelector(config.Priority),
+				StarvationLimit: config.Priority.StarvationLimit,
+			})
+			return nil
+		}
+		o.queueStorageID = config.StorageID
 		o.queueFactory = exporterqueue.NewPersistentQueueFactory[internal.Request](config.StorageID, exporterqueue.PersistentQueueSettings[internal.Request]{
 			Marshaler:   o.Marshaler,
 			Unmarshaler: o.Unmarshaler,
@@ -251,6 +327,18 @@ func WithRequestQueue(cfg exporterqueue.Config, queueFactory exporterqueue.Facto
 	}
 }
 
+// WithDeadLetter enables storing the requests that failed permanently, or after all the retries, in a storage extension.
+// This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
+func WithDeadLetter(config DeadLetterConfig) Option {
+	return func(o *BaseExporter) error {
+		if o.Marshaler == nil || o.Unmarshaler == nil {
+			return errors.New("WithDeadLetter option is not available for the new request exporters")
+		}
+		o.deadLetterCfg = config
+		return nil
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/internal"
@@ -115,3 +116,42 @@ func TestBaseExporterLogging(t *testing.T) {
 	runTest("enable_queue_batcher", true)
 	runTest("disable_queue_batcher", false)
 }
+
+func TestBaseExporterPartitionedBatching(t *testing.T) {
+	bCfg := exporterbatcher.NewDefaultConfig()
+	bCfg.Partition.MetadataKeys = []string{"tenant"}
+	runTest := func(testName string, enableQueueBatcher bool) {
+		t.Run(testName, func(t *testing.T) {
+			defer setFeatureGateForTest(t, usePullingBasedExporterQueueBatcher, enableQueueBatcher)()
+			_, err := NewBaseExporter(defaultSettings, defaultSignal, newNoopObsrepSender,
+				WithBatcher(bCfg))
+			require.ErrorContains(t, err, "batcher partitioning requires the sending queue")
+
+			be, err := NewBaseExporter(defaultSettings, defaultSignal, newNoopObsrepSender,
+				WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithQueue(NewDefaultQueueConfig()), WithBatcher(bCfg))
+			if enableQueueBatcher {
+				require.NoError(t, err)
+				require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+				require.NoError(t, be.Shutdown(context.Background()))
+			} else {
+				require.ErrorContains(t, err, "batcher partitioning requires the sending queue")
+			}
+
+			// The persistent queue does not keep the client metadata.
+			qCfg := NewDefaultQueueConfig()
+			storageID := component.MustNewIDWithName("file_storage", "storage")
+			qCfg.StorageID = &storageID
+			_, err = NewBaseExporter(defaultSettings, defaultSignal, newNoopObsrepSender,
+				WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithQueue(qCfg), WithBatcher(bCfg))
+			if enableQueueBatcher {
+				require.ErrorContains(t, err, "`metadata_keys` is not supported with the persistent queue")
+			} else {
+				require.ErrorContains(t, err, "batcher partitioning requires the sending queue")
+			}
+		})
+	}
+	runTest("enable_queue_batcher", true)
+	runTest("disable_queue_batcher", false)
+}
@@ -19,7 +19,7 @@ import (
 
 // BatchSender is a component that places requests into batches before passing them to the downstream senders.
 // Batches are sent out with any of the following conditions:
-// - batch size reaches cfg.MinSizeItems
+// - batch size reaches cfg.MinSizeItems or cfg.MinSizeBytes
 // - cfg.FlushTimeout is elapsed since the timestamp when the previous batch was sent out.
 // - concurrencyLimit is reached.
 type BatchSender struct {
@@ -131,7 +131,7 @@ func (bs *BatchSender) exportActiveBatch() {
 // The batch is ready if it has reached the minimum size or the concurrency limit is reached.
 // Caller must hold the lock.
 func (bs *BatchSender) isActiveBatchReady() bool {
-	return bs.activeBatch.request.ItemsCount() >= bs.cfg.MinSizeItems ||
+	return internal.MinSizeReached(bs.cfg.MinSizeConfig, bs.activeBatch.request) ||
 		(bs.concurrencyLimit > 0 && bs.activeRequests.Load() >= bs.concurrencyLimit)
 }
 
@@ -141,7 +141,7 @@ func (bs *BatchSender) Send(ctx context.Context, req internal.Request) error {
 		return bs.NextSender.Send(ctx, req)
 	}
 
-	if bs.cfg.MaxSizeItems > 0 {
+	if bs.cfg.MaxSizeItems > 0 || bs.cfg.MaxSizeBytes > 0 {
 		return bs.sendMergeSplitBatch(ctx, req)
 	}
 	return bs.sendMergeBatch(ctx, req)
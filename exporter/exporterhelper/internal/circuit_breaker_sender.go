@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
+)
+
+var errCircuitOpen = errors.New("the circuit breaker is open")
+
+// CircuitBreakerConfig defines configuration for the circuit breaker stopping the exports to a failing destination.
+type CircuitBreakerConfig struct {
+	// Enabled indicates whether to stop the exports when too many of them fail.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureRatio is the ratio of failed export attempts within Window above which the circuit opens.
+	FailureRatio float64 `mapstructure:"failure_ratio"`
+	// MinAttempts is the minimum number of export attempts within Window for the circuit to open.
+	MinAttempts int `mapstructure:"min_attempts"`
+	// Window is the duration of the window in which the export attempts are counted.
+	Window time.Duration `mapstructure:"window"`
+	// OpenDuration is the time the circuit stays open before letting probe export attempts through.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+	// HalfOpenAttempts is the number of probe export attempts that must succeed to close the circuit.
+	HalfOpenAttempts int `mapstructure:"half_open_attempts"`
+	// FailFast if true, the export attempts are rejected while the circuit is open. Otherwise, they wait for the
+	// circuit to close, which keeps the data in the sending queue.
+	FailFast bool `mapstructure:"fail_fast"`
+}
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+func NewDefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Enabled:          false,
+		FailureRatio:     0.5,
+		MinAttempts:      10,
+		Window:           10 * time.Second,
+		OpenDuration:     30 * time.Second,
+		HalfOpenAttempts: 1,
+		FailFast:         true,
+	}
+}
+
+// Validate checks if the CircuitBreakerConfig configuration is valid.
+func (cfg *CircuitBreakerConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FailureRatio <= 0 || cfg.FailureRatio > 1 {
+		return errors.New("`failure_ratio` must be greater than 0 and not greater than 1")
+	}
+	if cfg.MinAttempts <= 0 {
+		return errors.New("`min_attempts` must be positive")
+	}
+	if cfg.Window <= 0 {
+		return errors.New("`window` must be positive")
+	}
+	if cfg.OpenDuration <= 0 {
+		return errors.New("`open_duration` must be positive")
+	}
+	if cfg.HalfOpenAttempts <= 0 {
+		return errors.New("`half_open_attempts` must be positive")
+	}
+	return nil
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreakerSender is a requestSender stopping the export attempts when too many of them fail,
+// until probe export attempts succeed.
+type circuitBreakerSender struct {
+	BaseSender[internal.Request]
+	cfg      CircuitBreakerConfig
+	logger   *zap.Logger
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	// host is used to report the status changes, set when the sender starts.
+	host component.Host
+
+	mu    sync.Mutex
+	state circuitState
+	// changed is closed and replaced every time the state changes, to wake up the waiting export attempts.
+	changed chan struct{}
+	// windowStart, attempts and failures count the export attempts of the current window in the closed state.
+	windowStart time.Time
+	attempts    int
+	failures    int
+	// openUntil is the time after which the open circuit lets probe export attempts through.
+	openUntil time.Time
+	// probes and probeSuccesses count the probe export attempts in the half-open state.
+	probes         int
+	probeSuccesses int
+}
+
+func newCircuitBreakerSender(cfg CircuitBreakerConfig, set exporter.Settings) *circuitBreakerSender {
+	return &circuitBreakerSender{
+		cfg:         cfg,
+		logger:      set.Logger,
+		stopCh:      make(chan struct{}),
+		changed:     make(chan struct{}),
+		windowStart: time.Now(),
+	}
+}
+
+func (cbs *circuitBreakerSender) Start(_ context.Context, host component.Host) error {
+	cbs.host = host
+	return nil
+}
+
+func (cbs *circuitBreakerSender) Shutdown(context.Context) error {
+	cbs.stopOnce.Do(func() { close(cbs.stopCh) })
+	return nil
+}
+
+// Send implements the requestSender interface
+func (cbs *circuitBreakerSender) Send(ctx context.Context, req internal.Request) error {
+	for {
+		allowed, changed, wait := cbs.allow()
+		if allowed {
+			break
+		}
+		if cbs.cfg.FailFast {
+			return errCircuitOpen
+		}
+
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("request is cancelled or timed out while the circuit breaker is open: %w", ctx.Err())
+		case <-cbs.stopCh:
+			return experr.NewShutdownErr(errCircuitOpen)
+		case <-changed:
+		case <-timer:
+		}
+	}
+
+	err := cbs.NextSender.Send(ctx, req)
+	cbs.onAttemptFinished(err)
+	return err
+}
+
+// allow returns whether an export attempt is allowed. Otherwise, it returns the channel closed on the next state
+// change and the time after which the circuit lets probe export attempts through, if any.
+func (cbs *circuitBreakerSender) allow() (bool, <-chan struct{}, time.Duration) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	switch cbs.state {
+	case circuitOpen:
+		if wait := time.Until(cbs.openUntil); wait > 0 {
+			return false, cbs.changed, wait
+		}
+		cbs.setState(circuitHalfOpen)
+		cbs.probes, cbs.probeSuccesses = 0, 0
+		fallthrough
+	case circuitHalfOpen:
+		if cbs.probes >= cbs.cfg.HalfOpenAttempts {
+			return false, cbs.changed, 0
+		}
+		cbs.probes++
+	}
+	return true, nil, 0
+}
+
+func (cbs *circuitBreakerSender) onAttemptFinished(err error) {
+	// Permanent errors are caused by the data, not by a failing destination.
+	failed := err != nil && !consumererror.IsPermanent(err)
+
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	switch cbs.state {
+	case circuitClosed:
+		if now := time.Now(); now.Sub(cbs.windowStart) > cbs.cfg.Window {
+			cbs.windowStart, cbs.attempts, cbs.failures = now, 0, 0
+		}
+		cbs.attempts++
+		if !failed {
+			return
+		}
+		cbs.failures++
+		if cbs.attempts >= cbs.cfg.MinAttempts && float64(cbs.failures) >= cbs.cfg.FailureRatio*float64(cbs.attempts) {
+			cbs.open(err)
+		}
+	case circuitHalfOpen:
+		if failed {
+			cbs.open(err)
+			return
+		}
+		cbs.probeSuccesses++
+		if cbs.probeSuccesses >= cbs.cfg.HalfOpenAttempts {
+			cbs.windowStart, cbs.attempts, cbs.failures = time.Now(), 0, 0
+			cbs.setState(circuitClosed)
+			cbs.logger.Info("Exporting succeeded again, closing the circuit breaker.")
+			componentstatus.ReportStatus(cbs.host, componentstatus.NewEvent(componentstatus.StatusOK))
+		}
+	case circuitOpen:
+		// Attempts started before the circuit opened do not change the state.
+	}
+}
+
+func (cbs *circuitBreakerSender) open(err error) {
+	cbs.openUntil = time.Now().Add(cbs.cfg.OpenDuration)
+	cbs.setState(circuitOpen)
+	cbs.logger.Warn("Too many exports failed, opening the circuit breaker.",
+		zap.Error(err), zap.Duration("open_duration", cbs.cfg.OpenDuration))
+	componentstatus.ReportStatus(cbs.host, componentstatus.NewRecoverableErrorEvent(fmt.Errorf("%w: %w", errCircuitOpen, err)))
+}
+
+func (cbs *circuitBreakerSender) setState(state circuitState) {
+	cbs.state = state
+	close(cbs.changed)
+	cbs.changed = make(chan struct{})
+}
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
+)
+
+func TestCircuitBreakerConfig_Validate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	require.NoError(t, cfg.Validate())
+
+	// Disabled configs are not validated.
+	cfg.FailureRatio = 0
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.EqualError(t, cfg.Validate(), "`failure_ratio` must be greater than 0 and not greater than 1")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinAttempts = 0
+	require.EqualError(t, cfg.Validate(), "`min_attempts` must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.Window = 0
+	require.EqualError(t, cfg.Validate(), "`window` must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.OpenDuration = 0
+	require.EqualError(t, cfg.Validate(), "`open_duration` must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.HalfOpenAttempts = 0
+	require.EqualError(t, cfg.Validate(), "`half_open_attempts` must be positive")
+}
+
+func TestCircuitBreakerSender_OpenAndClose(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinAttempts = 4
+	cfg.OpenDuration = 50 * time.Millisecond
+	host := &statusHost{}
+	next := &fakeFailingSender{}
+	cbs := newCircuitBreakerSender(cfg, defaultSettings)
+	cbs.SetNextSender(next)
+	require.NoError(t, cbs.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, cbs.Shutdown(context.Background())) })
+
+	// Permanent errors do not count as failures.
+	next.setError(consumererror.NewPermanent(errors.New("bad data")))
+	for i := 0; i < 4; i++ {
+		require.Error(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+	}
+	assert.Equal(t, circuitClosed, cbs.state)
+
+	// The circuit opens once half of the attempts failed.
+	next.setError(errors.New("unavailable"))
+	for i := 0; i < 4; i++ {
+		require.Error(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+	}
+	assert.Equal(t, circuitOpen, cbs.state)
+	assert.Equal(t, 8, next.calls)
+	require.Len(t, host.events(), 1)
+	assert.Equal(t, componentstatus.StatusRecoverableError, host.events()[0].Status())
+	require.ErrorIs(t, host.events()[0].Err(), errCircuitOpen)
+
+	// The open circuit fails fast, without sending the requests.
+	require.ErrorIs(t, cbs.Send(context.Background(), newMockRequest(1, nil)), errCircuitOpen)
+	assert.Equal(t, 8, next.calls)
+
+	// A failed probe opens the circuit again.
+	time.Sleep(cfg.OpenDuration)
+	require.Error(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+	assert.Equal(t, circuitOpen, cbs.state)
+	assert.Equal(t, 9, next.calls)
+	require.Len(t, host.events(), 2)
+
+	// A successful probe closes the circuit.
+	time.Sleep(cfg.OpenDuration)
+	next.setError(nil)
+	require.NoError(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+	assert.Equal(t, circuitClosed, cbs.state)
+	require.Len(t, host.events(), 3)
+	assert.Equal(t, componentstatus.StatusOK, host.events()[2].Status())
+}
+
+func TestCircuitBreakerSender_Wait(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinAttempts = 1
+	cfg.OpenDuration = 50 * time.Millisecond
+	cfg.FailFast = false
+	next := &fakeFailingSender{}
+	cbs := newCircuitBreakerSender(cfg, defaultSettings)
+	cbs.SetNextSender(next)
+	require.NoError(t, cbs.Start(context.Background(), componenttest.NewNopHost()))
+
+	next.setError(errors.New("unavailable"))
+	require.Error(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+	assert.Equal(t, circuitOpen, cbs.state)
+
+	// The requests wait for the circuit to let them through instead of failing.
+	next.setError(nil)
+	start := time.Now()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, 40*time.Millisecond, time.Since(start))
+	assert.Equal(t, 4, next.calls)
+
+	// The waiting requests are returned on cancellation and on shutdown.
+	cbs.cfg.OpenDuration = time.Hour
+	next.setError(errors.New("unavailable"))
+	for cbs.state == circuitClosed {
+		require.Error(t, cbs.Send(context.Background(), newMockRequest(1, nil)))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, cbs.Send(ctx, newMockRequest(1, nil)), context.Canceled)
+	require.NoError(t, cbs.Shutdown(context.Background()))
+	err := cbs.Send(context.Background(), newMockRequest(1, nil))
+	require.ErrorIs(t, err, errCircuitOpen)
+	assert.True(t, experr.IsShutdownErr(err))
+	// Shutting down again is a no-op.
+	require.NoError(t, cbs.Shutdown(context.Background()))
+}
+
+func TestQueuedRetry_CircuitBreaker(t *testing.T) {
+	runTest := func(testName string, enableQueueBatcher bool) {
+		t.Run(testName, func(t *testing.T) {
+			resetFeatureGate := setFeatureGateForTest(t, usePullingBasedExporterQueueBatcher, enableQueueBatcher)
+			qCfg := NewDefaultQueueConfig()
+			qCfg.NumConsumers = 1
+			cbCfg := NewDefaultCircuitBreakerConfig()
+			cbCfg.Enabled = true
+			cbCfg.MinAttempts = 1
+			cbCfg.OpenDuration = time.Hour
+			be, err := NewBaseExporter(defaultSettings, defaultSignal, newObservabilityConsumerSender,
+				WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithQueue(qCfg), WithCircuitBreaker(cbCfg))
+			require.NoError(t, err)
+			ocs := be.ObsrepSender.(*observabilityConsumerSender)
+			require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				assert.NoError(t, be.Shutdown(context.Background()))
+				resetFeatureGate()
+			})
+
+			// The first request fails and opens the circuit, the second one is not sent.
+			mockR := newMockRequest(2, errors.New("transient error"))
+			ocs.run(func() {
+				require.NoError(t, be.Send(context.Background(), mockR))
+			})
+			ocs.awaitAsyncProcessing()
+			mockR2 := newMockRequest(3, nil)
+			ocs.run(func() {
+				require.NoError(t, be.Send(context.Background(), mockR2))
+			})
+			ocs.awaitAsyncProcessing()
+
+			mockR.checkNumRequests(t, 1)
+			mockR2.checkNumRequests(t, 0)
+			ocs.checkSendItemsCount(t, 0)
+			ocs.checkDroppedItemsCount(t, 5)
+		})
+	}
+	runTest("enable_queue_batcher", true)
+	runTest("disable_queue_batcher", false)
+}
+
+// fakeFailingSender returns the configured error for every request.
+type fakeFailingSender struct {
+	BaseSender[internal.Request]
+	mu    sync.Mutex
+	err   error
+	calls int
+}
+
+func (s *fakeFailingSender) setError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *fakeFailingSender) Send(context.Context, internal.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return s.err
+}
+
+// statusHost records the reported status events.
+type statusHost struct {
+	component.Host
+	mu  sync.Mutex
+	evs []*componentstatus.Event
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.evs = append(h.evs, ev)
+}
+
+func (h *statusHost) events() []*componentstatus.Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.evs
+}
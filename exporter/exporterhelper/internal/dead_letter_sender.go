@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	deadLetterWriteIndexKey = "wi"
+	// deadLetterLowIndexKey is the key of the low-water mark, the index below which all the records were replayed.
+	deadLetterLowIndexKey = "lw"
+)
+
+var (
+	errNoDeadLetterStorage    = errors.New("no storage extension found for the dead letter storage")
+	errWrongDeadLetterStorage = errors.New("requested dead letter storage extension is not a storage extension")
+)
+
+// DeadLetterConfig defines configuration for storing the requests that failed permanently.
+type DeadLetterConfig struct {
+	// Enabled indicates whether to store the requests that failed permanently or after all the retries.
+	Enabled bool `mapstructure:"enabled"`
+	// StorageID is the component specified as a storage extension to store the failed requests.
+	StorageID *component.ID `mapstructure:"storage"`
+	// ReplayOnStart if true, the stored requests are sent again through the exporter when it starts.
+	// A record is deleted only once its request was sent or enqueued, requests failing again keep their record.
+	ReplayOnStart bool `mapstructure:"replay_on_start"`
+}
+
+// NewDefaultDeadLetterConfig returns the default config for DeadLetterConfig.
+func NewDefaultDeadLetterConfig() DeadLetterConfig {
+	return DeadLetterConfig{
+		Enabled: false,
+	}
+}
+
+// Validate checks if the DeadLetterConfig configuration is valid
+func (dlCfg *DeadLetterConfig) Validate() error {
+	if !dlCfg.Enabled {
+		return nil
+	}
+	if dlCfg.StorageID == nil {
+		return errors.New("`storage` must be set when the dead letter storage is enabled")
+	}
+	return nil
+}
+
+// deadLetterRecord is the format of a failed request in the dead letter storage.
+type deadLetterRecord struct {
+	// Error is the error returned by the last attempt to send the request.
+	Error string `json:"error"`
+	// Timestamp is the time when the request was given up.
+	Timestamp time.Time `json:"timestamp"`
+	// Attempts is the number of attempts made to send the request.
+	Attempts int64 `json:"attempts"`
+	// Request is the request serialized by the exporter marshaler.
+	Request []byte `json:"request"`
+}
+
+type attemptsKey struct{}
+
+type replayKey struct{}
+
+// replayedRequest tracks a request replayed from the dead letter storage, a failure while the replay is
+// in progress is not stored again because the replayed record is kept.
+type replayedRequest struct {
+	mu       sync.Mutex
+	returned bool
+	failed   bool
+}
+
+// claimFailure returns true if the failure is handled by the replay.
+func (rr *replayedRequest) claimFailure() bool {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	if rr.returned {
+		return false
+	}
+	rr.failed = true
+	return true
+}
+
+// finish marks the replay as returned and reports whether a failure was claimed.
+func (rr *replayedRequest) finish() bool {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.returned = true
+	return rr.failed
+}
+
+// countAttempt increments the attempts counter of the request if it is tracked by the dead letter sender.
+func countAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*atomic.Int64); ok {
+		attempts.Add(1)
+	}
+}
+
+// deadLetterSender is a requestSender that stores the requests failing in the next senders in a storage extension.
+type deadLetterSender struct {
+	BaseSender[internal.Request]
+	cfg         DeadLetterConfig
+	signal      pipeline.Signal
+	set         exporter.Settings
+	marshaler   exporterqueue.Marshaler[internal.Request]
+	unmarshaler exporterqueue.Unmarshaler[internal.Request]
+
+	mu         sync.Mutex
+	client     storage.Client
+	writeIndex uint64
+	// lowIndex is the index of the first record that may still be stored, the replay starts from it.
+	lowIndex uint64
+
+	stopReplay context.CancelFunc
+	replayWG   sync.WaitGroup
+}
+
+func newDeadLetterSender(cfg DeadLetterConfig, signal pipeline.Signal, set exporter.Settings,
+	marshaler exporterqueue.Marshaler[internal.Request], unmarshaler exporterqueue.Unmarshaler[internal.Request],
+) *deadLetterSender {
+	return &deadLetterSender{
+		cfg:         cfg,
+		signal:      signal,
+		set:         set,
+		marshaler:   marshaler,
+		unmarshaler: unmarshaler,
+	}
+}
+
+// Start gets the storage client and restores the write index and the low-water mark.
+func (dls *deadLetterSender) Start(ctx context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[*dls.cfg.StorageID]
+	if !found {
+		return errNoDeadLetterStorage
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return errWrongDeadLetterStorage
+	}
+	// Use a different name than the persistent queue to allow sharing the same storage extension.
+	client, err := storageExt.GetClient(ctx, component.KindExporter, dls.set.ID, dls.signal.String()+"_dead_letter")
+	if err != nil {
+		return err
+	}
+
+	if dls.writeIndex, err = getDeadLetterIndex(ctx, client, deadLetterWriteIndexKey); err != nil {
+		return errors.Join(err, client.Close(ctx))
+	}
+	if dls.lowIndex, err = getDeadLetterIndex(ctx, client, deadLetterLowIndexKey); err != nil {
+		return errors.Join(err, client.Close(ctx))
+	}
+	dls.client = client
+	return nil
+}
+
+// getDeadLetterIndex reads the index stored at the given key, 0 if not stored yet.
+func getDeadLetterIndex(ctx context.Context, client storage.Client, key string) (uint64, error) {
+	buf, err := client.Get(ctx, key)
+	if err != nil || buf == nil {
+		return 0, err
+	}
+	if len(buf) != 8 {
+		return 0, errors.New("invalid dead letter index " + key)
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// startReplay sends the stored requests again using the given sender function, starting from the low-water mark.
+func (dls *deadLetterSender) startReplay(send func(context.Context, internal.Request) error) {
+	if !dls.cfg.ReplayOnStart {
+		return
+	}
+	dls.mu.Lock()
+	start, end := dls.lowIndex, dls.writeIndex
+	dls.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dls.stopReplay = cancel
+	dls.replayWG.Add(1)
+	go func() {
+		defer dls.replayWG.Done()
+		// The low-water mark moves past the records removed from the storage, up to the first record kept.
+		low := start
+		for idx := start; idx < end && ctx.Err() == nil; idx++ {
+			if dls.replay(ctx, idx, send) && low == idx {
+				low = idx + 1
+			}
+		}
+		if low != start {
+			dls.setLowIndex(context.WithoutCancel(ctx), low)
+		}
+	}()
+}
+
+// replay sends the record stored at the given index, and deletes it from the storage once it was sent.
+// It returns true if no record is stored at the index anymore.
+func (dls *deadLetterSender) replay(ctx context.Context, idx uint64, send func(context.Context, internal.Request) error) bool {
+	key := strconv.FormatUint(idx, 10)
+	buf, err := dls.client.Get(ctx, key)
+	if err != nil {
+		dls.set.Logger.Error("Failed to read a request from the dead letter storage.", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	if buf == nil {
+		return true
+	}
+	var rec deadLetterRecord
+	if err = json.Unmarshal(buf, &rec); err != nil {
+		dls.set.Logger.Error("Failed to decode a request from the dead letter storage.", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	req, err := dls.unmarshaler(rec.Request)
+	if err != nil {
+		dls.set.Logger.Error("Failed to unmarshal a request from the dead letter storage.", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	rr := &replayedRequest{}
+	err = send(context.WithValue(ctx, replayKey{}, rr), req)
+	if failed := rr.finish(); err != nil || failed {
+		dls.set.Logger.Warn("Failed to replay a request from the dead letter storage, keeping it for the next replay.",
+			zap.String("key", key), zap.Error(err))
+		return false
+	}
+	if err = dls.client.Delete(context.WithoutCancel(ctx), key); err != nil {
+		dls.set.Logger.Error("Failed to delete the replayed request from the dead letter storage.", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// setLowIndex persists the low-water mark, so that the next replay does not scan the records already removed.
+func (dls *deadLetterSender) setLowIndex(ctx context.Context, low uint64) {
+	if err := dls.client.Set(ctx, deadLetterLowIndexKey, binary.LittleEndian.AppendUint64(nil, low)); err != nil {
+		dls.set.Logger.Error("Failed to store the low-water mark of the dead letter storage.", zap.Error(err))
+		return
+	}
+	dls.mu.Lock()
+	dls.lowIndex = low
+	dls.mu.Unlock()
+}
+
+// Shutdown stops replaying and closes the storage client.
+func (dls *deadLetterSender) Shutdown(ctx context.Context) error {
+	dls.shutdownReplay()
+	if dls.client == nil {
+		return nil
+	}
+	return dls.client.Close(ctx)
+}
+
+// shutdownReplay stops replaying the stored requests, this must happen before the other senders are shut down.
+func (dls *deadLetterSender) shutdownReplay() {
+	if dls.stopReplay != nil {
+		dls.stopReplay()
+	}
+	dls.replayWG.Wait()
+}
+
+// Send implements the requestSender interface.
+func (dls *deadLetterSender) Send(ctx context.Context, req internal.Request) error {
+	attempts := &atomic.Int64{}
+	err := dls.NextSender.Send(context.WithValue(ctx, attemptsKey{}, attempts), req)
+	// Requests interrupted by shutdown are kept by the persistent queue.
+	if err == nil || experr.IsShutdownErr(err) {
+		return err
+	}
+	// The record of a replayed request is kept, there is no need to store it again.
+	if rr, ok := ctx.Value(replayKey{}).(*replayedRequest); ok && rr.claimFailure() {
+		return err
+	}
+	if storeErr := dls.store(context.WithoutCancel(ctx), req, err, attempts.Load()); storeErr != nil {
+		dls.set.Logger.Error("Failed to store the request in the dead letter storage.",
+			zap.Error(storeErr), zap.Int("dropped_items", req.ItemsCount()))
+	}
+	return err
+}
+
+// store writes the failed request with its error in the dead letter storage.
+func (dls *deadLetterSender) store(ctx context.Context, req internal.Request, err error, attempts int64) error {
+	reqBuf, mErr := dls.marshaler(req)
+	if mErr != nil {
+		return mErr
+	}
+	buf, mErr := json.Marshal(deadLetterRecord{
+		Error:     err.Error(),
+		Timestamp: time.Now(),
+		Attempts:  attempts,
+		Request:   reqBuf,
+	})
+	if mErr != nil {
+		return mErr
+	}
+
+	dls.mu.Lock()
+	defer dls.mu.Unlock()
+	if dls.client == nil {
+		return errNoDeadLetterStorage
+	}
+	idx := dls.writeIndex
+	if sErr := dls.client.Batch(ctx,
+		storage.SetOperation(strconv.FormatUint(idx, 10), buf),
+		storage.SetOperation(deadLetterWriteIndexKey, binary.LittleEndian.AppendUint64(nil, idx+1)),
+	); sErr != nil {
+		return sErr
+	}
+	dls.writeIndex = idx + 1
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/exporter/internal/storagetest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func TestDeadLetterConfig_Validate(t *testing.T) {
+	cfg := NewDefaultDeadLetterConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.EqualError(t, cfg.Validate(), "`storage` must be set when the dead letter storage is enabled")
+
+	storageID := component.MustNewID("file_storage")
+	cfg.StorageID = &storageID
+	require.NoError(t, cfg.Validate())
+}
+
+func newDeadLetterTestExporter(t *testing.T, ext storage.Extension, dlCfg DeadLetterConfig, rCfg configretry.BackOffConfig,
+	unmarshaler exporterqueue.Unmarshaler[internal.Request],
+) (*BaseExporter, component.Host) {
+	be, err := NewBaseExporter(defaultSettings, defaultSignal, newNoopObsrepSender,
+		WithMarshaler(mockRequestMarshaler), WithUnmarshaler(unmarshaler),
+		WithRetry(rCfg), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	return be, &MockHost{Ext: map[component.ID]component.Component{*dlCfg.StorageID: ext}}
+}
+
+func newDeadLetterTestConfig() DeadLetterConfig {
+	storageID := component.MustNewIDWithName("file_storage", "dead_letter")
+	return DeadLetterConfig{Enabled: true, StorageID: &storageID}
+}
+
+func readDeadLetterKey(t *testing.T, ext storage.Extension, key string) []byte {
+	client, err := ext.GetClient(context.Background(), component.KindExporter, defaultID, defaultSignal.String()+"_dead_letter")
+	require.NoError(t, err)
+	buf, err := client.Get(context.Background(), key)
+	require.NoError(t, err)
+	return buf
+}
+
+func readDeadLetterRecord(t *testing.T, ext storage.Extension, key string) *deadLetterRecord {
+	buf := readDeadLetterKey(t, ext, key)
+	if buf == nil {
+		return nil
+	}
+	rec := &deadLetterRecord{}
+	require.NoError(t, json.Unmarshal(buf, rec))
+	return rec
+}
+
+func TestDeadLetter_StorePermanentError(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	mockR := newMockRequest(2, consumererror.NewPermanent(errors.New("bad data")))
+	be, host := newDeadLetterTestExporter(t, ext, newDeadLetterTestConfig(), configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(mockR))
+	require.NoError(t, be.Start(context.Background(), host))
+
+	require.Error(t, be.Send(context.Background(), mockR))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	rec := readDeadLetterRecord(t, ext, "0")
+	require.NotNil(t, rec)
+	assert.Contains(t, rec.Error, "bad data")
+	assert.Equal(t, int64(1), rec.Attempts)
+	assert.Equal(t, []byte("mockRequest"), rec.Request)
+	assert.WithinDuration(t, time.Now(), rec.Timestamp, time.Minute)
+	assert.Nil(t, readDeadLetterRecord(t, ext, "1"))
+}
+
+func TestDeadLetter_StoreRetriesExhausted(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 20 * time.Millisecond
+	mockR := newErrorRequest()
+	be, host := newDeadLetterTestExporter(t, ext, newDeadLetterTestConfig(), rCfg, mockRequestUnmarshaler(mockR))
+	require.NoError(t, be.Start(context.Background(), host))
+
+	require.Error(t, be.Send(context.Background(), mockR))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	rec := readDeadLetterRecord(t, ext, "0")
+	require.NotNil(t, rec)
+	assert.Contains(t, rec.Error, "transient error")
+	assert.Equal(t, int64(mockR.(*mockErrorRequest).getNumRequests()), rec.Attempts)
+	assert.Greater(t, rec.Attempts, int64(1))
+}
+
+func TestDeadLetter_NotStoredOnSuccess(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	mockR := newMockRequest(2, nil)
+	be, host := newDeadLetterTestExporter(t, ext, newDeadLetterTestConfig(), configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(mockR))
+	require.NoError(t, be.Start(context.Background(), host))
+
+	require.NoError(t, be.Send(context.Background(), mockR))
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.Nil(t, readDeadLetterRecord(t, ext, "0"))
+}
+
+func TestDeadLetter_ReplayOnStart(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	dlCfg := newDeadLetterTestConfig()
+	failedR := newMockRequest(2, consumererror.NewPermanent(errors.New("bad data")))
+	be, host := newDeadLetterTestExporter(t, ext, dlCfg, configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(failedR))
+	require.NoError(t, be.Start(context.Background(), host))
+	require.Error(t, be.Send(context.Background(), failedR))
+	require.Error(t, be.Send(context.Background(), newMockRequest(1, consumererror.NewPermanent(errors.New("bad data")))))
+	require.NoError(t, be.Shutdown(context.Background()))
+	require.NotNil(t, readDeadLetterRecord(t, ext, "1"))
+
+	// Restart the exporter with replay enabled, the stored requests are sent again and removed from the storage.
+	dlCfg.ReplayOnStart = true
+	replayedR := newMockRequest(2, nil)
+	be, host = newDeadLetterTestExporter(t, ext, dlCfg, configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(replayedR))
+	require.NoError(t, be.Start(context.Background(), host))
+	replayedR.checkNumRequests(t, 2)
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	assert.Nil(t, readDeadLetterRecord(t, ext, "0"))
+	assert.Nil(t, readDeadLetterRecord(t, ext, "1"))
+	assert.Nil(t, readDeadLetterRecord(t, ext, "2"))
+	// The next replay starts after the replayed records.
+	assert.Equal(t, binary.LittleEndian.AppendUint64(nil, 2), readDeadLetterKey(t, ext, deadLetterLowIndexKey))
+
+	// A new record is replayed from the low-water mark.
+	be, host = newDeadLetterTestExporter(t, ext, newDeadLetterTestConfig(), configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(failedR))
+	require.NoError(t, be.Start(context.Background(), host))
+	require.Error(t, be.Send(context.Background(), newMockRequest(1, consumererror.NewPermanent(errors.New("bad data")))))
+	require.NoError(t, be.Shutdown(context.Background()))
+	replayedR = newMockRequest(2, nil)
+	be, host = newDeadLetterTestExporter(t, ext, dlCfg, configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(replayedR))
+	require.NoError(t, be.Start(context.Background(), host))
+	replayedR.checkNumRequests(t, 1)
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.Nil(t, readDeadLetterRecord(t, ext, "2"))
+	assert.Equal(t, binary.LittleEndian.AppendUint64(nil, 3), readDeadLetterKey(t, ext, deadLetterLowIndexKey))
+}
+
+func TestDeadLetter_ReplayFailureKeepsRecord(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	dlCfg := newDeadLetterTestConfig()
+	failedR := newMockRequest(2, consumererror.NewPermanent(errors.New("bad data")))
+	be, host := newDeadLetterTestExporter(t, ext, dlCfg, configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(failedR))
+	require.NoError(t, be.Start(context.Background(), host))
+	require.Error(t, be.Send(context.Background(), failedR))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	// Restart the exporter with replay enabled, the replayed request fails again and its record is kept.
+	dlCfg.ReplayOnStart = true
+	replayedR := newMockRequest(2, consumererror.NewPermanent(errors.New("still bad data")))
+	be, host = newDeadLetterTestExporter(t, ext, dlCfg, configretry.NewDefaultBackOffConfig(),
+		mockRequestUnmarshaler(replayedR))
+	require.NoError(t, be.Start(context.Background(), host))
+	replayedR.checkNumRequests(t, 1)
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	rec := readDeadLetterRecord(t, ext, "0")
+	require.NotNil(t, rec)
+	assert.Contains(t, rec.Error, "bad data")
+	assert.Nil(t, readDeadLetterRecord(t, ext, "1"))
+	// The kept record is replayed again by the next start.
+	assert.Nil(t, readDeadLetterKey(t, ext, deadLetterLowIndexKey))
+}
+
+func TestDeadLetter_NoStorageExtension(t *testing.T) {
+	be, err := NewBaseExporter(defaultSettings, defaultSignal, newNoopObsrepSender,
+		WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithDeadLetter(newDeadLetterTestConfig()))
+	require.NoError(t, err)
+	require.ErrorIs(t, be.Start(context.Background(), componenttest.NewNopHost()), errNoDeadLetterStorage)
+}
+
+func TestDeadLetter_RequestExporter(t *testing.T) {
+	_, err := NewBaseExporter(defaultSettings, defaultSignal, newNoopObsrepSender,
+		WithDeadLetter(newDeadLetterTestConfig()))
+	require.Error(t, err)
+}
@@ -30,6 +30,7 @@ type TelemetryBuilder struct {
 	ExporterEnqueueFailedMetricPoints metric.Int64Counter
 	ExporterEnqueueFailedSpans        metric.Int64Counter
 	ExporterQueueCapacity             metric.Int64ObservableGauge
+	ExporterQueueConcurrency          metric.Int64ObservableGauge
 	ExporterQueueSize                 metric.Int64ObservableGauge
 	ExporterSendFailedLogRecords      metric.Int64Counter
 	ExporterSendFailedMetricPoints    metric.Int64Counter
@@ -37,6 +38,7 @@ type TelemetryBuilder struct {
 	ExporterSentLogRecords            metric.Int64Counter
 	ExporterSentMetricPoints          metric.Int64Counter
 	ExporterSentSpans                 metric.Int64Counter
+	ExporterThrottledTime             metric.Float64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -68,6 +70,24 @@ func (builder *TelemetryBuilder) InitExporterQueueCapacity(cb func() int64, opts
 	return reg, err
 }
 
+// InitExporterQueueConcurrency configures the ExporterQueueConcurrency metric.
+func (builder *TelemetryBuilder) InitExporterQueueConcurrency(cb func() int64, opts ...metric.ObserveOption) (metric.Registration, error) {
+	var err error
+	builder.ExporterQueueConcurrency, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_concurrency",
+		metric.WithDescription("Current limit of concurrent exports from the queue, reported when the adaptive concurrency is enabled"),
+		metric.WithUnit("{exports}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := builder.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(builder.ExporterQueueConcurrency, cb(), opts...)
+		return nil
+	}, builder.ExporterQueueConcurrency)
+	return reg, err
+}
+
 // InitExporterQueueSize configures the ExporterQueueSize metric.
 func (builder *TelemetryBuilder) InitExporterQueueSize(cb func() int64, opts ...metric.ObserveOption) (metric.Registration, error) {
 	var err error
@@ -149,6 +169,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterThrottledTime, err = getLeveledMeter(builder.meter, configtelemetry.LevelBasic, settings.MetricsLevel).Float64Counter(
+		"otelcol_exporter_throttled_time",
+		metric.WithDescription("Time spent waiting for the delays requested by a throttling destination. [alpha]"),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
 
@@ -5,6 +5,7 @@ package internal // import "go.opentelemetry.io/collector/exporter/exporterhelpe
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -168,3 +169,8 @@ func (or *ObsReport) RecordEnqueueFailure(ctx context.Context, signal pipeline.S
 
 	enqueueFailedMeasure.Add(ctx, failed, or.otelAttrs)
 }
+
+// RecordThrottledTime records the time spent waiting for the delays requested by a throttling destination.
+func (or *ObsReport) RecordThrottledTime(ctx context.Context, delay time.Duration) {
+	or.TelemetryBuilder.ExporterThrottledTime.Add(ctx, delay.Seconds(), or.otelAttrs)
+}
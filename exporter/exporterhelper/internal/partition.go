@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourcePartitionKey returns a key identifying the combination of values of the given resource attribute keys.
+// Values of different types are never considered equal, and a missing attribute is different from any value.
+func ResourcePartitionKey(attrs pcommon.Map, keys []string) string {
+	var b strings.Builder
+	for _, k := range keys {
+		v, ok := attrs.Get(k)
+		if !ok {
+			b.WriteString("-;")
+			continue
+		}
+		val := v.Type().String() + ":" + v.AsString()
+		// Length-prefix the values so that the concatenation is unambiguous.
+		b.WriteString(strconv.Itoa(len(val)))
+		b.WriteByte(':')
+		b.WriteString(val)
+		b.WriteByte(';')
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestResourcePartitionKey(t *testing.T) {
+	keys := []string{"tenant", "region"}
+	newAttrs := func(fill func(pcommon.Map)) pcommon.Map {
+		m := pcommon.NewMap()
+		fill(m)
+		return m
+	}
+
+	a := newAttrs(func(m pcommon.Map) { m.PutStr("tenant", "a"); m.PutStr("region", "eu") })
+	sameAsA := newAttrs(func(m pcommon.Map) { m.PutStr("region", "eu"); m.PutStr("tenant", "a"); m.PutStr("other", "x") })
+	assert.Equal(t, ResourcePartitionKey(a, keys), ResourcePartitionKey(sameAsA, keys))
+
+	for _, other := range []pcommon.Map{
+		newAttrs(func(m pcommon.Map) { m.PutStr("tenant", "b"); m.PutStr("region", "eu") }),
+		newAttrs(func(m pcommon.Map) { m.PutStr("tenant", "a") }),
+		newAttrs(func(m pcommon.Map) { m.PutStr("tenant", "a;1:x"); m.PutStr("region", "eu") }),
+		newAttrs(func(m pcommon.Map) { m.PutInt("tenant", 1); m.PutStr("region", "eu") }),
+	} {
+		assert.NotEqual(t, ResourcePartitionKey(a, keys), ResourcePartitionKey(other, keys))
+	}
+
+	assert.NotEqual(t,
+		ResourcePartitionKey(newAttrs(func(m pcommon.Map) { m.PutInt("tenant", 1) }), keys),
+		ResourcePartitionKey(newAttrs(func(m pcommon.Map) { m.PutStr("tenant", "1") }), keys))
+	assert.Equal(t, "-;-;", ResourcePartitionKey(pcommon.NewMap(), keys))
+}
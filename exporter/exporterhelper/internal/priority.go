@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// PriorityConfig defines the priority lanes of the sending queue.
+type PriorityConfig struct {
+	// Lanes are the priority lanes ordered from the highest priority. A request is added to the first lane it
+	// matches, the requests matching no lane are added to a default lane with the lowest priority.
+	Lanes []PriorityLaneConfig `mapstructure:"lanes"`
+	// StarvationLimit is the number of consecutive requests read from higher priority lanes after which a request
+	// is read from a waiting lower priority lane. Zero means the lanes are always read in strict priority order.
+	StarvationLimit int `mapstructure:"starvation_limit"`
+}
+
+// PriorityLaneConfig defines the rule selecting the requests of a priority lane.
+// A request matches the lane if it matches all the configured conditions.
+type PriorityLaneConfig struct {
+	// Name identifies the lane.
+	Name string `mapstructure:"name"`
+	// Metadata are the client metadata values that the request context must have.
+	Metadata map[string]string `mapstructure:"metadata"`
+	// ResourceAttributes are the attribute values that at least one resource of the request must have.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+	// MinSeverity is the minimum severity that at least one log record of the request must have,
+	// one of "trace", "debug", "info", "warn", "error" or "fatal". Requests of other signals never match.
+	MinSeverity string `mapstructure:"min_severity"`
+}
+
+// Enabled returns true if at least one priority lane is configured.
+func (pCfg *PriorityConfig) Enabled() bool {
+	return len(pCfg.Lanes) > 0
+}
+
+// Validate checks if the PriorityConfig configuration is valid.
+func (pCfg *PriorityConfig) Validate() error {
+	if pCfg.StarvationLimit < 0 {
+		return errors.New("`starvation_limit` must be greater than or equal to zero")
+	}
+	var names []string
+	for _, lane := range pCfg.Lanes {
+		if lane.Name == "" {
+			return errors.New("every lane must have a `name`")
+		}
+		if slices.Contains(names, lane.Name) {
+			return fmt.Errorf("duplicate lane name %q", lane.Name)
+		}
+		names = append(names, lane.Name)
+		if len(lane.Metadata) == 0 && len(lane.ResourceAttributes) == 0 && lane.MinSeverity == "" {
+			return fmt.Errorf("lane %q must have at least one condition", lane.Name)
+		}
+		if lane.MinSeverity != "" {
+			if _, ok := severityNumbers[strings.ToLower(lane.MinSeverity)]; !ok {
+				return fmt.Errorf("lane %q has unsupported `min_severity` %q", lane.Name, lane.MinSeverity)
+			}
+		}
+	}
+	return nil
+}
+
+var severityNumbers = map[string]plog.SeverityNumber{
+	"trace": plog.SeverityNumberTrace,
+	"debug": plog.SeverityNumberDebug,
+	"info":  plog.SeverityNumberInfo,
+	"warn":  plog.SeverityNumberWarn,
+	"error": plog.SeverityNumberError,
+	"fatal": plog.SeverityNumberFatal,
+}
+
+// RequestResourceMatcher is an optional interface implemented by the requests that can be matched by resource attributes.
+type RequestResourceMatcher interface {
+	// HasResourceAttributes returns true if at least one resource of the request has all the given attribute values.
+	HasResourceAttributes(attrs map[string]string) bool
+}
+
+// RequestSeverityMatcher is an optional interface implemented by the requests that can be matched by log severity.
+type RequestSeverityMatcher interface {
+	// HasMinSeverity returns true if at least one log record of the request has at least the given severity.
+	HasMinSeverity(severity plog.SeverityNumber) bool
+}
+
+// newPrioritySelector returns a function selecting the lane of a request, len(lanes) being the default lane.
+func newPrioritySelector(pCfg PriorityConfig) func(context.Context, internal.Request) int {
+	return func(ctx context.Context, req internal.Request) int {
+		for i, lane := range pCfg.Lanes {
+			if laneMatches(ctx, lane, req) {
+				return i
+			}
+		}
+		return len(pCfg.Lanes)
+	}
+}
+
+func laneMatches(ctx context.Context, lane PriorityLaneConfig, req internal.Request) bool {
+	if len(lane.Metadata) > 0 {
+		info := client.FromContext(ctx)
+		for k, v := range lane.Metadata {
+			if !slices.Contains(info.Metadata.Get(k), v) {
+				return false
+			}
+		}
+	}
+	if len(lane.ResourceAttributes) > 0 {
+		rm, ok := req.(RequestResourceMatcher)
+		if !ok || !rm.HasResourceAttributes(lane.ResourceAttributes) {
+			return false
+		}
+	}
+	if lane.MinSeverity != "" {
+		sm, ok := req.(RequestSeverityMatcher)
+		if !ok || !sm.HasMinSeverity(severityNumbers[strings.ToLower(lane.MinSeverity)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResourceAttributesMatch returns true if the resource attributes have all the given values.
+func ResourceAttributesMatch(attrs pcommon.Map, want map[string]string) bool {
+	for k, v := range want {
+		attr, ok := attrs.Get(k)
+		if !ok || attr.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
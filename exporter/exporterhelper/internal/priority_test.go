@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestPriorityConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     PriorityConfig
+		wantErr string
+	}{
+		{
+			name: "disabled",
+			cfg:  PriorityConfig{},
+		},
+		{
+			name: "valid",
+			cfg: PriorityConfig{
+				Lanes: []PriorityLaneConfig{
+					{Name: "errors", MinSeverity: "ERROR"},
+					{Name: "slo", ResourceAttributes: map[string]string{"service.name": "slo"}},
+					{Name: "tenant", Metadata: map[string]string{"tenant": "a"}},
+				},
+				StarvationLimit: 10,
+			},
+		},
+		{
+			name:    "negative_starvation_limit",
+			cfg:     PriorityConfig{StarvationLimit: -1},
+			wantErr: "`starvation_limit` must be greater than or equal to zero",
+		},
+		{
+			name:    "missing_name",
+			cfg:     PriorityConfig{Lanes: []PriorityLaneConfig{{MinSeverity: "error"}}},
+			wantErr: "every lane must have a `name`",
+		},
+		{
+			name: "duplicate_name",
+			cfg: PriorityConfig{Lanes: []PriorityLaneConfig{
+				{Name: "errors", MinSeverity: "error"},
+				{Name: "errors", MinSeverity: "warn"},
+			}},
+			wantErr: `duplicate lane name "errors"`,
+		},
+		{
+			name:    "no_condition",
+			cfg:     PriorityConfig{Lanes: []PriorityLaneConfig{{Name: "errors"}}},
+			wantErr: `lane "errors" must have at least one condition`,
+		},
+		{
+			name:    "invalid_severity",
+			cfg:     PriorityConfig{Lanes: []PriorityLaneConfig{{Name: "errors", MinSeverity: "critical"}}},
+			wantErr: `lane "errors" has unsupported ` + "`min_severity`" + ` "critical"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestQueueConfig_ValidatePriorityWithStorage(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.Priority.Lanes = []PriorityLaneConfig{{Name: "errors", MinSeverity: "error"}}
+	require.NoError(t, qCfg.Validate())
+
+	qCfg.Priority.Lanes[0].MinSeverity = "critical"
+	require.ErrorContains(t, qCfg.Validate(), "`priority` is invalid")
+
+	qCfg.Priority.Lanes[0].MinSeverity = "error"
+	storageID := defaultID
+	qCfg.StorageID = &storageID
+	require.EqualError(t, qCfg.Validate(), "`priority` lanes are not supported with the persistent queue `storage`")
+}
+
+type fakeMatcherRequest struct {
+	mockRequest
+	resource pcommon.Map
+	severity plog.SeverityNumber
+}
+
+func (r *fakeMatcherRequest) HasResourceAttributes(attrs map[string]string) bool {
+	return ResourceAttributesMatch(r.resource, attrs)
+}
+
+func (r *fakeMatcherRequest) HasMinSeverity(severity plog.SeverityNumber) bool {
+	return r.severity >= severity
+}
+
+func TestPrioritySelector(t *testing.T) {
+	selector := newPrioritySelector(PriorityConfig{
+		Lanes: []PriorityLaneConfig{
+			{Name: "errors", MinSeverity: "Error"},
+			{Name: "slo", ResourceAttributes: map[string]string{"service.name": "slo", "env": "prod"}},
+			{Name: "tenant", Metadata: map[string]string{"tenant": "a"}},
+		},
+	})
+
+	newRequest := func(severity plog.SeverityNumber, attrs map[string]any) *fakeMatcherRequest {
+		resource := pcommon.NewMap()
+		require.NoError(t, resource.FromRaw(attrs))
+		return &fakeMatcherRequest{resource: resource, severity: severity}
+	}
+	tenantCtx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"b", "a"}}),
+	})
+
+	assert.Equal(t, 0, selector(context.Background(), newRequest(plog.SeverityNumberFatal, nil)))
+	assert.Equal(t, 1, selector(context.Background(), newRequest(plog.SeverityNumberInfo,
+		map[string]any{"service.name": "slo", "env": "prod"})))
+	assert.Equal(t, 3, selector(context.Background(), newRequest(plog.SeverityNumberInfo,
+		map[string]any{"service.name": "slo", "env": "dev"})))
+	assert.Equal(t, 2, selector(tenantCtx, newRequest(plog.SeverityNumberInfo, nil)))
+	assert.Equal(t, 3, selector(context.Background(), newRequest(plog.SeverityNumberInfo, nil)))
+	// Requests not implementing the matchers only match the metadata conditions.
+	assert.Equal(t, 2, selector(tenantCtx, newMockRequest(1, nil)))
+	assert.Equal(t, 3, selector(context.Background(), newMockRequest(1, nil)))
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"math/bits"
+)
+
+// ProtoFieldSize returns the number of bytes that a nested protobuf message of the given size adds to its parent
+// message, including the field tag and the length prefix. Only valid for field numbers lower than 16.
+func ProtoFieldSize(size int) int {
+	return 1 + varintSize(uint64(size)) + size
+}
+
+// ProtoFieldCapacity returns the maximum size of a nested protobuf message so that its ProtoFieldSize does not
+// exceed the given capacity. The returned value is conservative, it may be smaller than the exact maximum.
+func ProtoFieldCapacity(capacity int) int {
+	if capacity <= 0 {
+		return 0
+	}
+	return max(capacity-1-varintSize(uint64(capacity)), 0)
+}
+
+func varintSize(x uint64) int {
+	return (bits.Len64(x|1) + 6) / 7
+}
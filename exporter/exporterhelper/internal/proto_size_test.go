@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtoFieldSize(t *testing.T) {
+	assert.Equal(t, 2, ProtoFieldSize(0))
+	assert.Equal(t, 129, ProtoFieldSize(127))
+	assert.Equal(t, 131, ProtoFieldSize(128))
+	assert.Equal(t, 16386, ProtoFieldSize(16383))
+	assert.Equal(t, 16388, ProtoFieldSize(16384))
+}
+
+func TestProtoFieldCapacity(t *testing.T) {
+	assert.Equal(t, 0, ProtoFieldCapacity(-1))
+	assert.Equal(t, 0, ProtoFieldCapacity(1))
+	assert.Equal(t, 0, ProtoFieldCapacity(2))
+	assert.Equal(t, 127, ProtoFieldCapacity(128+2))
+	for _, capacity := range []int{3, 100, 129, 130, 131, 200, 16386, 16387, 16388, 1 << 22} {
+		assert.LessOrEqual(t, ProtoFieldSize(ProtoFieldCapacity(capacity)), capacity)
+	}
+}
@@ -6,6 +6,7 @@ package internal // import "go.opentelemetry.io/collector/exporter/exporterhelpe
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -28,14 +29,22 @@ type QueueConfig struct {
 	// If batching is enabled, a combined batch cannot contain more requests than the number of consumers.
 	// So it's recommended to set higher number of consumers if batching is enabled.
 	NumConsumers int `mapstructure:"num_consumers"`
-	// QueueSize is the maximum number of batches allowed in queue at a given time.
+	// QueueSize is the maximum size of the queue at a given time, measured in the units of the Sizer.
 	QueueSize int `mapstructure:"queue_size"`
+	// Sizer determines the unit of QueueSize: "requests" (default), "items" or "bytes".
+	// The "bytes" sizer measures the requests by their serialized OTLP protobuf size.
+	Sizer exporterqueue.SizerType `mapstructure:"sizer"`
 	// Blocking controls the queue behavior when full.
 	// If true it blocks until enough space to add the new request to the queue.
 	Blocking bool `mapstructure:"blocking"`
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// Priority defines the priority lanes of the queue. Every lane has a capacity of QueueSize.
+	// Priority lanes are not supported with the persistent queue.
+	Priority PriorityConfig `mapstructure:"priority"`
+	// AdaptiveConcurrency adapts the number of concurrent exports to the backend, NumConsumers being the maximum.
+	AdaptiveConcurrency AdaptiveConcurrencyConfig `mapstructure:"adaptive_concurrency"`
 }
 
 // NewDefaultQueueConfig returns the default config for QueueConfig.
@@ -46,8 +55,10 @@ func NewDefaultQueueConfig() QueueConfig {
 		// By default, batches are 8192 spans, for a total of up to 8 million spans in the queue
 		// This can be estimated at 1-4 GB worth of maximum memory usage
 		// This default is probably still too high, and may be adjusted further down in a future release
-		QueueSize: 1_000,
-		Blocking:  false,
+		QueueSize:           1_000,
+		Sizer:               exporterqueue.SizerTypeRequests,
+		Blocking:            false,
+		AdaptiveConcurrency: NewDefaultAdaptiveConcurrencyConfig(),
 	}
 }
 
@@ -65,6 +76,26 @@ func (qCfg *QueueConfig) Validate() error {
 		return errors.New("`num_consumers` must be positive")
 	}
 
+	if err := qCfg.Sizer.Validate(); err != nil {
+		return fmt.Errorf("`sizer` is invalid: %w", err)
+	}
+
+	if err := qCfg.Priority.Validate(); err != nil {
+		return fmt.Errorf("`priority` is invalid: %w", err)
+	}
+
+	if qCfg.Priority.Enabled() && qCfg.StorageID != nil {
+		return errors.New("`priority` lanes are not supported with the persistent queue `storage`")
+	}
+
+	if err := qCfg.AdaptiveConcurrency.Validate(); err != nil {
+		return fmt.Errorf("`adaptive_concurrency` is invalid: %w", err)
+	}
+
+	if qCfg.AdaptiveConcurrency.Enabled && qCfg.AdaptiveConcurrency.MinConsumers > qCfg.NumConsumers {
+		return errors.New("`adaptive_concurrency::min_consumers` must not be greater than `num_consumers`")
+	}
+
 	return nil
 }
 
@@ -75,6 +106,7 @@ type QueueSender struct {
 	traceAttribute attribute.KeyValue
 	batcher        queue.Batcher
 	consumers      *queue.Consumers[internal.Request]
+	concurrency    *adaptiveConcurrency
 
 	obsrep      *ObsReport
 	exporterID  component.ID
@@ -86,6 +118,7 @@ func NewQueueSender(
 	q exporterqueue.Queue[internal.Request],
 	set exporter.Settings,
 	numConsumers int,
+	concurrencyCfg AdaptiveConcurrencyConfig,
 	exportFailureMessage string,
 	obsrep *ObsReport,
 	batcherCfg exporterbatcher.Config,
@@ -107,6 +140,14 @@ func NewQueueSender(
 		}
 		return err
 	}
+	if concurrencyCfg.Enabled {
+		// All the consumers are started, the number of concurrent exports is limited by the adaptive concurrency.
+		qs.concurrency = newAdaptiveConcurrency(concurrencyCfg, numConsumers)
+		send := exportFunc
+		exportFunc = func(ctx context.Context, req internal.Request) error {
+			return qs.concurrency.export(ctx, func(ctx context.Context) error { return send(ctx, req) })
+		}
+	}
 	if usePullingBasedExporterQueueBatcher.IsEnabled() {
 		qs.batcher, _ = queue.NewBatcher(batcherCfg, q, exportFunc, numConsumers)
 	} else {
@@ -151,6 +192,17 @@ func (qs *QueueSender) Start(ctx context.Context, host component.Host) error {
 		})
 	}
 
+	if qs.concurrency != nil {
+		reg3, err3 := qs.obsrep.TelemetryBuilder.InitExporterQueueConcurrency(qs.concurrency.Limit,
+			metric.WithAttributeSet(attribute.NewSet(qs.traceAttribute, dataTypeAttr)))
+		if reg3 != nil {
+			qs.shutdownFns = append(qs.shutdownFns, func(context.Context) error {
+				return reg3.Unregister()
+			})
+		}
+		err2 = errors.Join(err2, err3)
+	}
+
 	return errors.Join(err1, err2)
 }
 
@@ -167,6 +219,10 @@ func (qs *QueueSender) Shutdown(ctx context.Context) error {
 	}
 	qs.shutdownFns = nil
 
+	if qs.concurrency != nil {
+		// Don't keep the remaining requests waiting for a slot while the queue is drained.
+		qs.concurrency.stop()
+	}
 	if err := qs.queue.Shutdown(ctx); err != nil {
 		return err
 	}
@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -320,6 +321,12 @@ func TestQueueConfig_Validate(t *testing.T) {
 
 			require.EqualError(t, qCfg.Validate(), "`num_consumers` must be positive")
 
+			qCfg = NewDefaultQueueConfig()
+			qCfg.Sizer = "spans"
+			require.EqualError(t, qCfg.Validate(), "`sizer` is invalid: unsupported sizer \"spans\", must be one of \"requests\", \"items\" or \"bytes\"")
+			qCfg.Sizer = exporterqueue.SizerTypeBytes
+			require.NoError(t, qCfg.Validate())
+
 			// Confirm Validate doesn't return error with invalid config when feature is disabled
 			qCfg.Enabled = false
 			assert.NoError(t, qCfg.Validate())
@@ -553,10 +560,47 @@ func TestQueueSenderNoStartShutdown(t *testing.T) {
 				ExporterCreateSettings: set,
 			})
 			require.NoError(t, err)
-			qs := NewQueueSender(queue, set, 1, "", obsrep, exporterbatcher.NewDefaultConfig())
+			qs := NewQueueSender(queue, set, 1, NewDefaultAdaptiveConcurrencyConfig(), "", obsrep, exporterbatcher.NewDefaultConfig())
 			assert.NoError(t, qs.Shutdown(context.Background()))
 		})
 	}
 	runTest("enable_queue_batcher", true)
 	runTest("disable_queue_batcher", false)
 }
+
+func TestQueuedRetry_PriorityLanes(t *testing.T) {
+	runTest := func(testName string, enableQueueBatcher bool) {
+		t.Run(testName, func(t *testing.T) {
+			resetFeatureGate := setFeatureGateForTest(t, usePullingBasedExporterQueueBatcher, enableQueueBatcher)
+			qCfg := NewDefaultQueueConfig()
+			qCfg.QueueSize = 10
+			qCfg.Priority.Lanes = []PriorityLaneConfig{{Name: "tenant", Metadata: map[string]string{"tenant": "a"}}}
+			be, err := NewBaseExporter(defaultSettings, defaultSignal, newObservabilityConsumerSender,
+				WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithQueue(qCfg))
+			require.NoError(t, err)
+			ocs := be.ObsrepSender.(*observabilityConsumerSender)
+			require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				assert.NoError(t, be.Shutdown(context.Background()))
+				resetFeatureGate()
+			})
+
+			// Every lane, including the default one, has the capacity of the queue size.
+			assert.Equal(t, int64(20), be.QueueSender.(*QueueSender).queue.Capacity())
+
+			mockR := newMockRequest(2, nil)
+			ocs.run(func() {
+				ctx := client.NewContext(context.Background(), client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"tenant": {"a"}}),
+				})
+				require.NoError(t, be.Send(ctx, mockR))
+			})
+			ocs.awaitAsyncProcessing()
+			mockR.checkNumRequests(t, 1)
+			ocs.checkSendItemsCount(t, 2)
+		})
+	}
+	runTest("enable_queue_batcher", true)
+	runTest("disable_queue_batcher", false)
+}
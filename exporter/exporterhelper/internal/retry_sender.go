@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -21,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/exporter/internal/experr"
 )
 
+var errExporterThrottled = errors.New("the exporter is throttled by the destination")
+
 // TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
 type throttleRetry struct {
 	err   error
@@ -49,14 +53,21 @@ type retrySender struct {
 	cfg            configretry.BackOffConfig
 	stopCh         chan struct{}
 	logger         *zap.Logger
+	obsrep         *ObsReport
+
+	// throttledUntil is the end of the delay requested by a throttling destination
+	// when all the requests of the exporter are paused, see configretry.ThrottleModeExporter.
+	throttleMu     sync.Mutex
+	throttledUntil time.Time
 }
 
-func newRetrySender(config configretry.BackOffConfig, set exporter.Settings) *retrySender {
+func newRetrySender(config configretry.BackOffConfig, set exporter.Settings, obsrep *ObsReport) *retrySender {
 	return &retrySender{
 		traceAttribute: attribute.String(ExporterKey, set.ID.String()),
 		cfg:            config,
 		stopCh:         make(chan struct{}),
 		logger:         set.Logger,
+		obsrep:         obsrep,
 	}
 }
 
@@ -82,6 +93,10 @@ func (rs *retrySender) Send(ctx context.Context, req internal.Request) error {
 	span := trace.SpanFromContext(ctx)
 	retryNum := int64(0)
 	for {
+		if err := rs.waitThrottled(ctx); err != nil {
+			return err
+		}
+
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
@@ -106,8 +121,14 @@ func (rs *retrySender) Send(ctx context.Context, req internal.Request) error {
 		}
 
 		throttleErr := throttleRetry{}
-		if errors.As(err, &throttleErr) {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+		if errors.As(err, &throttleErr) && throttleErr.delay > 0 {
+			throttleDelay := rs.cfg.Throttle.Delay(throttleErr.delay, rand.Float64())
+			if rs.cfg.Throttle.Mode == configretry.ThrottleModeExporter {
+				rs.throttle(ctx, throttleDelay)
+			} else {
+				rs.obsrep.RecordThrottledTime(ctx, throttleDelay)
+			}
+			backoffDelay = max(backoffDelay, throttleDelay)
 		}
 
 		if deadline, has := ctx.Deadline(); has && time.Until(deadline) < backoffDelay {
@@ -140,3 +161,44 @@ func (rs *retrySender) Send(ctx context.Context, req internal.Request) error {
 		}
 	}
 }
+
+// throttle pauses all the requests of the exporter for the given delay.
+func (rs *retrySender) throttle(ctx context.Context, delay time.Duration) {
+	rs.throttleMu.Lock()
+	defer rs.throttleMu.Unlock()
+	now := time.Now()
+	until := now.Add(delay)
+	if !until.After(rs.throttledUntil) {
+		return
+	}
+	// Only the extension of the pause is recorded, the exporter is already throttled until throttledUntil.
+	rs.obsrep.RecordThrottledTime(ctx, until.Sub(maxTime(now, rs.throttledUntil)))
+	rs.throttledUntil = until
+}
+
+// waitThrottled waits until the exporter is no longer paused by a throttling destination.
+func (rs *retrySender) waitThrottled(ctx context.Context) error {
+	for {
+		rs.throttleMu.Lock()
+		delay := time.Until(rs.throttledUntil)
+		rs.throttleMu.Unlock()
+		if delay <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("request is cancelled or timed out while the exporter is throttled: %w", ctx.Err())
+		case <-rs.stopCh:
+			return experr.NewShutdownErr(errExporterThrottled)
+		case <-time.After(delay):
+		}
+	}
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
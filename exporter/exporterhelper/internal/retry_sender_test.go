@@ -13,6 +13,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
@@ -20,9 +23,11 @@ import (
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/pipeline"
 )
@@ -238,6 +243,131 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	runTest("disable_queue_batcher", false)
 }
 
+func TestQueuedRetry_ThrottleMaxDelay(t *testing.T) {
+	runTest := func(testName string, enableQueueBatcher bool) {
+		t.Run(testName, func(t *testing.T) {
+			resetFeatureGate := setFeatureGateForTest(t, usePullingBasedExporterQueueBatcher, enableQueueBatcher)
+			qCfg := NewDefaultQueueConfig()
+			qCfg.NumConsumers = 1
+			rCfg := configretry.NewDefaultBackOffConfig()
+			rCfg.InitialInterval = 10 * time.Millisecond
+			rCfg.Throttle.MaxDelay = 50 * time.Millisecond
+			be, err := NewBaseExporter(defaultSettings, defaultSignal, newObservabilityConsumerSender,
+				WithMarshaler(mockRequestMarshaler), WithUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithRetry(rCfg), WithQueue(qCfg))
+			require.NoError(t, err)
+			ocs := be.ObsrepSender.(*observabilityConsumerSender)
+			require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				assert.NoError(t, be.Shutdown(context.Background()))
+				resetFeatureGate()
+			})
+
+			retry := NewThrottleRetry(errors.New("throttle error"), time.Hour)
+			mockR := newMockRequest(2, wrappedError{retry})
+			start := time.Now()
+			ocs.run(func() {
+				// This is asynchronous so it should just enqueue, no errors expected.
+				require.NoError(t, be.Send(context.Background(), mockR))
+			})
+			ocs.awaitAsyncProcessing()
+
+			// The throttle delay of 1h is bounded by the max delay of 50ms.
+			assert.Less(t, 50*time.Millisecond, time.Since(start))
+			assert.Greater(t, time.Minute, time.Since(start))
+
+			mockR.checkNumRequests(t, 2)
+			ocs.checkSendItemsCount(t, 2)
+			ocs.checkDroppedItemsCount(t, 0)
+		})
+	}
+	runTest("enable_queue_batcher", true)
+	runTest("disable_queue_batcher", false)
+}
+
+func TestRetrySender_ThrottleExporterMode(t *testing.T) {
+	tel := metadatatest.SetupTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := tel.NewSettings()
+	obsrep, err := NewExporter(ObsReportSettings{ExporterID: set.ID, ExporterCreateSettings: set, Signal: defaultSignal})
+	require.NoError(t, err)
+
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.Throttle.Mode = configretry.ThrottleModeExporter
+	rs := newRetrySender(rCfg, set, obsrep)
+	next := &fakeThrottlingSender{throttled: make(chan struct{}), delay: 100 * time.Millisecond}
+	rs.SetNextSender(next)
+	t.Cleanup(func() { require.NoError(t, rs.Shutdown(context.Background())) })
+
+	throttledDone := make(chan struct{})
+	go func() {
+		defer close(throttledDone)
+		assert.NoError(t, rs.Send(context.Background(), newMockRequest(1, nil)))
+	}()
+
+	// Requests sent while the exporter is throttled wait for the end of the delay, without being sent.
+	<-next.throttled
+	start := time.Now()
+	require.NoError(t, rs.Send(context.Background(), newMockRequest(1, nil)))
+	assert.LessOrEqual(t, 90*time.Millisecond, time.Since(start))
+	<-throttledDone
+	assert.Equal(t, int64(3), next.sent.Load())
+
+	tel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_throttled_time",
+			Description: "Time spent waiting for the delays requested by a throttling destination. [alpha]",
+			Unit:        "s",
+			Data: metricdata.Sum[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[float64]{
+					{
+						Attributes: attribute.NewSet(attribute.String(ExporterKey, set.ID.String())),
+						Value:      0.1,
+					},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
+
+func TestRetrySender_ThrottleExporterModeShutdown(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.Throttle.Mode = configretry.ThrottleModeExporter
+	obsrep, err := NewExporter(ObsReportSettings{ExporterID: defaultID, ExporterCreateSettings: defaultSettings, Signal: defaultSignal})
+	require.NoError(t, err)
+	rs := newRetrySender(rCfg, defaultSettings, obsrep)
+	rs.SetNextSender(&fakeThrottlingSender{throttled: make(chan struct{}), delay: time.Hour})
+	rs.throttle(context.Background(), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, rs.Send(ctx, newMockRequest(1, nil)), context.Canceled)
+
+	require.NoError(t, rs.Shutdown(context.Background()))
+	err = rs.Send(context.Background(), newMockRequest(1, nil))
+	require.ErrorIs(t, err, errExporterThrottled)
+	assert.True(t, experr.IsShutdownErr(err))
+}
+
+// fakeThrottlingSender throttles the first request with the given delay, and accepts the next ones.
+type fakeThrottlingSender struct {
+	BaseSender[internal.Request]
+	delay     time.Duration
+	throttled chan struct{}
+	sent      atomic.Int64
+}
+
+func (s *fakeThrottlingSender) Send(context.Context, internal.Request) error {
+	if s.sent.Add(1) == 1 {
+		close(s.throttled)
+		return NewThrottleRetry(errors.New("throttle error"), s.delay)
+	}
+	return nil
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	runTest := func(testName string, enableQueueBatcher bool) {
 		t.Run(testName, func(t *testing.T) {
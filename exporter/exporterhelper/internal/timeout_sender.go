@@ -40,6 +40,8 @@ type TimeoutSender struct {
 }
 
 func (ts *TimeoutSender) Send(ctx context.Context, req internal.Request) error {
+	// Every attempt to send the request, including the retries, passes through this sender.
+	countAttempt(ctx)
 	// TODO: Remove this by avoiding to create the timeout sender if timeout is 0.
 	if ts.cfg.Timeout == 0 {
 		return req.Export(ctx)
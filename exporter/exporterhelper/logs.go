@@ -66,6 +66,39 @@ func (req *logsRequest) ItemsCount() int {
 	return req.ld.LogRecordCount()
 }
 
+// ByteSize returns the size of the request serialized as OTLP protobuf.
+func (req *logsRequest) ByteSize() int {
+	return logsMarshaler.LogsSize(req.ld)
+}
+
+// HasResourceAttributes returns true if at least one resource of the request has all the given attribute values.
+func (req *logsRequest) HasResourceAttributes(attrs map[string]string) bool {
+	rls := req.ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		if internal.ResourceAttributesMatch(rls.At(i).Resource().Attributes(), attrs) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMinSeverity returns true if at least one log record of the request has at least the given severity.
+func (req *logsRequest) HasMinSeverity(severity plog.SeverityNumber) bool {
+	rls := req.ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if lrs.At(k).SeverityNumber() >= severity {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 type logsExporter struct {
 	*internal.BaseExporter
 	consumer.Logs
@@ -6,8 +6,11 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -23,6 +26,10 @@ func (req *logsRequest) MergeSplit(_ context.Context, cfg exporterbatcher.MaxSiz
 		}
 	}
 
+	if cfg.MaxSizeBytes > 0 {
+		return req.mergeSplitBytes(cfg.MaxSizeBytes, req2), nil
+	}
+
 	if cfg.MaxSizeItems == 0 {
 		req2.ld.ResourceLogs().MoveAndAppendTo(req.ld.ResourceLogs())
 		return []Request{req}, nil
@@ -75,6 +82,173 @@ func (req *logsRequest) MergeSplit(_ context.Context, cfg exporterbatcher.MaxSiz
 	return res, nil
 }
 
+// PartitionByResource splits the logs request into requests where all the resources have the same values for the
+// given attribute keys.
+func (req *logsRequest) PartitionByResource(keys []string) map[string]Request {
+	rls := req.ld.ResourceLogs()
+	partitionKeys := make([]string, rls.Len())
+	for i := 0; i < rls.Len(); i++ {
+		partitionKeys[i] = internal.ResourcePartitionKey(rls.At(i).Resource().Attributes(), keys)
+	}
+	if len(partitionKeys) == 0 {
+		return map[string]Request{internal.ResourcePartitionKey(pcommon.NewMap(), keys): req}
+	}
+	// All the resources belong to the same partition if every key is equal to the previous one.
+	if slices.Equal(partitionKeys[1:], partitionKeys[:len(partitionKeys)-1]) {
+		return map[string]Request{partitionKeys[0]: req}
+	}
+
+	res := make(map[string]Request)
+	for i := 0; i < rls.Len(); i++ {
+		partReq, ok := res[partitionKeys[i]].(*logsRequest)
+		if !ok {
+			partReq = &logsRequest{ld: plog.NewLogs(), pusher: req.pusher}
+			res[partitionKeys[i]] = partReq
+		}
+		rls.At(i).MoveTo(partReq.ld.ResourceLogs().AppendEmpty())
+	}
+	return res
+}
+
+// mergeSplitBytes merges and/or splits the provided logs requests into requests with a serialized size of at most
+// maxBytes. A single log record larger than maxBytes is sent in a request of its own.
+func (req *logsRequest) mergeSplitBytes(maxBytes int, req2 *logsRequest) []Request {
+	var (
+		res          []Request
+		destReq      *logsRequest
+		capacityLeft = maxBytes
+	)
+	for _, srcReq := range []*logsRequest{req, req2} {
+		if srcReq == nil {
+			continue
+		}
+
+		srcSize := logsMarshaler.LogsSize(srcReq.ld)
+		if srcSize <= capacityLeft {
+			if destReq == nil {
+				destReq = srcReq
+			} else {
+				srcReq.ld.ResourceLogs().MoveAndAppendTo(destReq.ld.ResourceLogs())
+			}
+			capacityLeft -= srcSize
+			continue
+		}
+
+		for srcReq.ld.LogRecordCount() > 0 {
+			extractedLogs, extractedSize := extractLogsBytes(srcReq.ld, capacityLeft)
+			if extractedLogs.LogRecordCount() == 0 {
+				if destReq != nil {
+					// Nothing fits into the current batch anymore, start a new one.
+					res = append(res, destReq)
+					destReq = nil
+					capacityLeft = maxBytes
+					continue
+				}
+				// A single log record does not fit into an empty batch, send it on its own.
+				extractedLogs = extractLogs(srcReq.ld, 1)
+				extractedSize = maxBytes
+			}
+			capacityLeft -= extractedSize
+			if destReq == nil {
+				destReq = &logsRequest{ld: extractedLogs, pusher: srcReq.pusher}
+			} else {
+				extractedLogs.ResourceLogs().MoveAndAppendTo(destReq.ld.ResourceLogs())
+			}
+			// Create new batch if the remaining log records did not fit.
+			if srcReq.ld.LogRecordCount() > 0 || capacityLeft <= 0 {
+				res = append(res, destReq)
+				destReq = nil
+				capacityLeft = maxBytes
+			}
+		}
+	}
+
+	if destReq != nil {
+		res = append(res, destReq)
+	}
+	return res
+}
+
+// extractLogsBytes extracts a new logs with a serialized size of at most capacity bytes.
+// It returns the extracted logs along with their serialized size.
+func extractLogsBytes(srcLogs plog.Logs, capacity int) (plog.Logs, int) {
+	destLogs := plog.NewLogs()
+	size := 0
+	full := false
+	srcLogs.ResourceLogs().RemoveIf(func(srcRL plog.ResourceLogs) bool {
+		if full {
+			return false
+		}
+		rlSize := internal.ProtoFieldSize(logsMarshaler.ResourceLogsSize(srcRL))
+		if size+rlSize <= capacity {
+			size += rlSize
+			srcRL.MoveTo(destLogs.ResourceLogs().AppendEmpty())
+			return true
+		}
+		full = true
+		destRL, destRLSize := extractResourceLogsBytes(srcRL, internal.ProtoFieldCapacity(capacity-size))
+		if destRL.ScopeLogs().Len() > 0 {
+			size += internal.ProtoFieldSize(destRLSize)
+			destRL.MoveTo(destLogs.ResourceLogs().AppendEmpty())
+		}
+		return false
+	})
+	return destLogs, size
+}
+
+// extractResourceLogsBytes extracts log records and returns a new resource logs with a serialized size of at most
+// capacity bytes along with its serialized size.
+func extractResourceLogsBytes(srcRL plog.ResourceLogs, capacity int) (plog.ResourceLogs, int) {
+	destRL := plog.NewResourceLogs()
+	destRL.SetSchemaUrl(srcRL.SchemaUrl())
+	srcRL.Resource().CopyTo(destRL.Resource())
+	size := logsMarshaler.ResourceLogsSize(destRL)
+	full := false
+	srcRL.ScopeLogs().RemoveIf(func(srcSL plog.ScopeLogs) bool {
+		if full {
+			return false
+		}
+		slSize := internal.ProtoFieldSize(logsMarshaler.ScopeLogsSize(srcSL))
+		if size+slSize <= capacity {
+			size += slSize
+			srcSL.MoveTo(destRL.ScopeLogs().AppendEmpty())
+			return true
+		}
+		full = true
+		destSL, destSLSize := extractScopeLogsBytes(srcSL, internal.ProtoFieldCapacity(capacity-size))
+		if destSL.LogRecords().Len() > 0 {
+			size += internal.ProtoFieldSize(destSLSize)
+			destSL.MoveTo(destRL.ScopeLogs().AppendEmpty())
+		}
+		return false
+	})
+	return destRL, size
+}
+
+// extractScopeLogsBytes extracts log records and returns a new scope logs with a serialized size of at most
+// capacity bytes along with its serialized size.
+func extractScopeLogsBytes(srcSL plog.ScopeLogs, capacity int) (plog.ScopeLogs, int) {
+	destSL := plog.NewScopeLogs()
+	destSL.SetSchemaUrl(srcSL.SchemaUrl())
+	srcSL.Scope().CopyTo(destSL.Scope())
+	size := logsMarshaler.ScopeLogsSize(destSL)
+	full := false
+	srcSL.LogRecords().RemoveIf(func(srcLR plog.LogRecord) bool {
+		if full {
+			return false
+		}
+		lrSize := internal.ProtoFieldSize(logsMarshaler.LogRecordSize(srcLR))
+		if size+lrSize > capacity {
+			full = true
+			return false
+		}
+		size += lrSize
+		srcLR.MoveTo(destSL.LogRecords().AppendEmpty())
+		return true
+	})
+	return destSL, size
+}
+
 // extractLogs extracts logs from the input logs and returns a new logs with the specified number of log records.
 func extractLogs(srcLogs plog.Logs, count int) plog.Logs {
 	destLogs := plog.NewLogs()
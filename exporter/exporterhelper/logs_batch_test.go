@@ -11,7 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
-	"go.opentelemetry.io/collector/exporter/internal"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -35,8 +35,8 @@ func TestMergeSplitLogs(t *testing.T) {
 	tests := []struct {
 		name     string
 		cfg      exporterbatcher.MaxSizeConfig
-		lr1      internal.Request
-		lr2      internal.Request
+		lr1      Request
+		lr2      Request
 		expected []*logsRequest
 	}{
 		{
@@ -152,3 +152,74 @@ func TestExtractLogs(t *testing.T) {
 		assert.Equal(t, 10-i, ld.LogRecordCount())
 	}
 }
+
+func TestMergeSplitLogsBytes(t *testing.T) {
+	lrSize := logsMarshaler.LogsSize(testdata.GenerateLogs(1))
+	for _, maxBytes := range []int{1, lrSize / 2, lrSize, lrSize + 100, 3 * lrSize, 10 * lrSize, 100 * lrSize} {
+		lr1 := &logsRequest{ld: testdata.GenerateLogs(7)}
+		lr2 := &logsRequest{ld: testdata.GenerateLogs(13)}
+		res, err := lr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, lr2)
+		require.NoError(t, err)
+		totalRecords := 0
+		for _, r := range res {
+			lr := r.(*logsRequest)
+			totalRecords += lr.ld.LogRecordCount()
+			if lr.ld.LogRecordCount() > 1 {
+				assert.LessOrEqual(t, lr.ByteSize(), maxBytes)
+			}
+		}
+		assert.Equal(t, 20, totalRecords)
+	}
+}
+
+func TestMergeSplitLogsBytesMergesSmallRequests(t *testing.T) {
+	lr1 := &logsRequest{ld: testdata.GenerateLogs(2)}
+	lr2 := &logsRequest{ld: testdata.GenerateLogs(3)}
+	maxBytes := lr1.ByteSize() + lr2.ByteSize()
+	res, err := lr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, lr2)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 5, res[0].ItemsCount())
+	assert.Equal(t, maxBytes, res[0].(*logsRequest).ByteSize())
+}
+
+func TestExtractLogsBytes(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		capacity := logsMarshaler.LogsSize(testdata.GenerateLogs(i))
+		ld := testdata.GenerateLogs(10)
+		extractedLogs, size := extractLogsBytes(ld, capacity)
+		assert.Equal(t, i, extractedLogs.LogRecordCount())
+		assert.Equal(t, 10-i, ld.LogRecordCount())
+		assert.Equal(t, logsMarshaler.LogsSize(extractedLogs), size)
+		assert.LessOrEqual(t, size, capacity)
+	}
+}
+
+func TestPartitionLogsByResource(t *testing.T) {
+	ld := testdata.GenerateLogs(1)
+	testdata.GenerateLogs(2).ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	ld.ResourceLogs().At(1).Resource().Attributes().PutStr("resource-attr", "resource-attr-val-2")
+	testdata.GenerateLogs(3).ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	keys := []string{"resource-attr"}
+	key1 := internal.ResourcePartitionKey(ld.ResourceLogs().At(0).Resource().Attributes(), keys)
+	key2 := internal.ResourcePartitionKey(ld.ResourceLogs().At(1).Resource().Attributes(), keys)
+
+	res := newLogsRequest(ld, nil).(*logsRequest).PartitionByResource(keys)
+	require.Len(t, res, 2)
+	assert.Equal(t, 2, res[key1].(*logsRequest).ld.ResourceLogs().Len())
+	assert.Equal(t, 4, res[key1].ItemsCount())
+	assert.Equal(t, 1, res[key2].(*logsRequest).ld.ResourceLogs().Len())
+	assert.Equal(t, 2, res[key2].ItemsCount())
+}
+
+func TestPartitionLogsByResourceSinglePartition(t *testing.T) {
+	ld := testdata.GenerateLogs(1)
+	testdata.GenerateLogs(2).ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	tr := newLogsRequest(ld, nil)
+
+	res := tr.(*logsRequest).PartitionByResource([]string{"resource-attr"})
+	require.Len(t, res, 1)
+	for _, r := range res {
+		assert.Same(t, tr, r)
+	}
+}
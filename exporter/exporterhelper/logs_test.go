@@ -52,6 +52,31 @@ func TestLogsRequest(t *testing.T) {
 	)
 }
 
+func TestLogsRequest_ByteSize(t *testing.T) {
+	ld := testdata.GenerateLogs(2)
+	buf, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), newLogsRequest(ld, nil).(*logsRequest).ByteSize())
+}
+
+func TestLogsRequest_HasResourceAttributes(t *testing.T) {
+	lr := newLogsRequest(testdata.GenerateLogs(1), nil).(*logsRequest)
+	assert.True(t, lr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-1"}))
+	assert.False(t, lr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-2"}))
+	assert.False(t, lr.HasResourceAttributes(map[string]string{"missing": "resource-attr-val-1"}))
+}
+
+func TestLogsRequest_HasMinSeverity(t *testing.T) {
+	ld := testdata.GenerateLogs(2)
+	lr := newLogsRequest(ld, nil).(*logsRequest)
+	assert.True(t, lr.HasMinSeverity(plog.SeverityNumberInfo))
+	assert.False(t, lr.HasMinSeverity(plog.SeverityNumberError))
+
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).SetSeverityNumber(plog.SeverityNumberError2)
+	assert.True(t, lr.HasMinSeverity(plog.SeverityNumberError))
+	assert.False(t, newLogsRequest(plog.NewLogs(), nil).(*logsRequest).HasMinSeverity(plog.SeverityNumberTrace))
+}
+
 func TestLogs_InvalidName(t *testing.T) {
 	le, err := NewLogs(context.Background(), exportertest.NewNopSettings(), nil, newPushLogsData(nil))
 	require.Nil(t, le)
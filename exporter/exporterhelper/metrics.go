@@ -66,6 +66,22 @@ func (req *metricsRequest) ItemsCount() int {
 	return req.md.DataPointCount()
 }
 
+// ByteSize returns the size of the request serialized as OTLP protobuf.
+func (req *metricsRequest) ByteSize() int {
+	return metricsMarshaler.MetricsSize(req.md)
+}
+
+// HasResourceAttributes returns true if at least one resource of the request has all the given attribute values.
+func (req *metricsRequest) HasResourceAttributes(attrs map[string]string) bool {
+	rss := req.md.ResourceMetrics()
+	for i := 0; i < rss.Len(); i++ {
+		if internal.ResourceAttributesMatch(rss.At(i).Resource().Attributes(), attrs) {
+			return true
+		}
+	}
+	return false
+}
+
 type metricsExporter struct {
 	*internal.BaseExporter
 	consumer.Metrics
@@ -6,8 +6,11 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -23,6 +26,10 @@ func (req *metricsRequest) MergeSplit(_ context.Context, cfg exporterbatcher.Max
 		}
 	}
 
+	if cfg.MaxSizeBytes > 0 {
+		return req.mergeSplitBytes(cfg.MaxSizeBytes, req2), nil
+	}
+
 	if cfg.MaxSizeItems == 0 {
 		req2.md.ResourceMetrics().MoveAndAppendTo(req.md.ResourceMetrics())
 		return []Request{req}, nil
@@ -76,6 +83,257 @@ func (req *metricsRequest) MergeSplit(_ context.Context, cfg exporterbatcher.Max
 	return res, nil
 }
 
+// PartitionByResource splits the metrics request into requests where all the resources have the same values for the
+// given attribute keys.
+func (req *metricsRequest) PartitionByResource(keys []string) map[string]Request {
+	rms := req.md.ResourceMetrics()
+	partitionKeys := make([]string, rms.Len())
+	for i := 0; i < rms.Len(); i++ {
+		partitionKeys[i] = internal.ResourcePartitionKey(rms.At(i).Resource().Attributes(), keys)
+	}
+	if len(partitionKeys) == 0 {
+		return map[string]Request{internal.ResourcePartitionKey(pcommon.NewMap(), keys): req}
+	}
+	// All the resources belong to the same partition if every key is equal to the previous one.
+	if slices.Equal(partitionKeys[1:], partitionKeys[:len(partitionKeys)-1]) {
+		return map[string]Request{partitionKeys[0]: req}
+	}
+
+	res := make(map[string]Request)
+	for i := 0; i < rms.Len(); i++ {
+		partReq, ok := res[partitionKeys[i]].(*metricsRequest)
+		if !ok {
+			partReq = &metricsRequest{md: pmetric.NewMetrics(), pusher: req.pusher}
+			res[partitionKeys[i]] = partReq
+		}
+		rms.At(i).MoveTo(partReq.md.ResourceMetrics().AppendEmpty())
+	}
+	return res
+}
+
+// mergeSplitBytes merges and/or splits the provided metrics requests into requests with a serialized size of at most
+// maxBytes. A single data point larger than maxBytes is sent in a request of its own.
+func (req *metricsRequest) mergeSplitBytes(maxBytes int, req2 *metricsRequest) []Request {
+	var (
+		res          []Request
+		destReq      *metricsRequest
+		capacityLeft = maxBytes
+	)
+	for _, srcReq := range []*metricsRequest{req, req2} {
+		if srcReq == nil {
+			continue
+		}
+
+		srcSize := metricsMarshaler.MetricsSize(srcReq.md)
+		if srcSize <= capacityLeft {
+			if destReq == nil {
+				destReq = srcReq
+			} else {
+				srcReq.md.ResourceMetrics().MoveAndAppendTo(destReq.md.ResourceMetrics())
+			}
+			capacityLeft -= srcSize
+			continue
+		}
+
+		for srcReq.md.DataPointCount() > 0 {
+			extractedMetrics, extractedSize := extractMetricsBytes(srcReq.md, capacityLeft)
+			if extractedMetrics.DataPointCount() == 0 {
+				if destReq != nil {
+					// Nothing fits into the current batch anymore, start a new one.
+					res = append(res, destReq)
+					destReq = nil
+					capacityLeft = maxBytes
+					continue
+				}
+				// A single data point does not fit into an empty batch, send it on its own.
+				extractedMetrics = extractMetrics(srcReq.md, 1)
+				extractedSize = maxBytes
+			}
+			capacityLeft -= extractedSize
+			if destReq == nil {
+				destReq = &metricsRequest{md: extractedMetrics, pusher: srcReq.pusher}
+			} else {
+				extractedMetrics.ResourceMetrics().MoveAndAppendTo(destReq.md.ResourceMetrics())
+			}
+			// Create new batch if the remaining data points did not fit.
+			if srcReq.md.DataPointCount() > 0 || capacityLeft <= 0 {
+				res = append(res, destReq)
+				destReq = nil
+				capacityLeft = maxBytes
+			}
+		}
+	}
+
+	if destReq != nil {
+		res = append(res, destReq)
+	}
+	return res
+}
+
+// extractMetricsBytes extracts a new metrics with a serialized size of at most capacity bytes.
+// It returns the extracted metrics along with their serialized size.
+func extractMetricsBytes(srcMetrics pmetric.Metrics, capacity int) (pmetric.Metrics, int) {
+	destMetrics := pmetric.NewMetrics()
+	size := 0
+	full := false
+	srcMetrics.ResourceMetrics().RemoveIf(func(srcRM pmetric.ResourceMetrics) bool {
+		if full {
+			return false
+		}
+		rmSize := internal.ProtoFieldSize(metricsMarshaler.ResourceMetricsSize(srcRM))
+		if size+rmSize <= capacity {
+			size += rmSize
+			srcRM.MoveTo(destMetrics.ResourceMetrics().AppendEmpty())
+			return true
+		}
+		full = true
+		destRM, destRMSize := extractResourceMetricsBytes(srcRM, internal.ProtoFieldCapacity(capacity-size))
+		if destRM.ScopeMetrics().Len() > 0 {
+			size += internal.ProtoFieldSize(destRMSize)
+			destRM.MoveTo(destMetrics.ResourceMetrics().AppendEmpty())
+		}
+		return false
+	})
+	return destMetrics, size
+}
+
+// extractResourceMetricsBytes extracts data points and returns a new resource metrics with a serialized size of
+// at most capacity bytes along with its serialized size.
+func extractResourceMetricsBytes(srcRM pmetric.ResourceMetrics, capacity int) (pmetric.ResourceMetrics, int) {
+	destRM := pmetric.NewResourceMetrics()
+	destRM.SetSchemaUrl(srcRM.SchemaUrl())
+	srcRM.Resource().CopyTo(destRM.Resource())
+	size := metricsMarshaler.ResourceMetricsSize(destRM)
+	full := false
+	srcRM.ScopeMetrics().RemoveIf(func(srcSM pmetric.ScopeMetrics) bool {
+		if full {
+			return false
+		}
+		smSize := internal.ProtoFieldSize(metricsMarshaler.ScopeMetricsSize(srcSM))
+		if size+smSize <= capacity {
+			size += smSize
+			srcSM.MoveTo(destRM.ScopeMetrics().AppendEmpty())
+			return true
+		}
+		full = true
+		destSM, destSMSize := extractScopeMetricsBytes(srcSM, internal.ProtoFieldCapacity(capacity-size))
+		if destSM.Metrics().Len() > 0 {
+			size += internal.ProtoFieldSize(destSMSize)
+			destSM.MoveTo(destRM.ScopeMetrics().AppendEmpty())
+		}
+		return false
+	})
+	return destRM, size
+}
+
+// extractScopeMetricsBytes extracts data points and returns a new scope metrics with a serialized size of
+// at most capacity bytes along with its serialized size.
+func extractScopeMetricsBytes(srcSM pmetric.ScopeMetrics, capacity int) (pmetric.ScopeMetrics, int) {
+	destSM := pmetric.NewScopeMetrics()
+	destSM.SetSchemaUrl(srcSM.SchemaUrl())
+	srcSM.Scope().CopyTo(destSM.Scope())
+	size := metricsMarshaler.ScopeMetricsSize(destSM)
+	full := false
+	srcSM.Metrics().RemoveIf(func(srcMetric pmetric.Metric) bool {
+		if full {
+			return false
+		}
+		metricSize := internal.ProtoFieldSize(metricsMarshaler.MetricSize(srcMetric))
+		if size+metricSize <= capacity {
+			size += metricSize
+			srcMetric.MoveTo(destSM.Metrics().AppendEmpty())
+			return true
+		}
+		full = true
+		destMetric, destMetricSize := extractMetricBytes(srcMetric, internal.ProtoFieldCapacity(capacity-size))
+		if metricDataPointCount(destMetric) > 0 {
+			size += internal.ProtoFieldSize(destMetricSize)
+			destMetric.MoveTo(destSM.Metrics().AppendEmpty())
+		}
+		return false
+	})
+	return destSM, size
+}
+
+// extractMetricBytes extracts data points and returns a new metric with the same metadata and a serialized size of
+// at most capacity bytes along with its serialized size.
+func extractMetricBytes(srcMetric pmetric.Metric, capacity int) (pmetric.Metric, int) {
+	destMetric := pmetric.NewMetric()
+	destMetric.SetName(srcMetric.Name())
+	destMetric.SetDescription(srcMetric.Description())
+	destMetric.SetUnit(srcMetric.Unit())
+	srcMetric.Metadata().CopyTo(destMetric.Metadata())
+	switch srcMetric.Type() {
+	case pmetric.MetricTypeGauge:
+		destMetric.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		destSum := destMetric.SetEmptySum()
+		destSum.SetAggregationTemporality(srcMetric.Sum().AggregationTemporality())
+		destSum.SetIsMonotonic(srcMetric.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		destMetric.SetEmptyHistogram().SetAggregationTemporality(srcMetric.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		destMetric.SetEmptyExponentialHistogram().SetAggregationTemporality(srcMetric.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		destMetric.SetEmptySummary()
+	}
+	// The data points are nested in one more message (e.g. Gauge) inside the metric.
+	size := metricsMarshaler.MetricSize(destMetric)
+	dataCapacity := internal.ProtoFieldCapacity(capacity - size)
+	dataSize := 0
+	fits := func(dpSize int) bool {
+		if dataSize+internal.ProtoFieldSize(dpSize) > dataCapacity {
+			dataCapacity = -1
+			return false
+		}
+		dataSize += internal.ProtoFieldSize(dpSize)
+		return true
+	}
+	switch srcMetric.Type() {
+	case pmetric.MetricTypeGauge:
+		srcMetric.Gauge().DataPoints().RemoveIf(func(srcDP pmetric.NumberDataPoint) bool {
+			if !fits(metricsMarshaler.NumberDataPointSize(srcDP)) {
+				return false
+			}
+			srcDP.MoveTo(destMetric.Gauge().DataPoints().AppendEmpty())
+			return true
+		})
+	case pmetric.MetricTypeSum:
+		srcMetric.Sum().DataPoints().RemoveIf(func(srcDP pmetric.NumberDataPoint) bool {
+			if !fits(metricsMarshaler.NumberDataPointSize(srcDP)) {
+				return false
+			}
+			srcDP.MoveTo(destMetric.Sum().DataPoints().AppendEmpty())
+			return true
+		})
+	case pmetric.MetricTypeHistogram:
+		srcMetric.Histogram().DataPoints().RemoveIf(func(srcDP pmetric.HistogramDataPoint) bool {
+			if !fits(metricsMarshaler.HistogramDataPointSize(srcDP)) {
+				return false
+			}
+			srcDP.MoveTo(destMetric.Histogram().DataPoints().AppendEmpty())
+			return true
+		})
+	case pmetric.MetricTypeExponentialHistogram:
+		srcMetric.ExponentialHistogram().DataPoints().RemoveIf(func(srcDP pmetric.ExponentialHistogramDataPoint) bool {
+			if !fits(metricsMarshaler.ExponentialHistogramDataPointSize(srcDP)) {
+				return false
+			}
+			srcDP.MoveTo(destMetric.ExponentialHistogram().DataPoints().AppendEmpty())
+			return true
+		})
+	case pmetric.MetricTypeSummary:
+		srcMetric.Summary().DataPoints().RemoveIf(func(srcDP pmetric.SummaryDataPoint) bool {
+			if !fits(metricsMarshaler.SummaryDataPointSize(srcDP)) {
+				return false
+			}
+			srcDP.MoveTo(destMetric.Summary().DataPoints().AppendEmpty())
+			return true
+		})
+	}
+	return destMetric, metricsMarshaler.MetricSize(destMetric)
+}
+
 // extractMetrics extracts metrics from srcMetrics until count of data points is reached.
 func extractMetrics(srcMetrics pmetric.Metrics, count int) pmetric.Metrics {
 	destMetrics := pmetric.NewMetrics()
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -159,3 +160,93 @@ func TestExtractMetricsInvalidMetric(t *testing.T) {
 	assert.Equal(t, testdata.GenerateMetricsMetricTypeInvalid(), extractedMetrics)
 	assert.Equal(t, 0, md.ResourceMetrics().Len())
 }
+
+func TestMergeSplitMetricsBytes(t *testing.T) {
+	dpSize := metricsMarshaler.MetricsSize(testdata.GenerateMetrics(1))
+	for _, maxBytes := range []int{1, dpSize / 2, dpSize, dpSize + 100, 3 * dpSize, 10 * dpSize, 100 * dpSize} {
+		mr1 := &metricsRequest{md: testdata.GenerateMetrics(7)}
+		mr2 := &metricsRequest{md: testdata.GenerateMetrics(13)}
+		res, err := mr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, mr2)
+		require.NoError(t, err)
+		totalDataPoints := 0
+		for _, r := range res {
+			mr := r.(*metricsRequest)
+			totalDataPoints += mr.md.DataPointCount()
+			if mr.md.DataPointCount() > 1 {
+				assert.LessOrEqual(t, mr.ByteSize(), maxBytes)
+			}
+		}
+		assert.Equal(t, 40, totalDataPoints)
+	}
+}
+
+func TestMergeSplitMetricsBytesMergesSmallRequests(t *testing.T) {
+	mr1 := &metricsRequest{md: testdata.GenerateMetrics(2)}
+	mr2 := &metricsRequest{md: testdata.GenerateMetrics(3)}
+	maxBytes := mr1.ByteSize() + mr2.ByteSize()
+	res, err := mr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, mr2)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 10, res[0].ItemsCount())
+	assert.Equal(t, maxBytes, res[0].(*metricsRequest).ByteSize())
+}
+
+func TestExtractMetricsBytes(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		capacity := metricsMarshaler.MetricsSize(testdata.GenerateMetrics(i))
+		md := testdata.GenerateMetrics(10)
+		extractedMetrics, size := extractMetricsBytes(md, capacity)
+		// Every generated metric has two data points.
+		assert.Equal(t, 2*i, extractedMetrics.DataPointCount())
+		assert.Equal(t, 20-2*i, md.DataPointCount())
+		assert.Equal(t, metricsMarshaler.MetricsSize(extractedMetrics), size)
+		assert.LessOrEqual(t, size, capacity)
+	}
+}
+
+func TestExtractMetricBytesKeepsMetadata(t *testing.T) {
+	md := testdata.GenerateMetrics(10)
+	srcMetric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2)
+	require.Equal(t, pmetric.MetricTypeSum, srcMetric.Type())
+	srcCount := metricDataPointCount(srcMetric)
+	dpSize := metricsMarshaler.NumberDataPointSize(srcMetric.Sum().DataPoints().At(0))
+	capacity := metricsMarshaler.MetricSize(srcMetric) - dpSize
+
+	destMetric, size := extractMetricBytes(srcMetric, capacity)
+	assert.LessOrEqual(t, size, capacity)
+	assert.Equal(t, metricsMarshaler.MetricSize(destMetric), size)
+	assert.Equal(t, srcCount, metricDataPointCount(destMetric)+metricDataPointCount(srcMetric))
+	assert.Equal(t, srcMetric.Name(), destMetric.Name())
+	assert.Equal(t, srcMetric.Unit(), destMetric.Unit())
+	assert.Equal(t, srcMetric.Sum().AggregationTemporality(), destMetric.Sum().AggregationTemporality())
+	assert.Equal(t, srcMetric.Sum().IsMonotonic(), destMetric.Sum().IsMonotonic())
+}
+
+func TestPartitionMetricsByResource(t *testing.T) {
+	md := testdata.GenerateMetrics(1)
+	testdata.GenerateMetrics(2).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	md.ResourceMetrics().At(1).Resource().Attributes().PutStr("resource-attr", "resource-attr-val-2")
+	testdata.GenerateMetrics(3).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	keys := []string{"resource-attr"}
+	key1 := internal.ResourcePartitionKey(md.ResourceMetrics().At(0).Resource().Attributes(), keys)
+	key2 := internal.ResourcePartitionKey(md.ResourceMetrics().At(1).Resource().Attributes(), keys)
+
+	res := newMetricsRequest(md, nil).(*metricsRequest).PartitionByResource(keys)
+	require.Len(t, res, 2)
+	assert.Equal(t, 2, res[key1].(*metricsRequest).md.ResourceMetrics().Len())
+	assert.Equal(t, 8, res[key1].ItemsCount())
+	assert.Equal(t, 1, res[key2].(*metricsRequest).md.ResourceMetrics().Len())
+	assert.Equal(t, 4, res[key2].ItemsCount())
+}
+
+func TestPartitionMetricsByResourceSinglePartition(t *testing.T) {
+	md := testdata.GenerateMetrics(1)
+	testdata.GenerateMetrics(2).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	tr := newMetricsRequest(md, nil)
+
+	res := tr.(*metricsRequest).PartitionByResource([]string{"resource-attr"})
+	require.Len(t, res, 1)
+	for _, r := range res {
+		assert.Same(t, tr, r)
+	}
+}
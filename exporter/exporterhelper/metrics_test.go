@@ -52,6 +52,19 @@ func TestMetricsRequest(t *testing.T) {
 	)
 }
 
+func TestMetricsRequest_ByteSize(t *testing.T) {
+	md := testdata.GenerateMetrics(2)
+	buf, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), newMetricsRequest(md, nil).(*metricsRequest).ByteSize())
+}
+
+func TestMetricsRequest_HasResourceAttributes(t *testing.T) {
+	mr := newMetricsRequest(testdata.GenerateMetrics(1), nil).(*metricsRequest)
+	assert.True(t, mr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-1"}))
+	assert.False(t, mr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-2"}))
+}
+
 func TestMetrics_NilConfig(t *testing.T) {
 	me, err := NewMetrics(context.Background(), exportertest.NewNopSettings(), nil, newPushMetricsData(nil))
 	require.Nil(t, me)
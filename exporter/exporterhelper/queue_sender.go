@@ -12,3 +12,29 @@ type QueueConfig = internal.QueueConfig
 func NewDefaultQueueConfig() QueueConfig {
 	return internal.NewDefaultQueueConfig()
 }
+
+// PriorityConfig defines the priority lanes of the sending queue.
+type PriorityConfig = internal.PriorityConfig
+
+// PriorityLaneConfig defines the rule selecting the requests of a priority lane.
+type PriorityLaneConfig = internal.PriorityLaneConfig
+
+// RequestResourceMatcher is an optional interface that can be implemented by Request to be matched by the
+// `resource_attributes` condition of the priority lanes.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestResourceMatcher = internal.RequestResourceMatcher
+
+// RequestSeverityMatcher is an optional interface that can be implemented by Request to be matched by the
+// `min_severity` condition of the priority lanes.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestSeverityMatcher = internal.RequestSeverityMatcher
+
+// AdaptiveConcurrencyConfig defines the adaptive concurrency of the exports from the sending queue.
+type AdaptiveConcurrencyConfig = internal.AdaptiveConcurrencyConfig
+
+// NewDefaultAdaptiveConcurrencyConfig returns the default config for AdaptiveConcurrencyConfig.
+func NewDefaultAdaptiveConcurrencyConfig() AdaptiveConcurrencyConfig {
+	return internal.NewDefaultAdaptiveConcurrencyConfig()
+}
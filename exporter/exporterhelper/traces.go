@@ -66,6 +66,22 @@ func (req *tracesRequest) ItemsCount() int {
 	return req.td.SpanCount()
 }
 
+// ByteSize returns the size of the request serialized as OTLP protobuf.
+func (req *tracesRequest) ByteSize() int {
+	return tracesMarshaler.TracesSize(req.td)
+}
+
+// HasResourceAttributes returns true if at least one resource of the request has all the given attribute values.
+func (req *tracesRequest) HasResourceAttributes(attrs map[string]string) bool {
+	rss := req.td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		if internal.ResourceAttributesMatch(rss.At(i).Resource().Attributes(), attrs) {
+			return true
+		}
+	}
+	return false
+}
+
 type tracesExporter struct {
 	*internal.BaseExporter
 	consumer.Traces
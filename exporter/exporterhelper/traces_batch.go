@@ -6,8 +6,11 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -23,6 +26,10 @@ func (req *tracesRequest) MergeSplit(_ context.Context, cfg exporterbatcher.MaxS
 		}
 	}
 
+	if cfg.MaxSizeBytes > 0 {
+		return req.mergeSplitBytes(cfg.MaxSizeBytes, req2), nil
+	}
+
 	if cfg.MaxSizeItems == 0 {
 		req2.td.ResourceSpans().MoveAndAppendTo(req.td.ResourceSpans())
 		return []Request{req}, nil
@@ -75,6 +82,173 @@ func (req *tracesRequest) MergeSplit(_ context.Context, cfg exporterbatcher.MaxS
 	return res, nil
 }
 
+// PartitionByResource splits the traces request into requests where all the resources have the same values for the
+// given attribute keys.
+func (req *tracesRequest) PartitionByResource(keys []string) map[string]Request {
+	rss := req.td.ResourceSpans()
+	partitionKeys := make([]string, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		partitionKeys[i] = internal.ResourcePartitionKey(rss.At(i).Resource().Attributes(), keys)
+	}
+	if len(partitionKeys) == 0 {
+		return map[string]Request{internal.ResourcePartitionKey(pcommon.NewMap(), keys): req}
+	}
+	// All the resources belong to the same partition if every key is equal to the previous one.
+	if slices.Equal(partitionKeys[1:], partitionKeys[:len(partitionKeys)-1]) {
+		return map[string]Request{partitionKeys[0]: req}
+	}
+
+	res := make(map[string]Request)
+	for i := 0; i < rss.Len(); i++ {
+		partReq, ok := res[partitionKeys[i]].(*tracesRequest)
+		if !ok {
+			partReq = &tracesRequest{td: ptrace.NewTraces(), pusher: req.pusher}
+			res[partitionKeys[i]] = partReq
+		}
+		rss.At(i).MoveTo(partReq.td.ResourceSpans().AppendEmpty())
+	}
+	return res
+}
+
+// mergeSplitBytes merges and/or splits the provided traces requests into requests with a serialized size of at most
+// maxBytes. A single span larger than maxBytes is sent in a request of its own.
+func (req *tracesRequest) mergeSplitBytes(maxBytes int, req2 *tracesRequest) []Request {
+	var (
+		res          []Request
+		destReq      *tracesRequest
+		capacityLeft = maxBytes
+	)
+	for _, srcReq := range []*tracesRequest{req, req2} {
+		if srcReq == nil {
+			continue
+		}
+
+		srcSize := tracesMarshaler.TracesSize(srcReq.td)
+		if srcSize <= capacityLeft {
+			if destReq == nil {
+				destReq = srcReq
+			} else {
+				srcReq.td.ResourceSpans().MoveAndAppendTo(destReq.td.ResourceSpans())
+			}
+			capacityLeft -= srcSize
+			continue
+		}
+
+		for srcReq.td.SpanCount() > 0 {
+			extractedTraces, extractedSize := extractTracesBytes(srcReq.td, capacityLeft)
+			if extractedTraces.SpanCount() == 0 {
+				if destReq != nil {
+					// Nothing fits into the current batch anymore, start a new one.
+					res = append(res, destReq)
+					destReq = nil
+					capacityLeft = maxBytes
+					continue
+				}
+				// A single span does not fit into an empty batch, send it on its own.
+				extractedTraces = extractTraces(srcReq.td, 1)
+				extractedSize = maxBytes
+			}
+			capacityLeft -= extractedSize
+			if destReq == nil {
+				destReq = &tracesRequest{td: extractedTraces, pusher: srcReq.pusher}
+			} else {
+				extractedTraces.ResourceSpans().MoveAndAppendTo(destReq.td.ResourceSpans())
+			}
+			// Create new batch if the remaining spans did not fit.
+			if srcReq.td.SpanCount() > 0 || capacityLeft <= 0 {
+				res = append(res, destReq)
+				destReq = nil
+				capacityLeft = maxBytes
+			}
+		}
+	}
+
+	if destReq != nil {
+		res = append(res, destReq)
+	}
+	return res
+}
+
+// extractTracesBytes extracts a new traces with a serialized size of at most capacity bytes.
+// It returns the extracted traces along with their serialized size.
+func extractTracesBytes(srcTraces ptrace.Traces, capacity int) (ptrace.Traces, int) {
+	destTraces := ptrace.NewTraces()
+	size := 0
+	full := false
+	srcTraces.ResourceSpans().RemoveIf(func(srcRS ptrace.ResourceSpans) bool {
+		if full {
+			return false
+		}
+		rsSize := internal.ProtoFieldSize(tracesMarshaler.ResourceSpansSize(srcRS))
+		if size+rsSize <= capacity {
+			size += rsSize
+			srcRS.MoveTo(destTraces.ResourceSpans().AppendEmpty())
+			return true
+		}
+		full = true
+		destRS, destRSSize := extractResourceSpansBytes(srcRS, internal.ProtoFieldCapacity(capacity-size))
+		if destRS.ScopeSpans().Len() > 0 {
+			size += internal.ProtoFieldSize(destRSSize)
+			destRS.MoveTo(destTraces.ResourceSpans().AppendEmpty())
+		}
+		return false
+	})
+	return destTraces, size
+}
+
+// extractResourceSpansBytes extracts spans and returns a new resource spans with a serialized size of at most
+// capacity bytes along with its serialized size.
+func extractResourceSpansBytes(srcRS ptrace.ResourceSpans, capacity int) (ptrace.ResourceSpans, int) {
+	destRS := ptrace.NewResourceSpans()
+	destRS.SetSchemaUrl(srcRS.SchemaUrl())
+	srcRS.Resource().CopyTo(destRS.Resource())
+	size := tracesMarshaler.ResourceSpansSize(destRS)
+	full := false
+	srcRS.ScopeSpans().RemoveIf(func(srcSS ptrace.ScopeSpans) bool {
+		if full {
+			return false
+		}
+		ssSize := internal.ProtoFieldSize(tracesMarshaler.ScopeSpansSize(srcSS))
+		if size+ssSize <= capacity {
+			size += ssSize
+			srcSS.MoveTo(destRS.ScopeSpans().AppendEmpty())
+			return true
+		}
+		full = true
+		destSS, destSSSize := extractScopeSpansBytes(srcSS, internal.ProtoFieldCapacity(capacity-size))
+		if destSS.Spans().Len() > 0 {
+			size += internal.ProtoFieldSize(destSSSize)
+			destSS.MoveTo(destRS.ScopeSpans().AppendEmpty())
+		}
+		return false
+	})
+	return destRS, size
+}
+
+// extractScopeSpansBytes extracts spans and returns a new scope spans with a serialized size of at most
+// capacity bytes along with its serialized size.
+func extractScopeSpansBytes(srcSS ptrace.ScopeSpans, capacity int) (ptrace.ScopeSpans, int) {
+	destSS := ptrace.NewScopeSpans()
+	destSS.SetSchemaUrl(srcSS.SchemaUrl())
+	srcSS.Scope().CopyTo(destSS.Scope())
+	size := tracesMarshaler.ScopeSpansSize(destSS)
+	full := false
+	srcSS.Spans().RemoveIf(func(srcSpan ptrace.Span) bool {
+		if full {
+			return false
+		}
+		spanSize := internal.ProtoFieldSize(tracesMarshaler.SpanSize(srcSpan))
+		if size+spanSize > capacity {
+			full = true
+			return false
+		}
+		size += spanSize
+		srcSpan.MoveTo(destSS.Spans().AppendEmpty())
+		return true
+	})
+	return destSS, size
+}
+
 // extractTraces extracts a new traces with a maximum number of spans.
 func extractTraces(srcTraces ptrace.Traces, count int) ptrace.Traces {
 	destTraces := ptrace.NewTraces()
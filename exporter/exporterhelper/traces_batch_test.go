@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -159,3 +160,74 @@ func TestExtractTraces(t *testing.T) {
 		assert.Equal(t, 10-i, td.SpanCount())
 	}
 }
+
+func TestMergeSplitTracesBytes(t *testing.T) {
+	spanSize := tracesMarshaler.TracesSize(testdata.GenerateTraces(1))
+	for _, maxBytes := range []int{1, spanSize / 2, spanSize, spanSize + 100, 3 * spanSize, 10 * spanSize, 100 * spanSize} {
+		tr1 := &tracesRequest{td: testdata.GenerateTraces(7)}
+		tr2 := &tracesRequest{td: testdata.GenerateTraces(13)}
+		res, err := tr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, tr2)
+		require.NoError(t, err)
+		totalSpans := 0
+		for _, r := range res {
+			tr := r.(*tracesRequest)
+			totalSpans += tr.td.SpanCount()
+			if tr.td.SpanCount() > 1 {
+				assert.LessOrEqual(t, tr.ByteSize(), maxBytes)
+			}
+		}
+		assert.Equal(t, 20, totalSpans)
+	}
+}
+
+func TestMergeSplitTracesBytesMergesSmallRequests(t *testing.T) {
+	tr1 := &tracesRequest{td: testdata.GenerateTraces(2)}
+	tr2 := &tracesRequest{td: testdata.GenerateTraces(3)}
+	maxBytes := tr1.ByteSize() + tr2.ByteSize()
+	res, err := tr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, tr2)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 5, res[0].ItemsCount())
+	assert.Equal(t, maxBytes, res[0].(*tracesRequest).ByteSize())
+}
+
+func TestExtractTracesBytes(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		capacity := tracesMarshaler.TracesSize(testdata.GenerateTraces(i))
+		td := testdata.GenerateTraces(10)
+		extractedTraces, size := extractTracesBytes(td, capacity)
+		assert.Equal(t, i, extractedTraces.SpanCount())
+		assert.Equal(t, 10-i, td.SpanCount())
+		assert.Equal(t, tracesMarshaler.TracesSize(extractedTraces), size)
+		assert.LessOrEqual(t, size, capacity)
+	}
+}
+
+func TestPartitionTracesByResource(t *testing.T) {
+	td := testdata.GenerateTraces(1)
+	testdata.GenerateTraces(2).ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	td.ResourceSpans().At(1).Resource().Attributes().PutStr("resource-attr", "resource-attr-val-2")
+	testdata.GenerateTraces(3).ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	keys := []string{"resource-attr"}
+	key1 := internal.ResourcePartitionKey(td.ResourceSpans().At(0).Resource().Attributes(), keys)
+	key2 := internal.ResourcePartitionKey(td.ResourceSpans().At(1).Resource().Attributes(), keys)
+
+	res := newTracesRequest(td, nil).(*tracesRequest).PartitionByResource(keys)
+	require.Len(t, res, 2)
+	assert.Equal(t, 2, res[key1].(*tracesRequest).td.ResourceSpans().Len())
+	assert.Equal(t, 4, res[key1].ItemsCount())
+	assert.Equal(t, 1, res[key2].(*tracesRequest).td.ResourceSpans().Len())
+	assert.Equal(t, 2, res[key2].ItemsCount())
+}
+
+func TestPartitionTracesByResourceSinglePartition(t *testing.T) {
+	td := testdata.GenerateTraces(1)
+	testdata.GenerateTraces(2).ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	tr := newTracesRequest(td, nil)
+
+	res := tr.(*tracesRequest).PartitionByResource([]string{"resource-attr"})
+	require.Len(t, res, 1)
+	for _, r := range res {
+		assert.Same(t, tr, r)
+	}
+}
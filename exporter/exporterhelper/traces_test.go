@@ -48,6 +48,19 @@ func TestTracesRequest(t *testing.T) {
 	assert.EqualValues(t, newTracesRequest(ptrace.NewTraces(), nil), mr.(RequestErrorHandler).OnError(traceErr))
 }
 
+func TestTracesRequest_ByteSize(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), newTracesRequest(td, nil).(*tracesRequest).ByteSize())
+}
+
+func TestTracesRequest_HasResourceAttributes(t *testing.T) {
+	tr := newTracesRequest(testdata.GenerateTraces(1), nil).(*tracesRequest)
+	assert.True(t, tr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-1"}))
+	assert.False(t, tr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-2"}))
+}
+
 func TestTraces_InvalidName(t *testing.T) {
 	te, err := NewTraces(context.Background(), exportertest.NewNopSettings(), nil, newTraceDataPusher(nil))
 	require.Nil(t, te)
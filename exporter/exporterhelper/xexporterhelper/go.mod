@@ -15,6 +15,7 @@ require (
 	go.opentelemetry.io/collector/exporter v0.117.0
 	go.opentelemetry.io/collector/exporter/exportertest v0.117.0
 	go.opentelemetry.io/collector/exporter/xexporter v0.117.0
+	go.opentelemetry.io/collector/pdata v1.23.0
 	go.opentelemetry.io/collector/pdata/pprofile v0.117.0
 	go.opentelemetry.io/collector/pdata/testdata v0.117.0
 	go.opentelemetry.io/collector/pipeline/xpipeline v0.117.0
@@ -36,11 +37,12 @@ require (
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/collector/client v1.23.0 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.117.0 // indirect
 	go.opentelemetry.io/collector/config/configtelemetry v0.117.0 // indirect
 	go.opentelemetry.io/collector/extension v0.117.0 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.117.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.23.0 // indirect
-	go.opentelemetry.io/collector/pdata v1.23.0 // indirect
 	go.opentelemetry.io/collector/pipeline v0.117.0 // indirect
 	go.opentelemetry.io/collector/receiver v0.117.0 // indirect
 	go.opentelemetry.io/collector/receiver/receivertest v0.117.0 // indirect
@@ -75,6 +77,8 @@ replace go.opentelemetry.io/collector/consumer/xconsumer => ../../../consumer/xc
 
 replace go.opentelemetry.io/collector/component => ../../../component
 
+replace go.opentelemetry.io/collector/component/componentstatus => ../../../component/componentstatus
+
 replace go.opentelemetry.io/collector/component/componenttest => ../../../component/componenttest
 
 replace go.opentelemetry.io/collector/receiver/xreceiver => ../../../receiver/xreceiver
@@ -104,3 +108,5 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../../../extens
 replace go.opentelemetry.io/collector/featuregate => ../../../featuregate
 
 replace go.opentelemetry.io/collector/extension/xextension => ../../../extension/xextension
+
+replace go.opentelemetry.io/collector/client => ../../../client
@@ -69,6 +69,22 @@ func (req *profilesRequest) ItemsCount() int {
 	return req.pd.SampleCount()
 }
 
+// ByteSize returns the size of the request serialized as OTLP protobuf.
+func (req *profilesRequest) ByteSize() int {
+	return profilesMarshaler.ProfilesSize(req.pd)
+}
+
+// HasResourceAttributes returns true if at least one resource of the request has all the given attribute values.
+func (req *profilesRequest) HasResourceAttributes(attrs map[string]string) bool {
+	rps := req.pd.ResourceProfiles()
+	for i := 0; i < rps.Len(); i++ {
+		if internal.ResourceAttributesMatch(rps.At(i).Resource().Attributes(), attrs) {
+			return true
+		}
+	}
+	return false
+}
+
 type profileExporter struct {
 	*internal.BaseExporter
 	xconsumer.Profiles
@@ -6,9 +6,12 @@ package xexporterhelper // import "go.opentelemetry.io/collector/exporter/export
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 )
 
@@ -23,6 +26,10 @@ func (req *profilesRequest) MergeSplit(_ context.Context, cfg exporterbatcher.Ma
 		}
 	}
 
+	if cfg.MaxSizeBytes > 0 {
+		return req.mergeSplitBytes(cfg.MaxSizeBytes, req2), nil
+	}
+
 	if cfg.MaxSizeItems == 0 {
 		req2.pd.ResourceProfiles().MoveAndAppendTo(req.pd.ResourceProfiles())
 		return []exporterhelper.Request{req}, nil
@@ -75,6 +82,218 @@ func (req *profilesRequest) MergeSplit(_ context.Context, cfg exporterbatcher.Ma
 	return res, nil
 }
 
+// PartitionByResource splits the profiles request into requests where all the resources have the same values for the
+// given attribute keys.
+func (req *profilesRequest) PartitionByResource(keys []string) map[string]exporterhelper.Request {
+	rps := req.pd.ResourceProfiles()
+	partitionKeys := make([]string, rps.Len())
+	for i := 0; i < rps.Len(); i++ {
+		partitionKeys[i] = internal.ResourcePartitionKey(rps.At(i).Resource().Attributes(), keys)
+	}
+	if len(partitionKeys) == 0 {
+		return map[string]exporterhelper.Request{internal.ResourcePartitionKey(pcommon.NewMap(), keys): req}
+	}
+	// All the resources belong to the same partition if every key is equal to the previous one.
+	if slices.Equal(partitionKeys[1:], partitionKeys[:len(partitionKeys)-1]) {
+		return map[string]exporterhelper.Request{partitionKeys[0]: req}
+	}
+
+	res := make(map[string]exporterhelper.Request)
+	for i := 0; i < rps.Len(); i++ {
+		partReq, ok := res[partitionKeys[i]].(*profilesRequest)
+		if !ok {
+			partReq = &profilesRequest{pd: pprofile.NewProfiles(), pusher: req.pusher}
+			res[partitionKeys[i]] = partReq
+		}
+		rps.At(i).MoveTo(partReq.pd.ResourceProfiles().AppendEmpty())
+	}
+	return res
+}
+
+// mergeSplitBytes merges and/or splits the provided profiles requests into requests with a serialized size of at most
+// maxBytes. A single profile larger than maxBytes is sent in a request of its own.
+func (req *profilesRequest) mergeSplitBytes(maxBytes int, req2 *profilesRequest) []exporterhelper.Request {
+	var (
+		res          []exporterhelper.Request
+		destReq      *profilesRequest
+		capacityLeft = maxBytes
+	)
+	for _, srcReq := range []*profilesRequest{req, req2} {
+		if srcReq == nil {
+			continue
+		}
+
+		srcSize := profilesMarshaler.ProfilesSize(srcReq.pd)
+		if srcSize <= capacityLeft {
+			if destReq == nil {
+				destReq = srcReq
+			} else {
+				srcReq.pd.ResourceProfiles().MoveAndAppendTo(destReq.pd.ResourceProfiles())
+			}
+			capacityLeft -= srcSize
+			continue
+		}
+
+		for profilesCount(srcReq.pd) > 0 {
+			extractedProfiles, extractedSize := extractProfilesBytes(srcReq.pd, capacityLeft)
+			if profilesCount(extractedProfiles) == 0 {
+				if destReq != nil {
+					// Nothing fits into the current batch anymore, start a new one.
+					res = append(res, destReq)
+					destReq = nil
+					capacityLeft = maxBytes
+					continue
+				}
+				// A single profile does not fit into an empty batch, send it on its own.
+				extractedProfiles = extractFirstProfile(srcReq.pd)
+				extractedSize = maxBytes
+			}
+			capacityLeft -= extractedSize
+			if destReq == nil {
+				destReq = &profilesRequest{pd: extractedProfiles, pusher: srcReq.pusher}
+			} else {
+				extractedProfiles.ResourceProfiles().MoveAndAppendTo(destReq.pd.ResourceProfiles())
+			}
+			// Create new batch if the remaining profiles did not fit.
+			if profilesCount(srcReq.pd) > 0 || capacityLeft <= 0 {
+				res = append(res, destReq)
+				destReq = nil
+				capacityLeft = maxBytes
+			}
+		}
+	}
+
+	if destReq != nil {
+		res = append(res, destReq)
+	}
+	return res
+}
+
+// extractProfilesBytes extracts a new profiles with a serialized size of at most capacity bytes.
+// It returns the extracted profiles along with their serialized size.
+func extractProfilesBytes(srcProfiles pprofile.Profiles, capacity int) (pprofile.Profiles, int) {
+	destProfiles := pprofile.NewProfiles()
+	size := 0
+	full := false
+	srcProfiles.ResourceProfiles().RemoveIf(func(srcRP pprofile.ResourceProfiles) bool {
+		if full {
+			return false
+		}
+		rpSize := internal.ProtoFieldSize(profilesMarshaler.ResourceProfilesSize(srcRP))
+		if size+rpSize <= capacity {
+			size += rpSize
+			srcRP.MoveTo(destProfiles.ResourceProfiles().AppendEmpty())
+			return true
+		}
+		full = true
+		destRP, destRPSize := extractResourceProfilesBytes(srcRP, internal.ProtoFieldCapacity(capacity-size))
+		if destRP.ScopeProfiles().Len() > 0 {
+			size += internal.ProtoFieldSize(destRPSize)
+			destRP.MoveTo(destProfiles.ResourceProfiles().AppendEmpty())
+		}
+		return false
+	})
+	return destProfiles, size
+}
+
+// extractResourceProfilesBytes extracts profiles and returns a new resource profiles with a serialized size of
+// at most capacity bytes along with its serialized size.
+func extractResourceProfilesBytes(srcRP pprofile.ResourceProfiles, capacity int) (pprofile.ResourceProfiles, int) {
+	destRP := pprofile.NewResourceProfiles()
+	destRP.SetSchemaUrl(srcRP.SchemaUrl())
+	srcRP.Resource().CopyTo(destRP.Resource())
+	size := profilesMarshaler.ResourceProfilesSize(destRP)
+	full := false
+	srcRP.ScopeProfiles().RemoveIf(func(srcSP pprofile.ScopeProfiles) bool {
+		if full {
+			return false
+		}
+		spSize := internal.ProtoFieldSize(profilesMarshaler.ScopeProfilesSize(srcSP))
+		if size+spSize <= capacity {
+			size += spSize
+			srcSP.MoveTo(destRP.ScopeProfiles().AppendEmpty())
+			return true
+		}
+		full = true
+		destSP, destSPSize := extractScopeProfilesBytes(srcSP, internal.ProtoFieldCapacity(capacity-size))
+		if destSP.Profiles().Len() > 0 {
+			size += internal.ProtoFieldSize(destSPSize)
+			destSP.MoveTo(destRP.ScopeProfiles().AppendEmpty())
+		}
+		return false
+	})
+	return destRP, size
+}
+
+// extractScopeProfilesBytes extracts profiles and returns a new scope profiles with a serialized size of
+// at most capacity bytes along with its serialized size.
+func extractScopeProfilesBytes(srcSP pprofile.ScopeProfiles, capacity int) (pprofile.ScopeProfiles, int) {
+	destSP := pprofile.NewScopeProfiles()
+	destSP.SetSchemaUrl(srcSP.SchemaUrl())
+	srcSP.Scope().CopyTo(destSP.Scope())
+	size := profilesMarshaler.ScopeProfilesSize(destSP)
+	full := false
+	srcSP.Profiles().RemoveIf(func(srcProfile pprofile.Profile) bool {
+		if full {
+			return false
+		}
+		profileSize := internal.ProtoFieldSize(profilesMarshaler.ProfileSize(srcProfile))
+		if size+profileSize > capacity {
+			full = true
+			return false
+		}
+		size += profileSize
+		srcProfile.MoveTo(destSP.Profiles().AppendEmpty())
+		return true
+	})
+	return destSP, size
+}
+
+// extractFirstProfile extracts a new profiles containing only the first profile.
+func extractFirstProfile(srcProfiles pprofile.Profiles) pprofile.Profiles {
+	destProfiles := pprofile.NewProfiles()
+	extracted := false
+	srcProfiles.ResourceProfiles().RemoveIf(func(srcRP pprofile.ResourceProfiles) bool {
+		if extracted {
+			return false
+		}
+		destRP := destProfiles.ResourceProfiles().AppendEmpty()
+		destRP.SetSchemaUrl(srcRP.SchemaUrl())
+		srcRP.Resource().CopyTo(destRP.Resource())
+		srcRP.ScopeProfiles().RemoveIf(func(srcSP pprofile.ScopeProfiles) bool {
+			if extracted {
+				return false
+			}
+			destSP := destRP.ScopeProfiles().AppendEmpty()
+			destSP.SetSchemaUrl(srcSP.SchemaUrl())
+			srcSP.Scope().CopyTo(destSP.Scope())
+			srcSP.Profiles().RemoveIf(func(srcProfile pprofile.Profile) bool {
+				if extracted {
+					return false
+				}
+				srcProfile.MoveTo(destSP.Profiles().AppendEmpty())
+				extracted = true
+				return true
+			})
+			return srcSP.Profiles().Len() == 0
+		})
+		return srcRP.ScopeProfiles().Len() == 0
+	})
+	return destProfiles
+}
+
+// profilesCount calculates the total number of profiles in the pprofile.Profiles.
+func profilesCount(pd pprofile.Profiles) int {
+	count := 0
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		sps := pd.ResourceProfiles().At(i).ScopeProfiles()
+		for j := 0; j < sps.Len(); j++ {
+			count += sps.At(j).Profiles().Len()
+		}
+	}
+	return count
+}
+
 // extractProfiles extracts a new profiles with a maximum number of samples.
 func extractProfiles(srcProfiles pprofile.Profiles, count int) pprofile.Profiles {
 	destProfiles := pprofile.NewProfiles()
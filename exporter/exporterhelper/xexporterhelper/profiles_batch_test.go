@@ -14,6 +14,7 @@ import (
 
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -160,3 +161,83 @@ func (req *dummyRequest) MergeSplit(_ context.Context, _ exporterbatcher.MaxSize
 ) {
 	return nil, nil
 }
+
+func TestMergeSplitProfilesBytes(t *testing.T) {
+	profileSize := profilesMarshaler.ProfilesSize(testdata.GenerateProfiles(1))
+	for _, maxBytes := range []int{1, profileSize / 2, profileSize, profileSize + 100, 3 * profileSize, 10 * profileSize, 100 * profileSize} {
+		pr1 := &profilesRequest{pd: testdata.GenerateProfiles(7)}
+		pr2 := &profilesRequest{pd: testdata.GenerateProfiles(13)}
+		res, err := pr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, pr2)
+		require.NoError(t, err)
+		totalProfiles := 0
+		for _, r := range res {
+			pr := r.(*profilesRequest)
+			totalProfiles += profilesCount(pr.pd)
+			if profilesCount(pr.pd) > 1 {
+				assert.LessOrEqual(t, pr.ByteSize(), maxBytes)
+			}
+		}
+		assert.Equal(t, 20, totalProfiles)
+	}
+}
+
+func TestMergeSplitProfilesBytesMergesSmallRequests(t *testing.T) {
+	pr1 := &profilesRequest{pd: testdata.GenerateProfiles(2)}
+	pr2 := &profilesRequest{pd: testdata.GenerateProfiles(3)}
+	maxBytes := pr1.ByteSize() + pr2.ByteSize()
+	res, err := pr1.MergeSplit(context.Background(), exporterbatcher.MaxSizeConfig{MaxSizeBytes: maxBytes}, pr2)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 5, profilesCount(res[0].(*profilesRequest).pd))
+	assert.Equal(t, maxBytes, res[0].(*profilesRequest).ByteSize())
+}
+
+func TestExtractProfilesBytes(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		capacity := profilesMarshaler.ProfilesSize(testdata.GenerateProfiles(i))
+		pd := testdata.GenerateProfiles(10)
+		extractedProfiles, size := extractProfilesBytes(pd, capacity)
+		assert.Equal(t, i, profilesCount(extractedProfiles))
+		assert.Equal(t, 10-i, profilesCount(pd))
+		assert.Equal(t, profilesMarshaler.ProfilesSize(extractedProfiles), size)
+		assert.LessOrEqual(t, size, capacity)
+	}
+}
+
+func TestExtractFirstProfile(t *testing.T) {
+	pd := testdata.GenerateProfiles(3)
+	extractedProfiles := extractFirstProfile(pd)
+	assert.Equal(t, 1, profilesCount(extractedProfiles))
+	assert.Equal(t, 2, profilesCount(pd))
+	assert.Equal(t, testdata.GenerateProfiles(1), extractedProfiles)
+}
+
+func TestPartitionProfilesByResource(t *testing.T) {
+	pd := testdata.GenerateProfiles(1)
+	testdata.GenerateProfiles(2).ResourceProfiles().MoveAndAppendTo(pd.ResourceProfiles())
+	pd.ResourceProfiles().At(1).Resource().Attributes().PutStr("resource-attr", "resource-attr-val-2")
+	testdata.GenerateProfiles(3).ResourceProfiles().MoveAndAppendTo(pd.ResourceProfiles())
+	keys := []string{"resource-attr"}
+	key1 := internal.ResourcePartitionKey(pd.ResourceProfiles().At(0).Resource().Attributes(), keys)
+	key2 := internal.ResourcePartitionKey(pd.ResourceProfiles().At(1).Resource().Attributes(), keys)
+
+	res := newProfilesRequest(pd, nil).(*profilesRequest).PartitionByResource(keys)
+	require.Len(t, res, 2)
+	assert.Equal(t, 2, res[key1].(*profilesRequest).pd.ResourceProfiles().Len())
+	assert.Equal(t, 4, res[key1].(*profilesRequest).pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().Len()+
+		res[key1].(*profilesRequest).pd.ResourceProfiles().At(1).ScopeProfiles().At(0).Profiles().Len())
+	assert.Equal(t, 1, res[key2].(*profilesRequest).pd.ResourceProfiles().Len())
+	assert.Equal(t, 2, res[key2].(*profilesRequest).pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().Len())
+}
+
+func TestPartitionProfilesByResourceSinglePartition(t *testing.T) {
+	pd := testdata.GenerateProfiles(1)
+	testdata.GenerateProfiles(2).ResourceProfiles().MoveAndAppendTo(pd.ResourceProfiles())
+	pr := newProfilesRequest(pd, nil)
+
+	res := pr.(*profilesRequest).PartitionByResource([]string{"resource-attr"})
+	require.Len(t, res, 1)
+	for _, r := range res {
+		assert.Same(t, pr, r)
+	}
+}
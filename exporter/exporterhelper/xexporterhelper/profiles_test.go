@@ -53,6 +53,19 @@ func TestProfilesRequest(t *testing.T) {
 	)
 }
 
+func TestProfilesRequest_ByteSize(t *testing.T) {
+	pd := testdata.GenerateProfiles(2)
+	buf, err := (&pprofile.ProtoMarshaler{}).MarshalProfiles(pd)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), newProfilesRequest(pd, nil).(*profilesRequest).ByteSize())
+}
+
+func TestProfilesRequest_HasResourceAttributes(t *testing.T) {
+	pr := newProfilesRequest(testdata.GenerateProfiles(1), nil).(*profilesRequest)
+	assert.True(t, pr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-1"}))
+	assert.False(t, pr.HasResourceAttributes(map[string]string{"resource-attr": "resource-attr-val-2"}))
+}
+
 func TestProfilesExporter_InvalidName(t *testing.T) {
 	le, err := NewProfilesExporter(context.Background(), exportertest.NewNopSettings(), nil, newPushProfilesData(nil))
 	require.Nil(t, le)
@@ -108,7 +108,7 @@ func TestQueueUsage(t *testing.T) {
 		},
 		{
 			name:  "items_based",
-			sizer: &itemsSizer{},
+			sizer: &uint64Sizer{},
 		},
 	}
 	for _, tt := range tests {
@@ -141,7 +141,7 @@ func TestBlockingQueueUsage(t *testing.T) {
 		},
 		{
 			name:  "items_based",
-			sizer: &itemsSizer{},
+			sizer: &uint64Sizer{},
 		},
 	}
 	for _, tt := range tests {
@@ -231,7 +231,7 @@ func BenchmarkOffer(b *testing.B) {
 		},
 		{
 			name:  "items_based",
-			sizer: &itemsSizer{},
+			sizer: &uint64Sizer{},
 		},
 	}
 	for _, tt := range tests {
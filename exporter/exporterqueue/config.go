@@ -5,6 +5,7 @@ package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporter
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -18,8 +19,10 @@ type Config struct {
 	Enabled bool `mapstructure:"enabled"`
 	// NumConsumers is the number of consumers from the queue.
 	NumConsumers int `mapstructure:"num_consumers"`
-	// QueueSize is the maximum number of requests allowed in queue at any given time.
+	// QueueSize is the maximum size of the queue at any given time, measured in the units of the Sizer.
 	QueueSize int `mapstructure:"queue_size"`
+	// Sizer determines the unit of QueueSize: "requests" (default), "items" or "bytes".
+	Sizer SizerType `mapstructure:"sizer"`
 	// Blocking controls the queue behavior when full.
 	// If true it blocks until enough space to add the new request to the queue.
 	Blocking bool `mapstructure:"blocking"`
@@ -33,6 +36,7 @@ func NewDefaultConfig() Config {
 		Enabled:      true,
 		NumConsumers: 10,
 		QueueSize:    1_000,
+		Sizer:        SizerTypeRequests,
 		Blocking:     true,
 	}
 }
@@ -48,6 +52,9 @@ func (qCfg *Config) Validate() error {
 	if qCfg.QueueSize <= 0 {
 		return errors.New("`queue_size` must be positive")
 	}
+	if err := qCfg.Sizer.Validate(); err != nil {
+		return fmt.Errorf("`sizer` is invalid: %w", err)
+	}
 	return nil
 }
 
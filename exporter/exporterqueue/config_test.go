@@ -21,6 +21,10 @@ func TestQueueConfig_Validate(t *testing.T) {
 	qCfg.QueueSize = 0
 	require.EqualError(t, qCfg.Validate(), "`queue_size` must be positive")
 
+	qCfg = NewDefaultConfig()
+	qCfg.Sizer = "unknown"
+	require.EqualError(t, qCfg.Validate(), "`sizer` is invalid: unsupported sizer \"unknown\", must be one of \"requests\", \"items\" or \"bytes\"")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
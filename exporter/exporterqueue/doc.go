@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package exporterqueue provides the queues used by the exporter helper to buffer requests before exporting.
+package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporterqueue"
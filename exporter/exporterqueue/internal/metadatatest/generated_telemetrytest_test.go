@@ -0,0 +1,53 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadatatest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/exporter/exporterqueue/internal/metadata"
+)
+
+func TestSetupTelemetry(t *testing.T) {
+	testTel := SetupTelemetry()
+	tb, err := metadata.NewTelemetryBuilder(
+		testTel.NewTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, tb)
+	tb.ExporterQueueMissingItems.Add(context.Background(), 1)
+	tb.ExporterQueueQuarantinedItems.Add(context.Background(), 1)
+
+	testTel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_queue_missing_items",
+			Description: "Number of items of the persistent queue that were referenced by the queue indexes but not found in the storage. [alpha]",
+			Unit:        "{batches}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_exporter_queue_quarantined_items",
+			Description: "Number of items of the persistent queue that failed to be read and were moved to the quarantine. [alpha]",
+			Unit:        "{batches}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+	require.NoError(t, testTel.Shutdown(context.Background()))
+}
@@ -11,10 +11,13 @@ import (
 	"strconv"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterqueue/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pipeline"
@@ -29,6 +32,13 @@ const (
 	writeIndexKey               = "wi"
 	currentlyDispatchedItemsKey = "di"
 	queueSizeKey                = "si"
+	quarantineIndexKey          = "qi"
+
+	// maxQuarantinedItems is the number of unreadable items kept in the quarantine, the oldest ones are deleted.
+	maxQuarantinedItems = 100
+	// maxRebuildProbes is the number of item keys looked up to find the first stored item when both the read
+	// and the write indexes are lost.
+	maxRebuildProbes = 1000
 )
 
 var (
@@ -79,6 +89,9 @@ type persistentQueue[T any] struct {
 	// isRequestSized indicates whether the queue is sized by the number of requests.
 	isRequestSized bool
 
+	telemetryBuilder *metadata.TelemetryBuilder
+	metricAttr       metric.MeasurementOption
+
 	// mu guards everything declared below.
 	mu                       sync.Mutex
 	hasMoreElements          *sync.Cond
@@ -87,6 +100,7 @@ type persistentQueue[T any] struct {
 	writeIndex               uint64
 	currentlyDispatchedItems []uint64
 	queueSize                int64
+	quarantineIndex          uint64
 	refClient                int64
 	stopped                  bool
 }
@@ -110,6 +124,14 @@ func (pq *persistentQueue[T]) Start(ctx context.Context, host component.Host) er
 	if err != nil {
 		return err
 	}
+	pq.telemetryBuilder, err = metadata.NewTelemetryBuilder(pq.set.set.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	pq.metricAttr = metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("exporter", pq.set.set.ID.String()),
+		attribute.String("data_type", pq.set.signal.String()),
+	))
 	pq.initClient(ctx, storageClient)
 	return nil
 }
@@ -136,24 +158,49 @@ func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Cli
 func (pq *persistentQueue[T]) initPersistentContiguousStorage(ctx context.Context) {
 	riOp := storage.GetOperation(readIndexKey)
 	wiOp := storage.GetOperation(writeIndexKey)
+	diOp := storage.GetOperation(currentlyDispatchedItemsKey)
+
+	err := pq.client.Batch(ctx, riOp, wiOp, diOp)
+	switch {
+	case err != nil:
+		pq.logger.Error("Failed getting read/write index, rebuilding them from the stored items", zap.Error(err))
+		pq.rebuildIndexes(ctx, false, false, nil)
+	case riOp.Value == nil && wiOp.Value == nil && len(diOp.Value) == 0:
+		pq.logger.Info("Initializing new persistent queue")
+	default:
+		var riErr, wiErr error
+		pq.readIndex, riErr = bytesToItemIndex(riOp.Value)
+		pq.writeIndex, wiErr = bytesToItemIndex(wiOp.Value)
+		// The read index is not stored until the first item is read, the first item is then still stored.
+		if errors.Is(riErr, errValueNotSet) && len(diOp.Value) == 0 {
+			if found, err := pq.hasItem(ctx, 0); err != nil || found {
+				riErr = nil
+			}
+		}
+		if riErr != nil || wiErr != nil {
+			pq.logger.Error("Invalid read/write index, rebuilding them from the stored items",
+				zap.NamedError("readIndexError", riErr), zap.NamedError("writeIndexError", wiErr))
+			pq.rebuildIndexes(ctx, riErr == nil, wiErr == nil, diOp.Value)
+		}
+	}
 
-	err := pq.client.Batch(ctx, riOp, wiOp)
-	if err == nil {
-		pq.readIndex, err = bytesToItemIndex(riOp.Value)
+	// The read index can only be ahead of the write index if the storage is corrupted, the items in between
+	// are not referenced by the queue anymore.
+	if pq.readIndex > pq.writeIndex {
+		pq.logger.Error("Read index is ahead of the write index, resetting the read index",
+			zap.Uint64(readIndexKey, pq.readIndex), zap.Uint64(writeIndexKey, pq.writeIndex))
+		pq.readIndex = pq.writeIndex
+		if err = pq.client.Set(ctx, readIndexKey, itemIndexToBytes(pq.readIndex)); err != nil {
+			pq.logger.Error("Failed resetting the read index", zap.Error(err))
+		}
 	}
 
+	qiBuf, err := pq.client.Get(ctx, quarantineIndexKey)
 	if err == nil {
-		pq.writeIndex, err = bytesToItemIndex(wiOp.Value)
+		pq.quarantineIndex, err = bytesToItemIndex(qiBuf)
 	}
-
-	if err != nil {
-		if errors.Is(err, errValueNotSet) {
-			pq.logger.Info("Initializing new persistent queue")
-		} else {
-			pq.logger.Error("Failed getting read/write index, starting with new ones", zap.Error(err))
-		}
-		pq.readIndex = 0
-		pq.writeIndex = 0
+	if err != nil && !errors.Is(err, errValueNotSet) {
+		pq.logger.Error("Failed getting the quarantine index", zap.Error(err))
 	}
 
 	queueSize := pq.writeIndex - pq.readIndex
@@ -168,6 +215,107 @@ func (pq *persistentQueue[T]) initPersistentContiguousStorage(ctx context.Contex
 	pq.queueSize = int64(queueSize)
 }
 
+// rebuildIndexes rebuilds the read and write indexes that could not be read, and stores them again. The items
+// waiting in the queue are stored under contiguous keys from the read index to the write index, so a lost index
+// is found by looking up the item keys from the other one. If both are lost, the first stored item is looked up
+// after the currently dispatched items, which are before the read index. Caller must hold the lock or be the
+// only user of the queue.
+func (pq *persistentQueue[T]) rebuildIndexes(ctx context.Context, knownRead, knownWrite bool, dispatchedBuf []byte) {
+	var low uint64
+	if dispatched, err := bytesToItemIndexArray(dispatchedBuf); err == nil {
+		for _, index := range dispatched {
+			low = max(low, index+1)
+		}
+	}
+
+	var err error
+	switch {
+	case knownRead:
+		pq.writeIndex, err = pq.nextMissingItem(ctx, pq.readIndex)
+	case knownWrite:
+		pq.readIndex = pq.writeIndex
+		var found bool
+		for pq.readIndex > low {
+			if found, err = pq.hasItem(ctx, pq.readIndex-1); err != nil || !found {
+				break
+			}
+			pq.readIndex--
+		}
+	default:
+		pq.readIndex, pq.writeIndex = low, low
+		for index := low; index < low+maxRebuildProbes; index++ {
+			var found bool
+			if found, err = pq.hasItem(ctx, index); err != nil {
+				break
+			}
+			if found {
+				pq.readIndex = index
+				pq.writeIndex, err = pq.nextMissingItem(ctx, index)
+				break
+			}
+		}
+	}
+	if err != nil {
+		pq.logger.Error("Failed rebuilding the read/write index, starting after the dispatched items", zap.Error(err))
+		pq.readIndex, pq.writeIndex = low, low
+		return
+	}
+
+	// Store the rebuilt indexes, so the stored items are referenced again by the queue.
+	if err = pq.client.Batch(ctx,
+		storage.SetOperation(readIndexKey, itemIndexToBytes(pq.readIndex)),
+		storage.SetOperation(writeIndexKey, itemIndexToBytes(pq.writeIndex))); err != nil {
+		pq.logger.Error("Failed storing the rebuilt read/write index", zap.Error(err))
+	}
+	pq.logger.Warn("Rebuilt the read/write index from the stored items",
+		zap.Uint64(readIndexKey, pq.readIndex), zap.Uint64(writeIndexKey, pq.writeIndex))
+}
+
+// hasItem reports whether an item is stored at the index.
+func (pq *persistentQueue[T]) hasItem(ctx context.Context, index uint64) (bool, error) {
+	val, err := pq.client.Get(ctx, getItemKey(index))
+	return val != nil, err
+}
+
+// nextMissingItem returns the index of the first missing item from the given index.
+func (pq *persistentQueue[T]) nextMissingItem(ctx context.Context, index uint64) (uint64, error) {
+	for {
+		found, err := pq.hasItem(ctx, index)
+		if err != nil || !found {
+			return index, err
+		}
+		index++
+	}
+}
+
+// quarantineOps returns the operations storing the value of an unreadable item under a quarantine key,
+// so it can be inspected later without blocking the queue. Only the last maxQuarantinedItems items are kept.
+// Caller must hold the lock.
+func (pq *persistentQueue[T]) quarantineOps(value []byte) []*storage.Operation {
+	key := getQuarantineKey(pq.quarantineIndex)
+	pq.quarantineIndex++
+	ops := []*storage.Operation{
+		storage.SetOperation(key, value),
+		storage.SetOperation(quarantineIndexKey, itemIndexToBytes(pq.quarantineIndex)),
+	}
+	if pq.quarantineIndex > maxQuarantinedItems {
+		ops = append(ops, storage.DeleteOperation(getQuarantineKey(pq.quarantineIndex-maxQuarantinedItems-1)))
+	}
+	return ops
+}
+
+func (pq *persistentQueue[T]) recordIntegrityIssues(ctx context.Context, quarantined, missing int) {
+	if pq.telemetryBuilder == nil {
+		return
+	}
+	if quarantined > 0 {
+		pq.telemetryBuilder.ExporterQueueQuarantinedItems.Add(ctx, int64(quarantined), pq.metricAttr)
+	}
+	if missing > 0 {
+		pq.telemetryBuilder.ExporterQueueMissingItems.Add(ctx, int64(missing), pq.metricAttr)
+	}
+}
+
 // restoreQueueSizeFromStorage restores the queue size from storage.
 func (pq *persistentQueue[T]) restoreQueueSizeFromStorage(ctx context.Context) (uint64, error) {
 	val, err := pq.client.Get(ctx, queueSizeKey)
@@ -235,6 +383,10 @@ func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
 // putInternal is the internal version that requires caller to hold the mutex lock.
 func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
 	reqSize := pq.set.sizer.Sizeof(req)
+	// A request larger than the whole capacity can never fit, don't wait for space.
+	if reqSize > pq.set.capacity {
+		return ErrQueueIsFull
+	}
 	for pq.queueSize+reqSize > pq.set.capacity {
 		if !pq.set.blocking {
 			return ErrQueueIsFull
@@ -327,7 +479,7 @@ func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, bool)
 
 	var request T
 	if err == nil {
-		request, err = pq.set.unmarshaler(getOp.Value)
+		request, err = pq.unmarshalItem(ctx, getOp)
 	}
 
 	if err != nil {
@@ -347,6 +499,26 @@ func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, bool)
 	return index, request, true
 }
 
+// unmarshalItem unmarshals the read item, the missing items are skipped and the unreadable items are moved to the
+// quarantine, so they don't stall the queue. Caller must hold the lock.
+func (pq *persistentQueue[T]) unmarshalItem(ctx context.Context, getOp *storage.Operation) (T, error) {
+	if getOp.Value == nil {
+		pq.logger.Warn("Skipping missing item", zap.String(zapKey, getOp.Key))
+		pq.recordIntegrityIssues(ctx, 0, 1)
+		var request T
+		return request, errValueNotSet
+	}
+	request, err := pq.set.unmarshaler(getOp.Value)
+	if err != nil {
+		pq.logger.Warn("Moving unreadable item to the quarantine", zap.String(zapKey, getOp.Key), zap.Error(err))
+		if qErr := pq.client.Batch(ctx, pq.quarantineOps(getOp.Value)...); qErr != nil {
+			pq.logger.Error("Failed moving unreadable item to the quarantine", zap.Error(qErr))
+		}
+		pq.recordIntegrityIssues(ctx, 1, 0)
+	}
+	return request, err
+}
+
 // OnProcessingFinished should be called to remove the item of the given index from the queue once processing is finished.
 func (pq *persistentQueue[T]) OnProcessingFinished(index uint64, consumeErr error) {
 	// Delete the item from the persistent storage after it was processed.
@@ -422,15 +594,19 @@ func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Co
 	}
 
 	errCount := 0
+	var quarantined, missing int
+	var quarantineBatch []*storage.Operation
 	for _, op := range retrieveBatch {
 		if op.Value == nil {
 			pq.logger.Warn("Failed retrieving item", zap.String(zapKey, op.Key), zap.Error(errValueNotSet))
+			missing++
 			continue
 		}
 		req, err := pq.set.unmarshaler(op.Value)
-		// If error happened or item is nil, it will be efficiently ignored
 		if err != nil {
-			pq.logger.Warn("Failed unmarshalling item", zap.String(zapKey, op.Key), zap.Error(err))
+			pq.logger.Warn("Moving unreadable item to the quarantine", zap.String(zapKey, op.Key), zap.Error(err))
+			quarantineBatch = append(quarantineBatch, pq.quarantineOps(op.Value)...)
+			quarantined++
 			continue
 		}
 		if pq.putInternal(ctx, req) != nil {
@@ -438,6 +614,13 @@ func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Co
 		}
 	}
 
+	if len(quarantineBatch) > 0 {
+		if err = pq.client.Batch(ctx, quarantineBatch...); err != nil {
+			pq.logger.Error("Failed moving unreadable items to the quarantine", zap.Error(err))
+		}
+	}
+	pq.recordIntegrityIssues(ctx, quarantined, missing)
+
 	if errCount > 0 {
 		pq.logger.Error("Errors occurred while moving items for dispatching back to queue",
 			zap.Int(zapNumberOfItems, len(retrieveBatch)), zap.Int(zapErrorCount, errCount))
@@ -501,6 +684,10 @@ func getItemKey(index uint64) string {
 	return strconv.FormatUint(index, 10)
 }
 
+func getQuarantineKey(index uint64) string {
+	return "q" + strconv.FormatUint(index, 10)
+}
+
 func itemIndexToBytes(value uint64) []byte {
 	return binary.LittleEndian.AppendUint64([]byte{}, value)
 }
@@ -18,8 +18,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterqueue/internal/metadatatest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/exporter/internal/storagetest"
@@ -28,10 +32,10 @@ import (
 	"go.opentelemetry.io/collector/pipeline"
 )
 
-// itemsSizer is a sizer implementation that returns the size of a queue element as the number of items it contains.
-type itemsSizer struct{}
+// uint64Sizer is a sizer implementation that returns the value of a queue element as its size.
+type uint64Sizer struct{}
 
-func (is *itemsSizer) Sizeof(val uint64) int64 {
+func (is *uint64Sizer) Sizeof(val uint64) int64 {
 	if val > math.MaxInt64 {
 		return math.MaxInt64
 	}
@@ -260,7 +264,7 @@ func createTestPersistentQueueWithRequestsCapacity(tb testing.TB, ext storage.Ex
 }
 
 func createTestPersistentQueueWithItemsCapacity(tb testing.TB, ext storage.Extension, capacity int64) *persistentQueue[uint64] {
-	return createTestPersistentQueueWithCapacityLimiter(tb, ext, &itemsSizer{}, capacity)
+	return createTestPersistentQueueWithCapacityLimiter(tb, ext, &uint64Sizer{}, capacity)
 }
 
 func createTestPersistentQueueWithCapacityLimiter(tb testing.TB, ext storage.Extension, sizer sizer[uint64],
@@ -294,7 +298,7 @@ func TestPersistentQueue_FullCapacity(t *testing.T) {
 		},
 		{
 			name:           "items_capacity",
-			sizer:          &itemsSizer{},
+			sizer:          &uint64Sizer{},
 			capacity:       55,
 			sizeMultiplier: 10,
 		},
@@ -407,7 +411,7 @@ func TestPersistentBlockingQueue(t *testing.T) {
 		},
 		{
 			name:  "items_based",
-			sizer: &itemsSizer{},
+			sizer: &uint64Sizer{},
 		},
 	}
 
@@ -579,12 +583,12 @@ func TestPersistentQueue_CorruptedData(t *testing.T) {
 		{
 			name:             "corrupted read index",
 			corruptReadIndex: true,
-			desiredQueueSize: 1, // The dispatched item.
+			desiredQueueSize: 3, // The read index is rebuilt from the write index.
 		},
 		{
 			name:              "corrupted write index",
 			corruptWriteIndex: true,
-			desiredQueueSize:  1, // The dispatched item.
+			desiredQueueSize:  3, // The write index is rebuilt from the read index.
 		},
 		{
 			name:                               "corrupted everything",
@@ -592,7 +596,7 @@ func TestPersistentQueue_CorruptedData(t *testing.T) {
 			corruptCurrentlyDispatchedItemsKey: true,
 			corruptReadIndex:                   true,
 			corruptWriteIndex:                  true,
-			desiredQueueSize:                   0,
+			desiredQueueSize:                   3, // The indexes are rebuilt from the stored items, quarantined once read.
 		},
 	}
 
@@ -645,6 +649,192 @@ func TestPersistentQueue_CorruptedData(t *testing.T) {
 	}
 }
 
+func TestPersistentQueue_SkipUnreadableItems(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithItemsCapacity(t, ext, 1000)
+	for i := uint64(1); i <= 5; i++ {
+		require.NoError(t, ps.Offer(context.Background(), i))
+	}
+	badBytes := []byte{0, 1, 2}
+	require.NoError(t, ps.client.Set(context.Background(), getItemKey(1), badBytes))
+	require.NoError(t, ps.client.Delete(context.Background(), getItemKey(3)))
+	require.NoError(t, ps.Shutdown(context.Background()))
+
+	tel := metadatatest.SetupTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := tel.NewSettings()
+	newPs := newPersistentQueue[uint64](persistentQueueSettings[uint64]{
+		sizer:       &uint64Sizer{},
+		capacity:    1000,
+		signal:      pipeline.SignalTraces,
+		storageID:   component.ID{},
+		marshaler:   uint64Marshaler,
+		unmarshaler: uint64Unmarshaler,
+		set:         set,
+	}).(*persistentQueue[uint64])
+	require.NoError(t, newPs.Start(context.Background(), &mockHost{ext: map[component.ID]component.Component{{}: ext}}))
+
+	// The items are not read on startup, the unreadable and missing items are skipped when they are read.
+	assert.Equal(t, int64(15), newPs.Size())
+	for _, want := range []uint64{1, 3, 5} {
+		require.True(t, consume(newPs, func(_ context.Context, item uint64) error {
+			assert.Equal(t, want, item)
+			return nil
+		}))
+	}
+	assert.Equal(t, int64(0), newPs.Size())
+	val, err := newPs.client.Get(context.Background(), getQuarantineKey(0))
+	require.NoError(t, err)
+	assert.Equal(t, badBytes, val)
+	require.NoError(t, newPs.Shutdown(context.Background()))
+
+	attrs := attribute.NewSet(attribute.String("exporter", set.ID.String()), attribute.String("data_type", "traces"))
+	tel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_queue_missing_items",
+			Description: "Number of items of the persistent queue that were referenced by the queue indexes but not found in the storage. [alpha]",
+			Unit:        "{batches}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints:  []metricdata.DataPoint[int64]{{Value: 1, Attributes: attrs}},
+			},
+		},
+		{
+			Name:        "otelcol_exporter_queue_quarantined_items",
+			Description: "Number of items of the persistent queue that failed to be read and were moved to the quarantine. [alpha]",
+			Unit:        "{batches}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints:  []metricdata.DataPoint[int64]{{Value: 1, Attributes: attrs}},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestPersistentQueue_QuarantineDispatchedItems(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+	for i := uint64(1); i <= 3; i++ {
+		require.NoError(t, ps.Offer(context.Background(), i))
+	}
+	// Keep the first two items dispatched and corrupt one of them.
+	for i := 0; i < 2; i++ {
+		_, _, _, found := ps.Read(context.Background())
+		require.True(t, found)
+	}
+	require.NoError(t, ps.client.Set(context.Background(), getItemKey(0), []byte{0, 1, 2}))
+	require.NoError(t, ps.Shutdown(context.Background()))
+
+	newPs := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+	assert.Equal(t, int64(2), newPs.Size())
+	assert.Equal(t, uint64(1), newPs.quarantineIndex)
+	for _, want := range []uint64{3, 2} {
+		require.True(t, consume(newPs, func(_ context.Context, item uint64) error {
+			assert.Equal(t, want, item)
+			return nil
+		}))
+	}
+}
+
+func TestPersistentQueue_RebuildIndexes(t *testing.T) {
+	cases := []struct {
+		name       string
+		deleteKeys []string
+	}{
+		{
+			name:       "lost write index",
+			deleteKeys: []string{writeIndexKey},
+		},
+		{
+			name:       "lost read index",
+			deleteKeys: []string{readIndexKey},
+		},
+		{
+			name:       "lost read and write indexes",
+			deleteKeys: []string{readIndexKey, writeIndexKey},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ext := storagetest.NewMockStorageExtension(nil)
+			ps := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+			for i := uint64(1); i <= 5; i++ {
+				require.NoError(t, ps.Offer(context.Background(), i))
+			}
+			// The first item is done, the second one is still dispatched.
+			require.True(t, consume(ps, func(context.Context, uint64) error { return nil }))
+			_, _, _, found := ps.Read(context.Background())
+			require.True(t, found)
+			for _, key := range c.deleteKeys {
+				require.NoError(t, ps.client.Delete(context.Background(), key))
+			}
+			require.NoError(t, ps.Shutdown(context.Background()))
+
+			// The stored items are found again, the dispatched one is moved at the back of the queue.
+			newPs := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+			assert.Equal(t, int64(4), newPs.Size())
+			for _, want := range []uint64{3, 4, 5, 2} {
+				require.True(t, consume(newPs, func(_ context.Context, item uint64) error {
+					assert.Equal(t, want, item)
+					return nil
+				}))
+			}
+			require.NoError(t, newPs.Shutdown(context.Background()))
+
+			// The rebuilt indexes are stored.
+			newPs = createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+			assert.Equal(t, int64(0), newPs.Size())
+			assert.Equal(t, uint64(6), newPs.readIndex)
+			assert.Equal(t, uint64(6), newPs.writeIndex)
+		})
+	}
+}
+
+func TestPersistentQueue_QuarantineLimit(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+	for i := uint64(0); i < maxQuarantinedItems+2; i++ {
+		require.NoError(t, ps.Offer(context.Background(), i))
+		require.NoError(t, ps.client.Set(context.Background(), getItemKey(i), []byte{0, 1, 2}))
+	}
+	require.NoError(t, ps.Offer(context.Background(), uint64(1)))
+	require.True(t, consume(ps, func(_ context.Context, item uint64) error {
+		assert.Equal(t, uint64(1), item)
+		return nil
+	}))
+
+	// Only the last quarantined items are kept.
+	assert.Equal(t, uint64(maxQuarantinedItems+2), ps.quarantineIndex)
+	for i, want := range map[uint64]bool{0: false, 1: false, 2: true, maxQuarantinedItems + 1: true} {
+		val, err := ps.client.Get(context.Background(), getQuarantineKey(i))
+		require.NoError(t, err)
+		assert.Equal(t, want, val != nil, i)
+	}
+	require.NoError(t, ps.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_ReadIndexAheadOfWriteIndex(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+	for i := uint64(1); i <= 2; i++ {
+		require.NoError(t, ps.Offer(context.Background(), i))
+	}
+	require.NoError(t, ps.client.Set(context.Background(), readIndexKey, itemIndexToBytes(10)))
+	require.NoError(t, ps.Shutdown(context.Background()))
+
+	// The inconsistent read index must not stall the queue.
+	newPs := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
+	assert.Equal(t, int64(0), newPs.Size())
+	assert.Equal(t, newPs.writeIndex, newPs.readIndex)
+	require.NoError(t, newPs.Offer(context.Background(), uint64(3)))
+	require.True(t, consume(newPs, func(_ context.Context, item uint64) error {
+		assert.Equal(t, uint64(3), item)
+		return nil
+	}))
+}
+
 func TestPersistentQueue_CurrentlyProcessedItems(t *testing.T) {
 	req := uint64(50)
 
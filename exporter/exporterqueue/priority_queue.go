@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporterqueue"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// priorityQueue is an in-memory queue with multiple lanes. Every lane has its own capacity, the consumers read
+// from the lane with the highest priority that has elements, unless a lower priority lane is starving.
+type priorityQueue[T any] struct {
+	component.StartFunc
+	sizer           sizer[T]
+	laneCap         int64
+	blocking        bool
+	selector        func(context.Context, T) int
+	starvationLimit int
+
+	mu              sync.Mutex
+	hasMoreElements *sync.Cond
+	lanes           []*priorityLane[T]
+	stopped         bool
+}
+
+type priorityLane[T any] struct {
+	items        *linkedQueue[T]
+	size         int64
+	hasMoreSpace *cond
+	// skipped is the number of consecutive reads served by higher priority lanes while this lane had elements.
+	skipped int
+}
+
+// priorityQueueSettings defines internal parameters for priorityQueue creation.
+type priorityQueueSettings[T any] struct {
+	sizer           sizer[T]
+	laneCapacity    int64
+	blocking        bool
+	lanes           int
+	selector        func(context.Context, T) int
+	starvationLimit int
+}
+
+func newPriorityQueue[T any](set priorityQueueSettings[T]) Queue[T] {
+	pq := &priorityQueue[T]{
+		sizer:           set.sizer,
+		laneCap:         set.laneCapacity,
+		blocking:        set.blocking,
+		selector:        set.selector,
+		starvationLimit: set.starvationLimit,
+		lanes:           make([]*priorityLane[T], max(set.lanes, 1)),
+	}
+	pq.hasMoreElements = sync.NewCond(&pq.mu)
+	for i := range pq.lanes {
+		pq.lanes[i] = &priorityLane[T]{items: &linkedQueue[T]{}, hasMoreSpace: newCond(&pq.mu)}
+	}
+	return pq
+}
+
+// laneOf returns the lane selected for the element, the lowest priority lane if the selected one does not exist.
+func (pq *priorityQueue[T]) laneOf(ctx context.Context, el T) *priorityLane[T] {
+	idx := pq.selector(ctx, el)
+	if idx < 0 || idx >= len(pq.lanes) {
+		idx = len(pq.lanes) - 1
+	}
+	return pq.lanes[idx]
+}
+
+// Offer puts the element into its lane if there is enough capacity in the lane.
+// Returns an error if the lane is full.
+func (pq *priorityQueue[T]) Offer(ctx context.Context, el T) error {
+	elSize := pq.sizer.Sizeof(el)
+	if elSize == 0 {
+		return nil
+	}
+
+	if elSize <= 0 {
+		return errInvalidSize
+	}
+
+	// An element larger than the whole capacity of a lane can never fit, don't wait for space.
+	if elSize > pq.laneCap {
+		return ErrQueueIsFull
+	}
+
+	lane := pq.laneOf(ctx, el)
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	for lane.size+elSize > pq.laneCap {
+		if !pq.blocking {
+			return ErrQueueIsFull
+		}
+		// Wait for more space or before the ctx is Done.
+		if err := lane.hasMoreSpace.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	lane.size += elSize
+	lane.items.push(ctx, el, elSize)
+	// Signal one consumer if any.
+	pq.hasMoreElements.Signal()
+	return nil
+}
+
+// Read removes the next element from the queue and returns it.
+// The call blocks until there is an item available or the queue is stopped.
+func (pq *priorityQueue[T]) Read(context.Context) (uint64, context.Context, T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	for {
+		if lane := pq.nextLane(); lane != nil {
+			elCtx, el, elSize := lane.items.pop()
+			lane.size -= elSize
+			lane.hasMoreSpace.Signal()
+			return 0, elCtx, el, true
+		}
+
+		if pq.stopped {
+			var el T
+			return 0, context.Background(), el, false
+		}
+
+		pq.hasMoreElements.Wait()
+	}
+}
+
+// nextLane returns the lane to read from and updates the starvation counters, or nil if all the lanes are empty.
+// Caller must hold the lock.
+func (pq *priorityQueue[T]) nextLane() *priorityLane[T] {
+	var next *priorityLane[T]
+	for _, lane := range pq.lanes {
+		if lane.size == 0 {
+			continue
+		}
+		if next == nil {
+			next = lane
+		}
+		// A starving lane is served before the higher priority lanes.
+		if pq.starvationLimit > 0 && lane.skipped >= pq.starvationLimit {
+			next = lane
+			break
+		}
+	}
+	if next == nil {
+		return nil
+	}
+
+	for _, lane := range pq.lanes {
+		switch {
+		case lane == next, lane.size == 0:
+			lane.skipped = 0
+		default:
+			lane.skipped++
+		}
+	}
+	return next
+}
+
+// OnProcessingFinished should be called to remove the item of the given index from the queue once processing is finished.
+// For in memory queue, this function is noop.
+func (pq *priorityQueue[T]) OnProcessingFinished(uint64, error) {}
+
+// Shutdown closes the queue to initiate draining of the queue.
+func (pq *priorityQueue[T]) Shutdown(context.Context) error {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	pq.stopped = true
+	pq.hasMoreElements.Broadcast()
+	return nil
+}
+
+func (pq *priorityQueue[T]) Size() int64 {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	var size int64
+	for _, lane := range pq.lanes {
+		size += lane.size
+	}
+	return size
+}
+
+func (pq *priorityQueue[T]) Capacity() int64 {
+	return pq.laneCap * int64(len(pq.lanes))
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// laneByPrefix selects the lane by the first character of the element, "h" for high, "m" for medium, "l" for low.
+func laneByPrefix(_ context.Context, el string) int {
+	switch {
+	case strings.HasPrefix(el, "h"):
+		return 0
+	case strings.HasPrefix(el, "m"):
+		return 1
+	case strings.HasPrefix(el, "l"):
+		return 2
+	}
+	return -1
+}
+
+func newTestPriorityQueue(capacity int64, starvationLimit int) Queue[string] {
+	return newPriorityQueue[string](priorityQueueSettings[string]{
+		sizer:           &requestSizer[string]{},
+		laneCapacity:    capacity,
+		lanes:           3,
+		selector:        laneByPrefix,
+		starvationLimit: starvationLimit,
+	})
+}
+
+func readAll(t *testing.T, q Queue[string], n int) []string {
+	var res []string
+	for i := 0; i < n; i++ {
+		require.True(t, consume(q, func(_ context.Context, item string) error {
+			res = append(res, item)
+			return nil
+		}))
+	}
+	return res
+}
+
+func TestPriorityQueue_StrictPriority(t *testing.T) {
+	q := newTestPriorityQueue(10, 0)
+	for _, el := range []string{"l1", "m1", "h1", "l2", "h2", "m2", "x1"} {
+		require.NoError(t, q.Offer(context.Background(), el))
+	}
+	assert.Equal(t, int64(7), q.Size())
+	assert.Equal(t, int64(30), q.Capacity())
+
+	// Elements without a lane are added to the lowest priority lane.
+	assert.Equal(t, []string{"h1", "h2", "m1", "m2", "l1", "l2", "x1"}, readAll(t, q, 7))
+	assert.Equal(t, int64(0), q.Size())
+}
+
+func TestPriorityQueue_StarvationLimit(t *testing.T) {
+	q := newTestPriorityQueue(10, 2)
+	for _, el := range []string{"l1", "l2", "h1", "h2", "h3", "h4", "h5", "h6"} {
+		require.NoError(t, q.Offer(context.Background(), el))
+	}
+	assert.Equal(t, []string{"h1", "h2", "l1", "h3", "h4", "l2", "h5", "h6"}, readAll(t, q, 8))
+}
+
+func TestPriorityQueue_LaneCapacity(t *testing.T) {
+	q := newTestPriorityQueue(1, 0)
+	require.NoError(t, q.Offer(context.Background(), "l1"))
+	require.ErrorIs(t, q.Offer(context.Background(), "l2"), ErrQueueIsFull)
+	// A full low priority lane does not prevent adding high priority elements.
+	require.NoError(t, q.Offer(context.Background(), "h1"))
+	require.ErrorIs(t, q.Offer(context.Background(), "h2"), ErrQueueIsFull)
+	assert.Equal(t, []string{"h1", "l1"}, readAll(t, q, 2))
+}
+
+func TestPriorityQueue_ShutdownDrains(t *testing.T) {
+	q := NewPriorityMemoryQueueFactory[string](PriorityQueueSettings[string]{
+		Lanes:    3,
+		Selector: laneByPrefix,
+	})(context.Background(), Settings{Signal: pipeline.SignalLogs, ExporterSettings: exportertest.NewNopSettings()},
+		Config{Enabled: true, NumConsumers: 1, QueueSize: 10, Sizer: SizerTypeRequests})
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	var mu sync.Mutex
+	var consumed []string
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for consume(q, func(_ context.Context, item string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			consumed = append(consumed, item)
+			return nil
+		}) {
+		}
+	}()
+
+	require.NoError(t, q.Offer(context.Background(), "l1"))
+	require.NoError(t, q.Offer(context.Background(), "h1"))
+	require.NoError(t, q.Shutdown(context.Background()))
+	wg.Wait()
+	assert.ElementsMatch(t, []string{"l1", "h1"}, consumed)
+}
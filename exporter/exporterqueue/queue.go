@@ -67,7 +67,7 @@ type Factory[T any] func(context.Context, Settings, Config) Queue[T]
 func NewMemoryQueueFactory[T any]() Factory[T] {
 	return func(_ context.Context, _ Settings, cfg Config) Queue[T] {
 		return newBoundedMemoryQueue[T](memoryQueueSettings[T]{
-			sizer:    &requestSizer[T]{},
+			sizer:    newSizer[T](cfg.Sizer),
 			capacity: int64(cfg.QueueSize),
 			blocking: cfg.Blocking,
 		})
@@ -94,7 +94,7 @@ func NewPersistentQueueFactory[T any](storageID *component.ID, factorySettings P
 	}
 	return func(_ context.Context, set Settings, cfg Config) Queue[T] {
 		return newPersistentQueue[T](persistentQueueSettings[T]{
-			sizer:       &requestSizer[T]{},
+			sizer:       newSizer[T](cfg.Sizer),
 			capacity:    int64(cfg.QueueSize),
 			blocking:    cfg.Blocking,
 			signal:      set.Signal,
@@ -105,3 +105,34 @@ func NewPersistentQueueFactory[T any](storageID *component.ID, factorySettings P
 		})
 	}
 }
+
+// PriorityQueueSettings defines developer settings for the priority queue factory.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type PriorityQueueSettings[T any] struct {
+	// Lanes is the number of priority lanes, the lane 0 having the highest priority.
+	Lanes int
+	// Selector returns the lane of the element. Elements selected to a lane that does not exist are added to
+	// the lowest priority lane.
+	Selector func(context.Context, T) int
+	// StarvationLimit is the number of consecutive reads from higher priority lanes after which a lane with
+	// elements is read from first. Zero means the lanes are always read in strict priority order.
+	StarvationLimit int
+}
+
+// NewPriorityMemoryQueueFactory returns a factory to create a new memory queue with priority lanes.
+// Every lane has a capacity of the configured queue size.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func NewPriorityMemoryQueueFactory[T any](factorySettings PriorityQueueSettings[T]) Factory[T] {
+	return func(_ context.Context, _ Settings, cfg Config) Queue[T] {
+		return newPriorityQueue[T](priorityQueueSettings[T]{
+			sizer:           newSizer[T](cfg.Sizer),
+			laneCapacity:    int64(cfg.QueueSize),
+			blocking:        cfg.Blocking,
+			lanes:           factorySettings.Lanes,
+			selector:        factorySettings.Selector,
+			starvationLimit: factorySettings.StarvationLimit,
+		})
+	}
+}
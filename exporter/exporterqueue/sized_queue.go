@@ -85,6 +85,11 @@ func (sq *sizedQueue[T]) Offer(ctx context.Context, el T) error {
 		return errInvalidSize
 	}
 
+	// An element larger than the whole capacity can never fit, don't wait for space.
+	if elSize > sq.cap {
+		return ErrQueueIsFull
+	}
+
 	sq.mu.Lock()
 	defer sq.mu.Unlock()
 
@@ -3,14 +3,85 @@
 
 package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporterqueue"
 
+import (
+	"fmt"
+)
+
+// SizerType defines the unit used to measure the size and the capacity of the queue.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type SizerType string
+
+const (
+	// SizerTypeRequests measures the queue size as the number of requests.
+	SizerTypeRequests SizerType = "requests"
+	// SizerTypeItems measures the queue size as the number of items (spans, data points, log records or profiles)
+	// in the requests. Elements must implement `ItemsCount() int`, otherwise they count as one item.
+	SizerTypeItems SizerType = "items"
+	// SizerTypeBytes measures the queue size as the serialized size of the requests in bytes.
+	// Elements must implement `ByteSize() int`, otherwise they count as one byte.
+	SizerTypeBytes SizerType = "bytes"
+)
+
+// Validate checks if the SizerType is supported.
+func (st SizerType) Validate() error {
+	switch st {
+	case "", SizerTypeRequests, SizerTypeItems, SizerTypeBytes:
+		return nil
+	}
+	return fmt.Errorf("unsupported sizer %q, must be one of %q, %q or %q", st, SizerTypeRequests, SizerTypeItems, SizerTypeBytes)
+}
+
 // sizer is an interface that returns the size of the given element.
 type sizer[T any] interface {
 	Sizeof(T) int64
 }
 
+// newSizer returns the sizer for the given SizerType. It defaults to the requestSizer.
+func newSizer[T any](st SizerType) sizer[T] {
+	switch st {
+	case SizerTypeItems:
+		return &itemsSizer[T]{}
+	case SizerTypeBytes:
+		return &bytesSizer[T]{}
+	default:
+		return &requestSizer[T]{}
+	}
+}
+
 // requestSizer is a sizer implementation that returns the size of a queue element as one request.
 type requestSizer[T any] struct{}
 
 func (rs *requestSizer[T]) Sizeof(T) int64 {
 	return 1
 }
+
+// itemsCounter is implemented by the queue elements that can report the number of items they contain.
+type itemsCounter interface {
+	ItemsCount() int
+}
+
+// itemsSizer is a sizer implementation that returns the size of a queue element as the number of items it contains.
+type itemsSizer[T any] struct{}
+
+func (is *itemsSizer[T]) Sizeof(el T) int64 {
+	if ic, ok := any(el).(itemsCounter); ok {
+		return int64(ic.ItemsCount())
+	}
+	return 1
+}
+
+// byteSizer is implemented by the queue elements that can report their serialized size in bytes.
+type byteSizer interface {
+	ByteSize() int
+}
+
+// bytesSizer is a sizer implementation that returns the size of a queue element as its serialized size in bytes.
+type bytesSizer[T any] struct{}
+
+func (bs *bytesSizer[T]) Sizeof(el T) int64 {
+	if b, ok := any(el).(byteSizer); ok {
+		return int64(b.ByteSize())
+	}
+	return 1
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sizedElement struct {
+	items int
+	bytes int
+}
+
+func (se sizedElement) ItemsCount() int {
+	return se.items
+}
+
+func (se sizedElement) ByteSize() int {
+	return se.bytes
+}
+
+func TestSizerType_Validate(t *testing.T) {
+	for _, st := range []SizerType{"", SizerTypeRequests, SizerTypeItems, SizerTypeBytes} {
+		require.NoError(t, st.Validate())
+	}
+	assert.Error(t, SizerType("spans").Validate())
+}
+
+func TestNewSizer(t *testing.T) {
+	el := sizedElement{items: 5, bytes: 300}
+	assert.Equal(t, int64(1), newSizer[sizedElement]("").Sizeof(el))
+	assert.Equal(t, int64(1), newSizer[sizedElement](SizerTypeRequests).Sizeof(el))
+	assert.Equal(t, int64(5), newSizer[sizedElement](SizerTypeItems).Sizeof(el))
+	assert.Equal(t, int64(300), newSizer[sizedElement](SizerTypeBytes).Sizeof(el))
+
+	// Elements that cannot report their size count as one unit.
+	assert.Equal(t, int64(1), newSizer[string](SizerTypeItems).Sizeof("a"))
+	assert.Equal(t, int64(1), newSizer[string](SizerTypeBytes).Sizeof("a"))
+}
+
+func TestMemoryQueueFactory_BytesSizer(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Sizer = SizerTypeBytes
+	cfg.QueueSize = 1000
+	cfg.Blocking = false
+	q := NewMemoryQueueFactory[sizedElement]()(context.Background(), Settings{}, cfg)
+
+	require.NoError(t, q.Offer(context.Background(), sizedElement{items: 1, bytes: 600}))
+	assert.Equal(t, int64(600), q.Size())
+	assert.Equal(t, int64(1000), q.Capacity())
+	require.ErrorIs(t, q.Offer(context.Background(), sizedElement{items: 1, bytes: 600}), ErrQueueIsFull)
+	require.NoError(t, q.Offer(context.Background(), sizedElement{items: 1, bytes: 400}))
+	assert.Equal(t, int64(1000), q.Size())
+}
+
+func TestMemoryQueueFactory_ItemsSizer(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Sizer = SizerTypeItems
+	cfg.QueueSize = 10
+	q := NewMemoryQueueFactory[sizedElement]()(context.Background(), Settings{}, cfg)
+
+	require.NoError(t, q.Offer(context.Background(), sizedElement{items: 7, bytes: 1}))
+	assert.Equal(t, int64(7), q.Size())
+
+	// An element larger than the capacity is rejected even for a blocking queue.
+	require.ErrorIs(t, q.Offer(context.Background(), sizedElement{items: 11, bytes: 1}), ErrQueueIsFull)
+	assert.Equal(t, int64(7), q.Size())
+}
@@ -5,7 +5,9 @@ go 1.22.0
 require (
 	github.com/cenkalti/backoff/v4 v4.3.0
 	github.com/stretchr/testify v1.10.0
+	go.opentelemetry.io/collector/client v1.23.0
 	go.opentelemetry.io/collector/component v0.117.0
+	go.opentelemetry.io/collector/component/componentstatus v0.117.0
 	go.opentelemetry.io/collector/component/componenttest v0.117.0
 	go.opentelemetry.io/collector/config/configretry v1.23.0
 	go.opentelemetry.io/collector/config/configtelemetry v0.117.0
@@ -58,6 +60,8 @@ require (
 
 replace go.opentelemetry.io/collector/component => ../component
 
+replace go.opentelemetry.io/collector/component/componentstatus => ../component/componentstatus
+
 replace go.opentelemetry.io/collector/component/componenttest => ../component/componenttest
 
 replace go.opentelemetry.io/collector/consumer => ../consumer
@@ -99,3 +103,5 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../extension/ex
 replace go.opentelemetry.io/collector/featuregate => ../featuregate
 
 replace go.opentelemetry.io/collector/extension/xextension => ../extension/xextension
+
+replace go.opentelemetry.io/collector/client => ../client
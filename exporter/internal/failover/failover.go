@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package failover sends the requests of an exporter to an ordered list of endpoints,
+// failing over to the next healthy endpoint and hedging slow requests.
+package failover // import "go.opentelemetry.io/collector/exporter/internal/failover"
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// errRequestDone cancels the requests still in flight once the result of the export is known.
+var errRequestDone = errors.New("the request is done on another endpoint")
+
+// Config defines the failover of the exports to other endpoints.
+type Config struct {
+	// Endpoints are the endpoints the exports fail over to, in order, when the previous endpoints are unhealthy.
+	// The endpoint of the exporter is always the first one.
+	Endpoints []string `mapstructure:"endpoints"`
+	// UnhealthyDuration is the time an endpoint is considered unhealthy after a failed export,
+	// before the exports are sent to it again.
+	UnhealthyDuration time.Duration `mapstructure:"unhealthy_duration"`
+	// HedgeAfter is the time after which the export is also sent to the next endpoint, if the previous
+	// ones did not respond yet. The first successful response is used. If 0, the exports are not hedged.
+	HedgeAfter time.Duration `mapstructure:"hedge_after"`
+	// AttemptTimeout is the timeout of the export to one endpoint, after which the export fails over to the next one.
+	// If 0, the export to one endpoint can use all the time left before the deadline of the export.
+	AttemptTimeout time.Duration `mapstructure:"attempt_timeout"`
+}
+
+// NewDefaultConfig returns the default config for Config.
+func NewDefaultConfig() Config {
+	return Config{
+		UnhealthyDuration: 30 * time.Second,
+		HedgeAfter:        0,
+	}
+}
+
+// Validate checks if the Config configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.UnhealthyDuration < 0 {
+		return errors.New("`unhealthy_duration` must be non-negative")
+	}
+	if cfg.HedgeAfter < 0 {
+		return errors.New("`hedge_after` must be non-negative")
+	}
+	if cfg.AttemptTimeout < 0 {
+		return errors.New("`attempt_timeout` must be non-negative")
+	}
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint == "" {
+			return errors.New("`endpoints` must not contain empty endpoints")
+		}
+	}
+	return nil
+}
+
+// Endpoints sends the exports to the clients of an ordered list of endpoints.
+type Endpoints[C any] struct {
+	cfg     Config
+	clients []C
+	// OnHedge is called every time an export is sent to another endpoint because the previous ones are slow.
+	OnHedge func(ctx context.Context)
+
+	mu             sync.Mutex
+	unhealthyUntil []time.Time
+}
+
+// NewEndpoints returns the Endpoints sending the exports to the given clients, ordered by priority.
+func NewEndpoints[C any](cfg Config, clients []C) *Endpoints[C] {
+	return &Endpoints[C]{
+		cfg:            cfg,
+		clients:        clients,
+		OnHedge:        func(context.Context) {},
+		unhealthyUntil: make([]time.Time, len(clients)),
+	}
+}
+
+// Healthy returns whether the i-th endpoint is healthy.
+func (e *Endpoints[C]) Healthy(i int) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !time.Now().Before(e.unhealthyUntil[i])
+}
+
+// Export sends the export to the first healthy endpoint, and to the next ones when it fails with a retryable error
+// or does not respond within the hedging delay. It returns nil on the first success, the permanent error of an
+// endpoint, or the error of the last endpoint.
+func (e *Endpoints[C]) Export(ctx context.Context, export func(context.Context, C) error) error {
+	if len(e.clients) == 1 {
+		// Nothing to fail over to, the health is not tracked.
+		return export(ctx, e.clients[0])
+	}
+	order := e.order()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make(chan error, len(order))
+	next, inFlight := 0, 0
+	send := func() {
+		i := order[next]
+		attemptCtx, attemptCancel := e.attemptContext(ctx)
+		next++
+		inFlight++
+		go func() {
+			defer attemptCancel()
+			err := export(attemptCtx, e.clients[i])
+			// The requests cancelled because the export is cancelled, or done on another endpoint, say nothing
+			// about the health.
+			if !errors.Is(ctx.Err(), context.Canceled) {
+				e.setHealth(i, err)
+			}
+			results <- err
+		}()
+	}
+
+	var hedgeCh <-chan time.Time
+	sendAndHedge := func() {
+		send()
+		hedgeCh = nil
+		if e.cfg.HedgeAfter > 0 && next < len(order) {
+			hedgeCh = time.After(e.cfg.HedgeAfter)
+		}
+	}
+
+	sendAndHedge()
+	var err error
+	for inFlight > 0 {
+		select {
+		case err = <-results:
+			inFlight--
+			if err == nil || consumererror.IsPermanent(err) {
+				e.finish(cancel, results, inFlight)
+				return err
+			}
+			// Fail over to the next endpoint, unless the export is cancelled.
+			if ctx.Err() == nil && next < len(order) {
+				sendAndHedge()
+			}
+		case <-hedgeCh:
+			sendAndHedge()
+			e.OnHedge(ctx)
+		}
+	}
+	return err
+}
+
+// attemptContext returns the context of the export to one endpoint, limited by the attempt timeout if set.
+func (e *Endpoints[C]) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.cfg.AttemptTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, e.cfg.AttemptTimeout)
+}
+
+// finish cancels the requests still in flight and waits for them, so they do not use the data after the export.
+func (e *Endpoints[C]) finish(cancel context.CancelCauseFunc, results <-chan error, inFlight int) {
+	cancel(errRequestDone)
+	for ; inFlight > 0; inFlight-- {
+		<-results
+	}
+}
+
+// order returns the indexes of the healthy endpoints by priority, followed by the unhealthy ones
+// by the end of their unhealthy time.
+func (e *Endpoints[C]) order() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	var healthy, unhealthy []int
+	for i := range e.clients {
+		if now.Before(e.unhealthyUntil[i]) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	slices.SortStableFunc(unhealthy, func(a, b int) int {
+		return e.unhealthyUntil[a].Compare(e.unhealthyUntil[b])
+	})
+	return append(healthy, unhealthy...)
+}
+
+func (e *Endpoints[C]) setHealth(i int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err == nil || consumererror.IsPermanent(err) {
+		// Permanent errors are caused by the data, not by the endpoint.
+		e.unhealthyUntil[i] = time.Time{}
+		return
+	}
+	e.unhealthyUntil[i] = time.Now().Add(e.cfg.UnhealthyDuration)
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.UnhealthyDuration = -1
+	require.EqualError(t, cfg.Validate(), "`unhealthy_duration` must be non-negative")
+
+	cfg = NewDefaultConfig()
+	cfg.HedgeAfter = -1
+	require.EqualError(t, cfg.Validate(), "`hedge_after` must be non-negative")
+
+	cfg = NewDefaultConfig()
+	cfg.AttemptTimeout = -1
+	require.EqualError(t, cfg.Validate(), "`attempt_timeout` must be non-negative")
+
+	cfg = NewDefaultConfig()
+	cfg.Endpoints = []string{"secondary:4317", ""}
+	require.EqualError(t, cfg.Validate(), "`endpoints` must not contain empty endpoints")
+}
+
+// fakeEndpoint records the exports and returns the configured error after the configured delay.
+type fakeEndpoint struct {
+	mu      sync.Mutex
+	err     error
+	delay   time.Duration
+	exports int
+}
+
+func (f *fakeEndpoint) set(err error, delay time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+	f.delay = delay
+}
+
+func (f *fakeEndpoint) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.exports
+}
+
+func export(ctx context.Context, f *fakeEndpoint) error {
+	f.mu.Lock()
+	f.exports++
+	err, delay := f.err, f.delay
+	f.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return err
+	}
+}
+
+func TestEndpointsFailover(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	cfg := NewDefaultConfig()
+	cfg.UnhealthyDuration = 50 * time.Millisecond
+	e := NewEndpoints(cfg, []*fakeEndpoint{primary, secondary})
+
+	require.NoError(t, e.Export(context.Background(), export))
+	assert.Equal(t, 1, primary.count())
+	assert.Equal(t, 0, secondary.count())
+
+	// A retryable error fails over to the secondary endpoint, and makes the primary one unhealthy.
+	primary.set(errors.New("unavailable"), 0)
+	require.NoError(t, e.Export(context.Background(), export))
+	assert.Equal(t, 2, primary.count())
+	assert.Equal(t, 1, secondary.count())
+	assert.False(t, e.Healthy(0))
+	assert.True(t, e.Healthy(1))
+
+	// The unhealthy primary endpoint is skipped.
+	require.NoError(t, e.Export(context.Background(), export))
+	assert.Equal(t, 2, primary.count())
+	assert.Equal(t, 2, secondary.count())
+
+	// Permanent errors are returned without failing over.
+	secondary.set(consumererror.NewPermanent(errors.New("bad data")), 0)
+	require.True(t, consumererror.IsPermanent(e.Export(context.Background(), export)))
+	assert.Equal(t, 2, primary.count())
+	assert.Equal(t, 3, secondary.count())
+	assert.True(t, e.Healthy(1))
+
+	// The primary endpoint is used again once healthy.
+	primary.set(nil, 0)
+	time.Sleep(cfg.UnhealthyDuration)
+	assert.True(t, e.Healthy(0))
+	require.NoError(t, e.Export(context.Background(), export))
+	assert.Equal(t, 3, primary.count())
+	assert.Equal(t, 3, secondary.count())
+}
+
+func TestEndpointsAllUnhealthy(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	primary.set(errors.New("unavailable"), 0)
+	secondary.set(errors.New("unavailable"), 0)
+	e := NewEndpoints(NewDefaultConfig(), []*fakeEndpoint{primary, secondary})
+
+	require.EqualError(t, e.Export(context.Background(), export), "unavailable")
+	assert.False(t, e.Healthy(0))
+	assert.False(t, e.Healthy(1))
+
+	// All the endpoints are still tried, the one unhealthy for the longest time first.
+	secondary.set(nil, 0)
+	require.NoError(t, e.Export(context.Background(), export))
+	assert.Equal(t, 2, primary.count())
+	assert.Equal(t, 2, secondary.count())
+}
+
+func TestEndpointsHedging(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	primary.set(nil, time.Second)
+	cfg := NewDefaultConfig()
+	cfg.HedgeAfter = 10 * time.Millisecond
+	e := NewEndpoints(cfg, []*fakeEndpoint{primary, secondary})
+	hedged := atomic.Int64{}
+	e.OnHedge = func(context.Context) { hedged.Add(1) }
+
+	start := time.Now()
+	require.NoError(t, e.Export(context.Background(), export))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, primary.count())
+	assert.Equal(t, 1, secondary.count())
+	assert.Equal(t, int64(1), hedged.Load())
+	// The cancelled slow request does not make the primary endpoint unhealthy.
+	assert.True(t, e.Healthy(0))
+}
+
+func TestEndpointsBlackholedPrimary(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	primary.set(nil, time.Hour)
+	e := NewEndpoints(NewDefaultConfig(), []*fakeEndpoint{primary, secondary})
+
+	// Without attempt timeout, the primary endpoint uses all the time of the export.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, e.Export(ctx, export), context.DeadlineExceeded)
+	assert.Equal(t, 0, secondary.count())
+	assert.False(t, e.Healthy(0))
+
+	// The attempt timeout fails over to the next endpoint before the deadline.
+	cfg := NewDefaultConfig()
+	cfg.AttemptTimeout = 10 * time.Millisecond
+	e = NewEndpoints(cfg, []*fakeEndpoint{primary, secondary})
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	require.NoError(t, e.Export(ctx, export))
+	assert.Less(t, time.Since(start), 900*time.Millisecond)
+	assert.Equal(t, 2, primary.count())
+	assert.Equal(t, 1, secondary.count())
+	assert.False(t, e.Healthy(0))
+}
+
+func TestEndpointsCancelled(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	primary.set(nil, time.Second)
+	e := NewEndpoints(NewDefaultConfig(), []*fakeEndpoint{primary, secondary})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	require.ErrorIs(t, e.Export(ctx, export), context.Canceled)
+	// The export is not failed over once cancelled, and the cancellation says nothing about the health.
+	assert.Equal(t, 0, secondary.count())
+	assert.True(t, e.Healthy(0))
+}
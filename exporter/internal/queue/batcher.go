@@ -5,7 +5,9 @@ package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
@@ -14,9 +16,40 @@ import (
 )
 
 type batch struct {
-	ctx     context.Context
-	req     internal.Request
-	idxList []uint64
+	ctx    context.Context
+	req    internal.Request
+	queued []*queuedRequest
+	// created is the time the batch was started, used to pick the batch to flush when the partitions limit is reached.
+	created time.Time
+}
+
+// queuedRequest is a request read from the queue. A request can be split across several batches, by the
+// partitions or by the maximum size, and its processing is only finished once all of them were exported.
+type queuedRequest struct {
+	idx uint64
+
+	mu sync.Mutex
+	// refs counts the batches holding a part of the request, plus one while the request is being batched.
+	refs int
+	err  error
+}
+
+func newQueuedRequest(idx uint64) *queuedRequest {
+	return &queuedRequest{idx: idx, refs: 1}
+}
+
+// ref adds a reference to the request, for a batch holding a part of it.
+func (qr *queuedRequest) ref() {
+	qr.mu.Lock()
+	defer qr.mu.Unlock()
+	qr.refs++
+}
+
+// setError records an error processing a part of the request.
+func (qr *queuedRequest) setError(err error) {
+	qr.mu.Lock()
+	defer qr.mu.Unlock()
+	qr.err = errors.Join(qr.err, err)
 }
 
 // Batcher is in charge of reading items from the queue and send them out asynchronously.
@@ -76,11 +109,24 @@ func (qb *BaseBatcher) flush(batchToFlush batch) {
 	go func() {
 		defer qb.stopWG.Done()
 		err := qb.exportFunc(batchToFlush.ctx, batchToFlush.req)
-		for _, idx := range batchToFlush.idxList {
-			qb.queue.OnProcessingFinished(idx, err)
+		for _, qr := range batchToFlush.queued {
+			qb.finish(qr, err)
 		}
 		if qb.workerPool != nil {
 			qb.workerPool <- true
 		}
 	}()
 }
+
+// finish releases a reference to the request, and finishes its processing in the queue once all the references
+// were released.
+func (qb *BaseBatcher) finish(qr *queuedRequest, err error) {
+	qr.mu.Lock()
+	qr.refs--
+	qr.err = errors.Join(qr.err, err)
+	done := qr.refs == 0
+	qr.mu.Unlock()
+	if done {
+		qb.queue.OnProcessingFinished(qr.idx, qr.err)
+	}
+}
@@ -6,22 +6,34 @@ package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
 import (
 	"context"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/internal"
 )
 
 // DefaultBatcher continuously reads from the queue and flushes asynchronously if size limit is met or on timeout.
+// If partitioning is configured, the requests are grouped in one batch per partition.
 type DefaultBatcher struct {
 	BaseBatcher
 	currentBatchMu sync.Mutex
-	currentBatch   *batch
+	// currentBatches holds the pending batch of every partition, the key is empty if partitioning is disabled.
+	currentBatches map[string]*batch
 	timer          *time.Timer
 	shutdownCh     chan bool
 }
 
+// partitionedRequest is a request, or a part of it, along with the partition it belongs to.
+type partitionedRequest struct {
+	key string
+	ctx context.Context
+	req internal.Request
+}
+
 func (qb *DefaultBatcher) resetTimer() {
 	if qb.batchCfg.FlushTimeout != 0 {
 		qb.timer.Reset(qb.batchCfg.FlushTimeout)
@@ -41,84 +53,190 @@ func (qb *DefaultBatcher) startReadingFlushingGoroutine() {
 				return
 			}
 
-			qb.currentBatchMu.Lock()
-
-			if qb.batchCfg.MaxSizeItems > 0 {
-				var reqList []internal.Request
-				var mergeSplitErr error
-				if qb.currentBatch == nil || qb.currentBatch.req == nil {
-					qb.resetTimer()
-					reqList, mergeSplitErr = req.MergeSplit(ctx, qb.batchCfg.MaxSizeConfig, nil)
-				} else {
-					reqList, mergeSplitErr = qb.currentBatch.req.MergeSplit(ctx, qb.batchCfg.MaxSizeConfig, req)
-				}
-
-				if mergeSplitErr != nil || reqList == nil {
-					qb.queue.OnProcessingFinished(idx, mergeSplitErr)
-					qb.currentBatchMu.Unlock()
-					continue
-				}
-
-				// If there was a split, we flush everything immediately.
-				if reqList[0].ItemsCount() >= qb.batchCfg.MinSizeItems || len(reqList) > 1 {
-					qb.currentBatch = nil
-					qb.currentBatchMu.Unlock()
-					for i := 0; i < len(reqList); i++ {
-						qb.flush(batch{
-							req:     reqList[i],
-							ctx:     ctx,
-							idxList: []uint64{idx},
-						})
-						// TODO: handle partial failure
-					}
-					qb.resetTimer()
-				} else {
-					qb.currentBatch = &batch{
-						req:     reqList[0],
-						ctx:     ctx,
-						idxList: []uint64{idx},
-					}
-					qb.currentBatchMu.Unlock()
-				}
-			} else {
-				if qb.currentBatch == nil || qb.currentBatch.req == nil {
-					qb.resetTimer()
-					qb.currentBatch = &batch{
-						req:     req,
-						ctx:     ctx,
-						idxList: []uint64{idx},
-					}
-				} else {
-					// TODO: consolidate implementation for the cases where MaxSizeConfig is specified and the case where it is not specified
-					mergedReq, mergeErr := qb.currentBatch.req.MergeSplit(qb.currentBatch.ctx, qb.batchCfg.MaxSizeConfig, req)
-					if mergeErr != nil {
-						qb.queue.OnProcessingFinished(idx, mergeErr)
-						qb.currentBatchMu.Unlock()
-						continue
-					}
-					qb.currentBatch = &batch{
-						req:     mergedReq[0],
-						ctx:     qb.currentBatch.ctx,
-						idxList: append(qb.currentBatch.idxList, idx),
-					}
-				}
-
-				if qb.currentBatch.req.ItemsCount() >= qb.batchCfg.MinSizeItems {
-					batchToFlush := *qb.currentBatch
-					qb.currentBatch = nil
-					qb.currentBatchMu.Unlock()
-
-					// flush() blocks until successfully started a goroutine for flushing.
-					qb.flush(batchToFlush)
-					qb.resetTimer()
-				} else {
-					qb.currentBatchMu.Unlock()
-				}
+			qr := newQueuedRequest(idx)
+			for _, pr := range qb.partition(ctx, req) {
+				qb.add(qr, pr)
 			}
+			qb.finish(qr, nil)
 		}
 	}()
 }
 
+// partition splits the request into the partitions defined by the partition configuration.
+func (qb *DefaultBatcher) partition(ctx context.Context, req internal.Request) []partitionedRequest {
+	pCfg := qb.batchCfg.Partition
+	if !pCfg.Enabled() {
+		return []partitionedRequest{{ctx: ctx, req: req}}
+	}
+
+	var key strings.Builder
+	if len(pCfg.MetadataKeys) > 0 {
+		info := client.FromContext(ctx)
+		md := make(map[string][]string, len(pCfg.MetadataKeys))
+		for _, k := range pCfg.MetadataKeys {
+			vs := info.Metadata.Get(k)
+			writeMetadataPartitionKey(&key, vs)
+			if len(vs) > 0 {
+				md[k] = vs
+			}
+		}
+		// Only the partitioned metadata is propagated, other metadata may differ between the batched requests.
+		ctx = client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(md)})
+	}
+
+	rp, ok := req.(internal.RequestResourcePartitioner)
+	if len(pCfg.ResourceAttributes) == 0 || !ok {
+		return []partitionedRequest{{key: key.String(), ctx: ctx, req: req}}
+	}
+	parts := rp.PartitionByResource(pCfg.ResourceAttributes)
+	res := make([]partitionedRequest, 0, len(parts))
+	for resourceKey, partReq := range parts {
+		res = append(res, partitionedRequest{key: key.String() + resourceKey, ctx: ctx, req: partReq})
+	}
+	return res
+}
+
+// writeMetadataPartitionKey writes the metadata values length-prefixed, so that the key is unambiguous.
+func writeMetadataPartitionKey(key *strings.Builder, vs []string) {
+	key.WriteString(strconv.Itoa(len(vs)))
+	key.WriteByte('#')
+	for _, v := range vs {
+		key.WriteString(strconv.Itoa(len(v)))
+		key.WriteByte(':')
+		key.WriteString(v)
+	}
+	key.WriteByte(';')
+}
+
+// add adds the part of the request read from the queue to the batch of its partition,
+// and flushes the batches that are ready.
+func (qb *DefaultBatcher) add(qr *queuedRequest, pr partitionedRequest) {
+	qb.currentBatchMu.Lock()
+	toFlush, ok := qb.addLocked(qr, pr)
+	resetTimer := len(qb.currentBatches) == 0
+	qb.currentBatchMu.Unlock()
+
+	if !ok {
+		return
+	}
+	for i := range toFlush {
+		// flush() blocks until successfully started a goroutine for flushing.
+		qb.flush(toFlush[i])
+		// TODO: handle partial failure
+	}
+	if len(toFlush) > 0 && resetTimer {
+		qb.resetTimer()
+	}
+}
+
+// addLocked returns the batches to flush, or false if the request failed to be merged.
+// Caller must hold the lock.
+func (qb *DefaultBatcher) addLocked(qr *queuedRequest, pr partitionedRequest) ([]batch, bool) {
+	var toFlush []batch
+	current := qb.currentBatches[pr.key]
+	if current == nil {
+		if len(qb.currentBatches) == 0 {
+			qb.resetTimer()
+		} else if limit := qb.batchCfg.Partition.CardinalityLimit; limit > 0 && len(qb.currentBatches) >= limit {
+			toFlush = append(toFlush, qb.removeOldestBatch())
+		}
+	}
+
+	if qb.batchCfg.MaxSizeItems > 0 || qb.batchCfg.MaxSizeBytes > 0 {
+		var reqList []internal.Request
+		var mergeSplitErr error
+		var queued []*queuedRequest
+		if current == nil {
+			reqList, mergeSplitErr = pr.req.MergeSplit(pr.ctx, qb.batchCfg.MaxSizeConfig, nil)
+		} else {
+			reqList, mergeSplitErr = current.req.MergeSplit(pr.ctx, qb.batchCfg.MaxSizeConfig, pr.req)
+			queued = current.queued
+		}
+
+		if mergeSplitErr != nil || reqList == nil {
+			qr.setError(mergeSplitErr)
+			return toFlush, len(toFlush) > 0
+		}
+
+		// If there was a split, we flush everything immediately.
+		if internal.MinSizeReached(qb.batchCfg.MinSizeConfig, reqList[0]) || len(reqList) > 1 {
+			delete(qb.currentBatches, pr.key)
+			for i := 0; i < len(reqList); i++ {
+				qr.ref()
+				toFlush = append(toFlush, batch{
+					req:    reqList[i],
+					ctx:    pr.ctx,
+					queued: append(queued, qr),
+				})
+				queued = nil
+			}
+		} else {
+			qr.ref()
+			qb.currentBatches[pr.key] = &batch{
+				req:     reqList[0],
+				ctx:     pr.ctx,
+				queued:  append(queued, qr),
+				created: createdAt(current),
+			}
+		}
+		return toFlush, true
+	}
+
+	if current == nil {
+		qr.ref()
+		current = &batch{
+			req:     pr.req,
+			ctx:     pr.ctx,
+			queued:  []*queuedRequest{qr},
+			created: time.Now(),
+		}
+	} else {
+		// TODO: consolidate implementation for the cases where MaxSizeConfig is specified and the case where it is not specified
+		mergedReq, mergeErr := current.req.MergeSplit(current.ctx, qb.batchCfg.MaxSizeConfig, pr.req)
+		if mergeErr != nil {
+			qr.setError(mergeErr)
+			return toFlush, len(toFlush) > 0
+		}
+		qr.ref()
+		current = &batch{
+			req:     mergedReq[0],
+			ctx:     current.ctx,
+			queued:  append(current.queued, qr),
+			created: current.created,
+		}
+	}
+
+	if internal.MinSizeReached(qb.batchCfg.MinSizeConfig, current.req) {
+		delete(qb.currentBatches, pr.key)
+		toFlush = append(toFlush, *current)
+	} else {
+		qb.currentBatches[pr.key] = current
+	}
+	return toFlush, true
+}
+
+// removeOldestBatch removes the batch that was created first from the current batches and returns it.
+// Caller must hold the lock.
+func (qb *DefaultBatcher) removeOldestBatch() batch {
+	var oldestKey string
+	var oldest *batch
+	for key, b := range qb.currentBatches {
+		if oldest == nil || b.created.Before(oldest.created) {
+			oldestKey, oldest = key, b
+		}
+	}
+	delete(qb.currentBatches, oldestKey)
+	return *oldest
+}
+
+// createdAt returns the creation time of the given batch, or now if the batch is nil.
+func createdAt(b *batch) time.Time {
+	if b == nil {
+		return time.Now()
+	}
+	return b.created
+}
+
 // startTimeBasedFlushingGoroutine starts a goroutine that flushes on timeout.
 func (qb *DefaultBatcher) startTimeBasedFlushingGoroutine() {
 	qb.stopWG.Add(1)
@@ -143,6 +261,7 @@ func (qb *DefaultBatcher) Start(_ context.Context, _ component.Host) error {
 	}
 
 	qb.shutdownCh = make(chan bool, 1)
+	qb.currentBatches = make(map[string]*batch)
 
 	if qb.batchCfg.FlushTimeout == 0 {
 		qb.timer = time.NewTimer(math.MaxInt)
@@ -156,19 +275,24 @@ func (qb *DefaultBatcher) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
-// flushCurrentBatchIfNecessary sends out the current request batch if it is not nil
+// flushCurrentBatchIfNecessary sends out the current request batches if any.
 func (qb *DefaultBatcher) flushCurrentBatchIfNecessary() {
 	qb.currentBatchMu.Lock()
-	if qb.currentBatch == nil || qb.currentBatch.req == nil {
+	if len(qb.currentBatches) == 0 {
 		qb.currentBatchMu.Unlock()
 		return
 	}
-	batchToFlush := *qb.currentBatch
-	qb.currentBatch = nil
+	batchesToFlush := make([]batch, 0, len(qb.currentBatches))
+	for key, b := range qb.currentBatches {
+		batchesToFlush = append(batchesToFlush, *b)
+		delete(qb.currentBatches, key)
+	}
 	qb.currentBatchMu.Unlock()
 
-	// flush() blocks until successfully started a goroutine for flushing.
-	qb.flush(batchToFlush)
+	for i := range batchesToFlush {
+		// flush() blocks until successfully started a goroutine for flushing.
+		qb.flush(batchesToFlush[i])
+	}
 	qb.resetTimer()
 }
 
@@ -7,12 +7,14 @@ import (
 	"context"
 	"errors"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
@@ -151,6 +153,50 @@ func TestDefaultBatcher_NoSplit_TimeoutDisabled(t *testing.T) {
 	}
 }
 
+func TestDefaultBatcher_NoSplit_MinSizeBytes_TimeoutDisabled(t *testing.T) {
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.FlushTimeout = 0
+	// MinSizeBytes takes precedence over MinSizeItems.
+	cfg.MinSizeConfig = exporterbatcher.MinSizeConfig{
+		MinSizeItems: 1,
+		MinSizeBytes: 100,
+	}
+
+	q := exporterqueue.NewMemoryQueueFactory[internal.Request]()(
+		context.Background(),
+		exporterqueue.Settings{
+			Signal:           pipeline.SignalTraces,
+			ExporterSettings: exportertest.NewNopSettings(),
+		},
+		exporterqueue.NewDefaultConfig())
+
+	ba, err := NewBatcher(cfg, q,
+		func(ctx context.Context, req internal.Request) error { return req.Export(ctx) },
+		1)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, q.Shutdown(context.Background()))
+		require.NoError(t, ba.Shutdown(context.Background()))
+	})
+
+	sink := newFakeRequestSink()
+
+	require.NoError(t, q.Offer(context.Background(), &fakeRequest{items: 4, sink: sink}))
+	require.NoError(t, q.Offer(context.Background(), &fakeRequest{items: 5, sink: sink}))
+	assert.Never(t, func() bool {
+		return sink.requestsCount.Load() > 0
+	}, 30*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, q.Offer(context.Background(), &fakeRequest{items: 1, sink: sink}))
+	assert.Eventually(t, func() bool {
+		return sink.requestsCount.Load() == 1 && sink.itemsCount.Load() == 10
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
 func TestDefaultBatcher_NoSplit_WithTimeout(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector/issues/11869")
@@ -298,6 +344,176 @@ func TestDefaultBatcher_Split_TimeoutDisabled(t *testing.T) {
 	}
 }
 
+func TestDefaultBatcher_PartitionByMetadata(t *testing.T) {
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.FlushTimeout = 0
+	cfg.MinSizeConfig = exporterbatcher.MinSizeConfig{
+		MinSizeItems: 10,
+	}
+	cfg.Partition.MetadataKeys = []string{"tenant"}
+
+	q := exporterqueue.NewMemoryQueueFactory[internal.Request]()(
+		context.Background(),
+		exporterqueue.Settings{
+			Signal:           pipeline.SignalTraces,
+			ExporterSettings: exportertest.NewNopSettings(),
+		},
+		exporterqueue.NewDefaultConfig())
+
+	var mu sync.Mutex
+	tenantItems := map[string]int{}
+	ba, err := NewBatcher(cfg, q,
+		func(ctx context.Context, req internal.Request) error {
+			mu.Lock()
+			defer mu.Unlock()
+			tenants := client.FromContext(ctx).Metadata.Get("tenant")
+			require.Len(t, tenants, 1)
+			assert.Empty(t, client.FromContext(ctx).Metadata.Get("other"))
+			tenantItems[tenants[0]] += req.ItemsCount()
+			return req.Export(ctx)
+		},
+		1)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, q.Shutdown(context.Background()))
+		require.NoError(t, ba.Shutdown(context.Background()))
+	})
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}, "other": {tenant}}),
+		})
+	}
+
+	sink := newFakeRequestSink()
+	require.NoError(t, q.Offer(tenantCtx("a"), &fakeRequest{items: 6, sink: sink}))
+	require.NoError(t, q.Offer(tenantCtx("b"), &fakeRequest{items: 7, sink: sink}))
+	require.NoError(t, q.Offer(tenantCtx("a"), &fakeRequest{items: 5, sink: sink}))
+	require.NoError(t, q.Offer(tenantCtx("b"), &fakeRequest{items: 3, sink: sink}))
+	assert.Eventually(t, func() bool {
+		return sink.requestsCount.Load() == 2 && sink.itemsCount.Load() == 21
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"a": 11, "b": 10}, tenantItems)
+}
+
+func TestDefaultBatcher_PartitionCardinalityLimit(t *testing.T) {
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.FlushTimeout = 0
+	cfg.MinSizeConfig = exporterbatcher.MinSizeConfig{
+		MinSizeItems: 100,
+	}
+	cfg.Partition.MetadataKeys = []string{"tenant"}
+	cfg.Partition.CardinalityLimit = 2
+
+	q := exporterqueue.NewMemoryQueueFactory[internal.Request]()(
+		context.Background(),
+		exporterqueue.Settings{
+			Signal:           pipeline.SignalTraces,
+			ExporterSettings: exportertest.NewNopSettings(),
+		},
+		exporterqueue.NewDefaultConfig())
+
+	ba, err := NewBatcher(cfg, q,
+		func(ctx context.Context, req internal.Request) error { return req.Export(ctx) },
+		1)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, q.Shutdown(context.Background()))
+		require.NoError(t, ba.Shutdown(context.Background()))
+	})
+
+	sink := newFakeRequestSink()
+	for _, tenant := range []string{"a", "b", "a", "c"} {
+		ctx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+		require.NoError(t, q.Offer(ctx, &fakeRequest{items: 5, sink: sink}))
+	}
+	// The third partition evicts the oldest pending batch, the one of tenant "a".
+	assert.Eventually(t, func() bool {
+		return sink.requestsCount.Load() == 1 && sink.itemsCount.Load() == 10
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+// finishRecordingQueue records the number of exported requests each time the processing of a request is finished.
+type finishRecordingQueue struct {
+	exporterqueue.Queue[internal.Request]
+	sink *fakeRequestSink
+
+	mu       sync.Mutex
+	finished []int64
+}
+
+func (q *finishRecordingQueue) OnProcessingFinished(idx uint64, err error) {
+	q.mu.Lock()
+	q.finished = append(q.finished, q.sink.requestsCount.Load())
+	q.mu.Unlock()
+	q.Queue.OnProcessingFinished(idx, err)
+}
+
+func TestDefaultBatcher_PartitionByResourceFinishesOnce(t *testing.T) {
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.FlushTimeout = 0
+	cfg.MinSizeConfig = exporterbatcher.MinSizeConfig{
+		MinSizeItems: 1,
+	}
+	cfg.Partition.ResourceAttributes = []string{"service.name"}
+
+	sink := newFakeRequestSink()
+	q := &finishRecordingQueue{
+		Queue: exporterqueue.NewMemoryQueueFactory[internal.Request]()(
+			context.Background(),
+			exporterqueue.Settings{
+				Signal:           pipeline.SignalTraces,
+				ExporterSettings: exportertest.NewNopSettings(),
+			},
+			exporterqueue.NewDefaultConfig()),
+		sink: sink,
+	}
+
+	ba, err := NewBatcher(cfg, q,
+		func(ctx context.Context, req internal.Request) error { return req.Export(ctx) },
+		3)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, q.Shutdown(context.Background()))
+		require.NoError(t, ba.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, q.Offer(context.Background(), &fakeResourceRequest{
+		fakeRequest: &fakeRequest{items: 6, sink: sink, delay: 10 * time.Millisecond},
+		resources:   map[string]int{"a": 1, "b": 2, "c": 3},
+	}))
+	assert.Eventually(t, func() bool {
+		return sink.requestsCount.Load() == 3 && sink.itemsCount.Load() == 6
+	}, time.Second, 10*time.Millisecond)
+
+	// The request is only finished once, after all its parts were exported.
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return len(q.finished) == 1
+	}, time.Second, 10*time.Millisecond)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	assert.Equal(t, []int64{3}, q.finished)
+}
+
 func TestDefaultBatcher_Shutdown(t *testing.T) {
 	batchCfg := exporterbatcher.NewDefaultConfig()
 	batchCfg.MinSizeItems = 10
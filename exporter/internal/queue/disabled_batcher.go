@@ -34,9 +34,9 @@ func (qb *DisabledBatcher) Start(_ context.Context, _ component.Host) error {
 				return
 			}
 			qb.flush(batch{
-				req:     req,
-				ctx:     context.Background(),
-				idxList: []uint64{idx},
+				req:    req,
+				ctx:    context.Background(),
+				queued: []*queuedRequest{newQueuedRequest(idx)},
 			})
 		}
 	}()
@@ -53,6 +53,11 @@ func (r *fakeRequest) ItemsCount() int {
 	return r.items
 }
 
+// ByteSize returns the size of the request assuming every item is 10 bytes.
+func (r *fakeRequest) ByteSize() int {
+	return 10 * r.items
+}
+
 func (r *fakeRequest) MergeSplit(_ context.Context, cfg exporterbatcher.MaxSizeConfig, r2 internal.Request) ([]internal.Request, error) {
 	if r.mergeErr != nil {
 		return nil, r.mergeErr
@@ -110,3 +115,18 @@ func (r *fakeRequest) MergeSplit(_ context.Context, cfg exporterbatcher.MaxSizeC
 
 	return res, nil
 }
+
+// fakeResourceRequest is a fakeRequest holding the items of several resources.
+type fakeResourceRequest struct {
+	*fakeRequest
+	// resources is the number of items per resource key.
+	resources map[string]int
+}
+
+func (r *fakeResourceRequest) PartitionByResource([]string) map[string]internal.Request {
+	parts := make(map[string]internal.Request, len(r.resources))
+	for key, items := range r.resources {
+		parts[key] = &fakeRequest{items: items, sink: r.sink, exportErr: r.exportErr, delay: r.delay}
+	}
+	return parts
+}
@@ -22,7 +22,9 @@ type Request interface {
 	// MergeSplit is a function that merge and/or splits this request with another one into multiple requests based on the
 	// configured limit provided in MaxSizeConfig.
 	// MergeSplit does not split if all fields in MaxSizeConfig are not initialized (zero).
-	// All the returned requests MUST have a number of items that does not exceed the maximum number of items.
+	// All the returned requests MUST have a number of items that does not exceed the maximum number of items,
+	// or, if MaxSizeBytes is set, a size in bytes that does not exceed the maximum number of bytes unless a single
+	// item is larger than that.
 	// Size of the last returned request MUST be less or equal than the size of any other returned request.
 	// The original request MUST not be mutated if error is returned after mutation or if the exporter is
 	// marked as not mutable. The length of the returned slice MUST not be 0.
@@ -43,3 +45,34 @@ type RequestErrorHandler interface {
 	// Otherwise, it should return the original Request.
 	OnError(error) Request
 }
+
+// RequestByteSizer is an optional interface that can be implemented by Request to report its serialized size in bytes.
+// It is required for the byte-based limits of the batcher and the "bytes" sizer of the queue.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestByteSizer interface {
+	// ByteSize returns the serialized size of the request in bytes, e.g. the OTLP protobuf size for OTLP.
+	ByteSize() int
+}
+
+// MinSizeReached returns true if the request has reached the minimum size defined by the MinSizeConfig.
+// MinSizeBytes takes precedence over MinSizeItems if set. Requests that do not implement RequestByteSizer
+// never reach a byte-based minimum size.
+func MinSizeReached(cfg exporterbatcher.MinSizeConfig, req Request) bool {
+	if cfg.MinSizeBytes > 0 {
+		bs, ok := req.(RequestByteSizer)
+		return ok && bs.ByteSize() >= cfg.MinSizeBytes
+	}
+	return req.ItemsCount() >= cfg.MinSizeItems
+}
+
+// RequestResourcePartitioner is an optional interface that can be implemented by Request to split it by the values
+// of resource attributes. It is required to partition the batches by resource attributes.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestResourcePartitioner interface {
+	// PartitionByResource splits the request into requests where all the resources have the same values for the
+	// given attribute keys. The returned map is keyed by a string uniquely identifying the values of the attributes.
+	// The original request can be returned as is if all its resources belong to the same partition.
+	PartitionByResource(keys []string) map[string]Request
+}
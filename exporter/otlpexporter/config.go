@@ -22,7 +22,13 @@ import (
 type Config struct {
 	exporterhelper.TimeoutConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 	exporterhelper.QueueConfig   `mapstructure:"sending_queue"`
-	RetryConfig                  configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	RetryConfig                  configretry.BackOffConfig           `mapstructure:"retry_on_failure"`
+	DeadLetterConfig             exporterhelper.DeadLetterConfig     `mapstructure:"dead_letter"`
+	CircuitBreakerConfig         exporterhelper.CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// Failover defines the endpoints the exports fail over to when the endpoint is unhealthy,
+	// and the hedging of slow exports.
+	Failover FailoverConfig `mapstructure:"failover"`
 
 	// Experimental: This configuration is at the early stage of development and may change without backward compatibility
 	// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved
@@ -36,7 +42,24 @@ func (c *Config) Validate() error {
 	if endpoint == "" {
 		return errors.New(`requires a non-empty "endpoint"`)
 	}
+	if err := validateEndpoint(endpoint); err != nil {
+		return err
+	}
 
+	for _, failoverEndpoint := range c.Failover.Endpoints {
+		if failoverEndpoint == "" {
+			// Reported by the validation of the failover config.
+			continue
+		}
+		if err := validateEndpoint(sanitizeEndpoint(failoverEndpoint)); err != nil {
+			return fmt.Errorf("invalid failover endpoint %q: %w", failoverEndpoint, err)
+		}
+	}
+
+	return nil
+}
+
+func validateEndpoint(endpoint string) error {
 	// Validate that the port is in the address
 	_, port, err := net.SplitHostPort(endpoint)
 	if err != nil {
@@ -45,21 +68,24 @@ func (c *Config) Validate() error {
 	if _, err := strconv.Atoi(port); err != nil {
 		return fmt.Errorf(`invalid port "%s"`, port)
 	}
-
 	return nil
 }
 
 func (c *Config) sanitizedEndpoint() string {
+	return sanitizeEndpoint(c.Endpoint)
+}
+
+func sanitizeEndpoint(endpoint string) string {
 	switch {
-	case strings.HasPrefix(c.Endpoint, "http://"):
-		return strings.TrimPrefix(c.Endpoint, "http://")
-	case strings.HasPrefix(c.Endpoint, "https://"):
-		return strings.TrimPrefix(c.Endpoint, "https://")
-	case strings.HasPrefix(c.Endpoint, "dns://"):
+	case strings.HasPrefix(endpoint, "http://"):
+		return strings.TrimPrefix(endpoint, "http://")
+	case strings.HasPrefix(endpoint, "https://"):
+		return strings.TrimPrefix(endpoint, "https://")
+	case strings.HasPrefix(endpoint, "dns://"):
 		r := regexp.MustCompile("^dns://[/]?")
-		return r.ReplaceAllString(c.Endpoint, "")
+		return r.ReplaceAllString(endpoint, "")
 	default:
-		return c.Endpoint
+		return endpoint
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -31,6 +32,7 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 }
 
 func TestUnmarshalConfig(t *testing.T) {
+	deadLetterStorageID := component.MustNewIDWithName("file_storage", "dead_letter")
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 	factory := NewFactory()
@@ -48,11 +50,32 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				Throttle:            configretry.NewDefaultThrottleConfig(),
 			},
 			QueueConfig: exporterhelper.QueueConfig{
-				Enabled:      true,
-				NumConsumers: 2,
-				QueueSize:    10,
+				Enabled:             true,
+				NumConsumers:        2,
+				QueueSize:           10,
+				Sizer:               exporterqueue.SizerTypeRequests,
+				AdaptiveConcurrency: exporterhelper.NewDefaultAdaptiveConcurrencyConfig(),
+			},
+			DeadLetterConfig: exporterhelper.DeadLetterConfig{
+				Enabled:   true,
+				StorageID: &deadLetterStorageID,
+			},
+			CircuitBreakerConfig: exporterhelper.CircuitBreakerConfig{
+				Enabled:          true,
+				FailureRatio:     0.8,
+				MinAttempts:      20,
+				Window:           time.Minute,
+				OpenDuration:     10 * time.Second,
+				HalfOpenAttempts: 2,
+				FailFast:         false,
+			},
+			Failover: FailoverConfig{
+				Endpoints:         []string{"5.6.7.8:1234"},
+				UnhealthyDuration: time.Minute,
+				HedgeAfter:        500 * time.Millisecond,
 			},
 			BatcherConfig: exporterbatcher.Config{
 				Enabled:      true,
@@ -130,6 +153,14 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_port",
 			errorMsg: `invalid port "port"`,
 		},
+		{
+			name:     "invalid_failover_endpoint",
+			errorMsg: `invalid failover endpoint "example.com": address example.com: missing port in address`,
+		},
+		{
+			name:     "invalid_failover",
+			errorMsg: "`hedge_after` must be non-negative",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := factory.CreateDefaultConfig()
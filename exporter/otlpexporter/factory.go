@@ -16,8 +16,11 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/exporter/otlpexporter/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -37,10 +40,13 @@ func createDefaultConfig() component.Config {
 	batcherCfg.Enabled = false
 
 	return &Config{
-		TimeoutConfig: exporterhelper.NewDefaultTimeoutConfig(),
-		RetryConfig:   configretry.NewDefaultBackOffConfig(),
-		QueueConfig:   exporterhelper.NewDefaultQueueConfig(),
-		BatcherConfig: batcherCfg,
+		TimeoutConfig:        exporterhelper.NewDefaultTimeoutConfig(),
+		RetryConfig:          configretry.NewDefaultBackOffConfig(),
+		QueueConfig:          exporterhelper.NewDefaultQueueConfig(),
+		DeadLetterConfig:     exporterhelper.NewDefaultDeadLetterConfig(),
+		CircuitBreakerConfig: exporterhelper.NewDefaultCircuitBreakerConfig(),
+		Failover:             failover.NewDefaultConfig(),
+		BatcherConfig:        batcherCfg,
 		ClientConfig: configgrpc.ClientConfig{
 			Headers: map[string]configopaque.String{},
 			// Default to gzip compression
@@ -56,7 +62,7 @@ func createTraces(
 	set exporter.Settings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	oce := newExporter(cfg, set)
+	oce := newExporter(cfg, set, pipeline.SignalTraces)
 	oCfg := cfg.(*Config)
 	return exporterhelper.NewTraces(ctx, set, cfg,
 		oce.pushTraces,
@@ -64,6 +70,8 @@ func createTraces(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -75,7 +83,7 @@ func createMetrics(
 	set exporter.Settings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	oce := newExporter(cfg, set)
+	oce := newExporter(cfg, set, pipeline.SignalMetrics)
 	oCfg := cfg.(*Config)
 	return exporterhelper.NewMetrics(ctx, set, cfg,
 		oce.pushMetrics,
@@ -83,6 +91,8 @@ func createMetrics(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -94,7 +104,7 @@ func createLogs(
 	set exporter.Settings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	oce := newExporter(cfg, set)
+	oce := newExporter(cfg, set, pipeline.SignalLogs)
 	oCfg := cfg.(*Config)
 	return exporterhelper.NewLogs(ctx, set, cfg,
 		oce.pushLogs,
@@ -102,6 +112,8 @@ func createLogs(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -113,7 +125,7 @@ func createProfilesExporter(
 	set exporter.Settings,
 	cfg component.Config,
 ) (xexporter.Profiles, error) {
-	oce := newExporter(cfg, set)
+	oce := newExporter(cfg, set, xpipeline.SignalProfiles)
 	oCfg := cfg.(*Config)
 	return xexporterhelper.NewProfilesExporter(ctx, set, cfg,
 		oce.pushProfiles,
@@ -121,6 +133,8 @@ func createProfilesExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
+	"go.opentelemetry.io/collector/exporter/otlpexporter/internal/metadata"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// FailoverConfig defines the failover of the exports to other endpoints, and the hedging of slow exports.
+type FailoverConfig = failover.Config
+
+// endpointsTelemetry reports the health of the endpoints and the hedged requests.
+type endpointsTelemetry struct {
+	telemetryBuilder *metadata.TelemetryBuilder
+	registrations    []metric.Registration
+}
+
+func newEndpointsTelemetry[C any](id component.ID, signal pipeline.Signal, set component.TelemetrySettings, endpoints *failover.Endpoints[C], names []string) (*endpointsTelemetry, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	et := &endpointsTelemetry{telemetryBuilder: telemetryBuilder}
+	exporterAttr := attribute.String("exporter", id.String())
+	dataTypeAttr := attribute.String("data_type", signal.String())
+	for i, name := range names {
+		reg, err := telemetryBuilder.InitExporterEndpointHealthy(func() int64 {
+			if endpoints.Healthy(i) {
+				return 1
+			}
+			return 0
+		}, metric.WithAttributes(exporterAttr, dataTypeAttr, attribute.String("endpoint", name)))
+		if err != nil {
+			return nil, errors.Join(err, et.shutdown())
+		}
+		et.registrations = append(et.registrations, reg)
+	}
+	hedgedAttrs := metric.WithAttributes(exporterAttr, dataTypeAttr)
+	endpoints.OnHedge = func(ctx context.Context) {
+		telemetryBuilder.ExporterHedgedRequests.Add(ctx, 1, hedgedAttrs)
+	}
+	return et, nil
+}
+
+func (et *endpointsTelemetry) shutdown() error {
+	var errs error
+	for _, reg := range et.registrations {
+		errs = errors.Join(errs, reg.Unregister())
+	}
+	et.registrations = nil
+	return errs
+}
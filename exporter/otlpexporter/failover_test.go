@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
+	"go.opentelemetry.io/collector/exporter/otlpexporter/internal/metadatatest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func startTracesReceiver(t *testing.T) (*mockTracesReceiver, string) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, err := otlpTracesReceiverOnGRPCServer(ln, false)
+	require.NoError(t, err)
+	t.Cleanup(rcv.srv.GracefulStop)
+	return rcv, ln.Addr().String()
+}
+
+func newFailoverTracesConfig(primary, secondary string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.Enabled = false
+	cfg.Endpoint = primary
+	cfg.TLSSetting = configtls.ClientConfig{Insecure: true}
+	cfg.Failover.Endpoints = []string{secondary}
+	return cfg
+}
+
+func TestSendTracesFailover(t *testing.T) {
+	primary, primaryAddr := startTracesReceiver(t)
+	secondary, secondaryAddr := startTracesReceiver(t)
+	primary.setExportError(status.Error(codes.Unavailable, "unavailable"))
+
+	cfg := newFailoverTracesConfig(primaryAddr, secondaryAddr)
+	exp, err := NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(context.Background())) })
+
+	// The export fails over to the secondary endpoint.
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.EqualValues(t, 1, primary.requestCount.Load())
+	assert.EqualValues(t, 1, secondary.requestCount.Load())
+	assert.EqualValues(t, td, secondary.getLastRequest())
+
+	// The unhealthy primary endpoint is skipped.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.EqualValues(t, 1, primary.requestCount.Load())
+	assert.EqualValues(t, 2, secondary.requestCount.Load())
+
+	// Permanent errors are not failed over.
+	secondary.setExportError(status.Error(codes.InvalidArgument, "bad data"))
+	require.Error(t, exp.ConsumeTraces(context.Background(), td))
+	assert.EqualValues(t, 1, primary.requestCount.Load())
+	assert.EqualValues(t, 3, secondary.requestCount.Load())
+}
+
+func TestSendTracesHedging(t *testing.T) {
+	primary, primaryAddr := startTracesReceiver(t)
+	secondary, secondaryAddr := startTracesReceiver(t)
+	primary.setExportResponse(func() ptraceotlp.ExportResponse {
+		time.Sleep(200 * time.Millisecond)
+		return ptraceotlp.NewExportResponse()
+	})
+
+	cfg := newFailoverTracesConfig(primaryAddr, secondaryAddr)
+	cfg.Failover.HedgeAfter = 10 * time.Millisecond
+	exp, err := NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(context.Background())) })
+
+	// The slow export is also sent to the secondary endpoint, which responds first.
+	start := time.Now()
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+	assert.EqualValues(t, 1, secondary.requestCount.Load())
+}
+
+func TestEndpointsTelemetry(t *testing.T) {
+	tel := metadatatest.SetupTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	endpoints := failover.NewEndpoints(failover.NewDefaultConfig(), []int{0, 1})
+	et, err := newEndpointsTelemetry(component.MustNewID("otlp"), pipeline.SignalTraces, tel.NewTelemetrySettings(), endpoints, []string{"primary:4317", "secondary:4317"})
+	require.NoError(t, err)
+
+	require.NoError(t, endpoints.Export(context.Background(), func(_ context.Context, i int) error {
+		if i == 0 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}))
+	endpoints.OnHedge(context.Background())
+
+	tel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_endpoint_healthy",
+			Description: "Whether the endpoint is healthy (1) or not (0), reported for every endpoint when failover endpoints are configured.",
+			Unit:        "1",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlp"), attribute.String("data_type", "traces"), attribute.String("endpoint", "primary:4317")),
+						Value:      0,
+					},
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlp"), attribute.String("data_type", "traces"), attribute.String("endpoint", "secondary:4317")),
+						Value:      1,
+					},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_exporter_hedged_requests",
+			Description: "Number of requests also sent to the next endpoint because the previous endpoints did not respond within the hedging delay. [alpha]",
+			Unit:        "{requests}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlp"), attribute.String("data_type", "traces")),
+						Value:      1,
+					},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp())
+
+	// The health of the endpoints is no longer reported once shut down.
+	require.NoError(t, et.shutdown())
+	tel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_hedged_requests",
+			Description: "Number of requests also sent to the next endpoint because the previous endpoints did not respond within the hedging delay. [alpha]",
+			Unit:        "{requests}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlp"), attribute.String("data_type", "traces")),
+						Value:      1,
+					},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
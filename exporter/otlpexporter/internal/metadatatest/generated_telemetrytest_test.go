@@ -0,0 +1,40 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadatatest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/exporter/otlpexporter/internal/metadata"
+)
+
+func TestSetupTelemetry(t *testing.T) {
+	testTel := SetupTelemetry()
+	tb, err := metadata.NewTelemetryBuilder(
+		testTel.NewTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, tb)
+	tb.ExporterHedgedRequests.Add(context.Background(), 1)
+
+	testTel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_hedged_requests",
+			Description: "Number of requests also sent to the next endpoint because the previous endpoints did not respond within the hedging delay. [alpha]",
+			Unit:        "{requests}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+	require.NoError(t, testTel.Shutdown(context.Background()))
+}
@@ -5,6 +5,7 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -29,47 +31,78 @@ import (
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
 )
 
 type baseExporter struct {
 	// Input configuration.
 	config *Config
 
-	// gRPC clients and connection.
-	traceExporter   ptraceotlp.GRPCClient
-	metricExporter  pmetricotlp.GRPCClient
-	logExporter     plogotlp.GRPCClient
-	profileExporter pprofileotlp.GRPCClient
-	clientConn      *grpc.ClientConn
-	metadata        metadata.MD
-	callOptions     []grpc.CallOption
+	// gRPC clients of the endpoint followed by the failover endpoints.
+	clients     []*grpcClients
+	endpoints   *failover.Endpoints[*grpcClients]
+	metadata    metadata.MD
+	callOptions []grpc.CallOption
 
-	settings component.TelemetrySettings
+	id        component.ID
+	signal    pipeline.Signal
+	settings  component.TelemetrySettings
+	telemetry *endpointsTelemetry
 
 	// Default user-agent header.
 	userAgent string
 }
 
-func newExporter(cfg component.Config, set exporter.Settings) *baseExporter {
+func newExporter(cfg component.Config, set exporter.Settings, signal pipeline.Signal) *baseExporter {
 	oCfg := cfg.(*Config)
 
 	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
 
-	return &baseExporter{config: oCfg, settings: set.TelemetrySettings, userAgent: userAgent}
+	return &baseExporter{config: oCfg, id: set.ID, signal: signal, settings: set.TelemetrySettings, userAgent: userAgent}
+}
+
+// grpcClients holds the gRPC clients and connection of an endpoint.
+type grpcClients struct {
+	traceExporter   ptraceotlp.GRPCClient
+	metricExporter  pmetricotlp.GRPCClient
+	logExporter     plogotlp.GRPCClient
+	profileExporter pprofileotlp.GRPCClient
+	clientConn      *grpc.ClientConn
 }
 
-// start actually creates the gRPC connection. The client construction is deferred till this point as this
+func newGRPCClients(clientConn *grpc.ClientConn) *grpcClients {
+	return &grpcClients{
+		traceExporter:   ptraceotlp.NewGRPCClient(clientConn),
+		metricExporter:  pmetricotlp.NewGRPCClient(clientConn),
+		logExporter:     plogotlp.NewGRPCClient(clientConn),
+		profileExporter: pprofileotlp.NewGRPCClient(clientConn),
+		clientConn:      clientConn,
+	}
+}
+
+// start actually creates the gRPC connections. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
-func (e *baseExporter) start(ctx context.Context, host component.Host) (err error) {
+func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 	agentOpt := configgrpc.WithGrpcDialOption(grpc.WithUserAgent(e.userAgent))
-	if e.clientConn, err = e.config.ClientConfig.ToClientConn(ctx, host, e.settings, agentOpt); err != nil {
-		return err
+	endpoints := append([]string{e.config.Endpoint}, e.config.Failover.Endpoints...)
+	for _, endpoint := range endpoints {
+		clientCfg := e.config.ClientConfig
+		clientCfg.Endpoint = endpoint
+		clientConn, err := clientCfg.ToClientConn(ctx, host, e.settings, agentOpt)
+		if err != nil {
+			return err
+		}
+		e.clients = append(e.clients, newGRPCClients(clientConn))
+	}
+	e.endpoints = failover.NewEndpoints(e.config.Failover, e.clients)
+	if len(endpoints) > 1 {
+		var err error
+		if e.telemetry, err = newEndpointsTelemetry(e.id, e.signal, e.settings, e.endpoints, endpoints); err != nil {
+			return err
+		}
 	}
-	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
-	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
-	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
-	e.profileExporter = pprofileotlp.NewGRPCClient(e.clientConn)
+
 	headers := map[string]string{}
 	for k, v := range e.config.ClientConfig.Headers {
 		headers[k] = string(v)
@@ -79,78 +112,90 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 		grpc.WaitForReady(e.config.ClientConfig.WaitForReady),
 	}
 
-	return
+	return nil
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
-	if e.clientConn != nil {
-		return e.clientConn.Close()
+	var errs error
+	if e.telemetry != nil {
+		errs = e.telemetry.shutdown()
 	}
-	return nil
+	for _, c := range e.clients {
+		errs = errors.Join(errs, c.clientConn.Close())
+	}
+	return errs
 }
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
-		e.settings.Logger.Warn("Partial success response",
-			zap.String("message", resp.PartialSuccess().ErrorMessage()),
-			zap.Int64("dropped_spans", resp.PartialSuccess().RejectedSpans()),
-		)
-	}
-	return nil
+	return e.endpoints.Export(ctx, func(ctx context.Context, c *grpcClients) error {
+		resp, respErr := c.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
+			e.settings.Logger.Warn("Partial success response",
+				zap.String("message", resp.PartialSuccess().ErrorMessage()),
+				zap.Int64("dropped_spans", resp.PartialSuccess().RejectedSpans()),
+			)
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
-		e.settings.Logger.Warn("Partial success response",
-			zap.String("message", resp.PartialSuccess().ErrorMessage()),
-			zap.Int64("dropped_data_points", resp.PartialSuccess().RejectedDataPoints()),
-		)
-	}
-	return nil
+	return e.endpoints.Export(ctx, func(ctx context.Context, c *grpcClients) error {
+		resp, respErr := c.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
+			e.settings.Logger.Warn("Partial success response",
+				zap.String("message", resp.PartialSuccess().ErrorMessage()),
+				zap.Int64("dropped_data_points", resp.PartialSuccess().RejectedDataPoints()),
+			)
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
-		e.settings.Logger.Warn("Partial success response",
-			zap.String("message", resp.PartialSuccess().ErrorMessage()),
-			zap.Int64("dropped_log_records", resp.PartialSuccess().RejectedLogRecords()),
-		)
-	}
-	return nil
+	return e.endpoints.Export(ctx, func(ctx context.Context, c *grpcClients) error {
+		resp, respErr := c.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
+			e.settings.Logger.Warn("Partial success response",
+				zap.String("message", resp.PartialSuccess().ErrorMessage()),
+				zap.Int64("dropped_log_records", resp.PartialSuccess().RejectedLogRecords()),
+			)
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) error {
 	req := pprofileotlp.NewExportRequestFromProfiles(td)
-	resp, respErr := e.profileExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
-		return err
-	}
-	partialSuccess := resp.PartialSuccess()
-	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedProfiles() == 0) {
-		e.settings.Logger.Warn("Partial success response",
-			zap.String("message", resp.PartialSuccess().ErrorMessage()),
-			zap.Int64("dropped_profiles", resp.PartialSuccess().RejectedProfiles()),
-		)
-	}
-	return nil
+	return e.endpoints.Export(ctx, func(ctx context.Context, c *grpcClients) error {
+		resp, respErr := c.profileExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+		if err := processError(respErr); err != nil {
+			return err
+		}
+		partialSuccess := resp.PartialSuccess()
+		if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedProfiles() == 0) {
+			e.settings.Logger.Warn("Partial success response",
+				zap.String("message", resp.PartialSuccess().ErrorMessage()),
+				zap.Int64("dropped_profiles", resp.PartialSuccess().RejectedProfiles()),
+			)
+		}
+		return nil
+	})
 }
 
 func (e *baseExporter) enhanceContext(ctx context.Context) context.Context {
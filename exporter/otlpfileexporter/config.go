@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// Format defines the encoding of the data in the files.
+type Format string
+
+const (
+	// FormatProto encodes every export as an OTLP protobuf message, preceded by its size as a 4 bytes big endian integer.
+	FormatProto Format = "proto"
+	// FormatJSON encodes every export as an OTLP JSON message on its own line.
+	FormatJSON Format = "json"
+)
+
+// Validate checks if the Format is valid.
+func (f Format) Validate() error {
+	switch f {
+	case FormatProto, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("unsupported format %q, must be one of %q or %q", f, FormatProto, FormatJSON)
+}
+
+// RotationConfig defines the rotation of the files.
+type RotationConfig struct {
+	// MaxMegabytes is the size in MiB after which the file is rotated. Zero disables the rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+	// MaxBackups is the maximum number of rotated files kept for every signal, the oldest ones being deleted.
+	// Zero keeps all the rotated files.
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
+// Config defines the configuration for the OTLP file exporter.
+type Config struct {
+	// Directory is the directory where the files are written. Every signal is written to its own file,
+	// named after the signal: traces, metrics or logs.
+	Directory string `mapstructure:"directory"`
+	// Format is the encoding of the data, "proto" or "json".
+	Format Format `mapstructure:"format"`
+	// Compression is the compression of the files, "none" or "zstd".
+	Compression configcompression.Type `mapstructure:"compression"`
+	// Rotation defines the rotation of the files.
+	Rotation RotationConfig `mapstructure:"rotation"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("`directory` must be set")
+	}
+	if err := cfg.Format.Validate(); err != nil {
+		return fmt.Errorf("`format` is invalid: %w", err)
+	}
+	if cfg.Compression.IsCompressed() && cfg.Compression != configcompression.TypeZstd {
+		return fmt.Errorf("unsupported compression %q, must be %q", cfg.Compression, configcompression.TypeZstd)
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("`rotation::max_megabytes` must be non-negative")
+	}
+	if cfg.Rotation.MaxBackups < 0 {
+		return errors.New("`rotation::max_backups` must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Directory:   "/var/lib/otelcol/capture",
+			Format:      FormatJSON,
+			Compression: "none",
+			Rotation: RotationConfig{
+				MaxMegabytes: 10,
+				MaxBackups:   3,
+			},
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     func() *Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Directory = "/var/lib/otelcol/capture"
+				return cfg
+			},
+		},
+		{
+			name:    "missing_directory",
+			cfg:     func() *Config { return createDefaultConfig().(*Config) },
+			wantErr: "`directory` must be set",
+		},
+		{
+			name: "invalid_format",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Directory = "/var/lib/otelcol/capture"
+				cfg.Format = "yaml"
+				return cfg
+			},
+			wantErr: "`format` is invalid: unsupported format \"yaml\", must be one of \"proto\" or \"json\"",
+		},
+		{
+			name: "unsupported_compression",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Directory = "/var/lib/otelcol/capture"
+				cfg.Compression = configcompression.TypeGzip
+				return cfg
+			},
+			wantErr: "unsupported compression \"gzip\", must be \"zstd\"",
+		},
+		{
+			name: "negative_max_megabytes",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Directory = "/var/lib/otelcol/capture"
+				cfg.Rotation.MaxMegabytes = -1
+				return cfg
+			},
+			wantErr: "`rotation::max_megabytes` must be non-negative",
+		},
+		{
+			name: "negative_max_backups",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Directory = "/var/lib/otelcol/capture"
+				cfg.Rotation.MaxBackups = -1
+				return cfg
+			},
+			wantErr: "`rotation::max_backups` must be non-negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg().Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
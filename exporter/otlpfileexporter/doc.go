@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package otlpfileexporter implements an exporter writing the data to rotating files, encoded with OTLP protobuf
+// or OTLP JSON, which can be replayed with the OTLP file receiver.
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	protoExt = ".pb"
+	jsonExt  = ".jsonl"
+	zstdExt  = ".zst"
+)
+
+// directories holds the directories in use by the started exporters, so that different exporters cannot
+// write to the same files.
+var directories = &directoryRegistry{users: map[string]*directoryUser{}}
+
+type directoryUser struct {
+	id   component.ID
+	refs int
+}
+
+// directoryRegistry tracks the exporter using every directory. An exporter uses the directory once per signal.
+type directoryRegistry struct {
+	mu    sync.Mutex
+	users map[string]*directoryUser
+}
+
+// acquire marks the directory as used by the exporter, failing if another exporter uses it.
+func (dr *directoryRegistry) acquire(dir string, id component.ID) error {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	user, ok := dr.users[dir]
+	if !ok {
+		dr.users[dir] = &directoryUser{id: id, refs: 1}
+		return nil
+	}
+	if user.id != id {
+		return fmt.Errorf("the directory %q is already used by the exporter %q", dir, user.id)
+	}
+	user.refs++
+	return nil
+}
+
+func (dr *directoryRegistry) release(dir string) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	if user, ok := dr.users[dir]; ok {
+		if user.refs--; user.refs == 0 {
+			delete(dr.users, dir)
+		}
+	}
+}
+
+type fileExporter struct {
+	id     component.ID
+	format Format
+	writer *fileWriter
+	// dir is the absolute directory acquired by the started exporter, empty otherwise.
+	dir string
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+}
+
+// newFileExporter creates an exporter writing the data of the signal to the file named after prefix.
+func newFileExporter(cfg *Config, set exporter.Settings, prefix string) *fileExporter {
+	e := &fileExporter{
+		id:     set.ID,
+		format: cfg.Format,
+		writer: &fileWriter{
+			dir:        cfg.Directory,
+			prefix:     prefix,
+			ext:        protoExt,
+			compressed: cfg.Compression.IsCompressed(),
+			maxBytes:   int64(cfg.Rotation.MaxMegabytes) * 1024 * 1024,
+			maxBackups: cfg.Rotation.MaxBackups,
+			logger:     set.Logger,
+		},
+	}
+	if cfg.Format == FormatJSON {
+		e.writer.ext = jsonExt
+		e.tracesMarshaler = &ptrace.JSONMarshaler{}
+		e.metricsMarshaler = &pmetric.JSONMarshaler{}
+		e.logsMarshaler = &plog.JSONMarshaler{}
+	} else {
+		e.tracesMarshaler = &ptrace.ProtoMarshaler{}
+		e.metricsMarshaler = &pmetric.ProtoMarshaler{}
+		e.logsMarshaler = &plog.ProtoMarshaler{}
+	}
+	if e.writer.compressed {
+		e.writer.ext += zstdExt
+	}
+	return e
+}
+
+func (e *fileExporter) start(context.Context, component.Host) error {
+	dir, err := filepath.Abs(e.writer.dir)
+	if err != nil {
+		return err
+	}
+	if err = directories.acquire(dir, e.id); err != nil {
+		return err
+	}
+	if err = e.writer.open(); err != nil {
+		directories.release(dir)
+		return err
+	}
+	e.dir = dir
+	return nil
+}
+
+func (e *fileExporter) shutdown(context.Context) error {
+	err := e.writer.close()
+	if e.dir != "" {
+		directories.release(e.dir)
+		e.dir = ""
+	}
+	return err
+}
+
+func (e *fileExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.writer.write(e.frame(buf, time.Now()))
+}
+
+func (e *fileExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	buf, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.writer.write(e.frame(buf, time.Now()))
+}
+
+func (e *fileExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.writer.write(e.frame(buf, time.Now()))
+}
+
+// frame returns the record of the message captured at the given time. With the proto format, the message is
+// preceded by the capture time in nanoseconds since the Unix epoch as a 8 bytes big endian integer, and by its size as
+// a 4 bytes big endian integer. With the JSON format, the record is a JSON object on its own line, with the capture
+// time in nanoseconds since the Unix epoch as a string in captureTimeUnixNano, like the times of OTLP JSON, and the
+// message in data.
+func (e *fileExporter) frame(buf []byte, captured time.Time) []byte {
+	if e.format == FormatJSON {
+		record := make([]byte, 0, len(buf)+64)
+		record = append(record, `{"captureTimeUnixNano":"`...)
+		record = strconv.AppendInt(record, captured.UnixNano(), 10)
+		record = append(record, `","data":`...)
+		record = append(record, buf...)
+		return append(record, "}\n"...)
+	}
+	record := make([]byte, 0, 12+len(buf))
+	record = binary.BigEndian.AppendUint64(record, uint64(captured.UnixNano()))
+	record = binary.BigEndian.AppendUint32(record, uint32(len(buf)))
+	return append(record, buf...)
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type record struct {
+	captured time.Time
+	data     []byte
+}
+
+// readRecords reads the records of a file written with the proto or the JSON format.
+func readRecords(t *testing.T, path string) []record {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f.Close()) }()
+	var r io.Reader = f
+	if strings.HasSuffix(path, zstdExt) {
+		zr, err := zstd.NewReader(f)
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	}
+	var records []record
+	if strings.HasSuffix(strings.TrimSuffix(path, zstdExt), jsonExt) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			var line struct {
+				CaptureTimeUnixNano int64           `json:"captureTimeUnixNano,string"`
+				Data                json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			records = append(records, record{captured: time.Unix(0, line.CaptureTimeUnixNano), data: line.Data})
+		}
+		require.NoError(t, scanner.Err())
+		return records
+	}
+	for {
+		var header struct {
+			Captured int64
+			Size     uint32
+		}
+		err := binary.Read(r, binary.BigEndian, &header)
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		data := make([]byte, header.Size)
+		_, err = io.ReadFull(r, data)
+		require.NoError(t, err)
+		records = append(records, record{captured: time.Unix(0, header.Captured), data: data})
+	}
+}
+
+func generateTraces(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+func TestExportProto(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	// The exports of every start are appended to the file.
+	start := time.Now()
+	for _, name := range []string{"first", "second"} {
+		exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+		require.NoError(t, err)
+		require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, exp.ConsumeTraces(context.Background(), generateTraces(name)))
+		require.NoError(t, exp.Shutdown(context.Background()))
+	}
+
+	end := time.Now()
+
+	records := readRecords(t, filepath.Join(cfg.Directory, "traces.pb.zst"))
+	require.Len(t, records, 2)
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	// Every record has the time it was exported at.
+	assert.False(t, records[0].captured.Before(start))
+	assert.False(t, records[1].captured.Before(records[0].captured))
+	assert.False(t, records[1].captured.After(end))
+	for i, name := range []string{"first", "second"} {
+		td, err := unmarshaler.UnmarshalTraces(records[i].data)
+		require.NoError(t, err)
+		assert.Equal(t, generateTraces(name), td)
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Format = FormatJSON
+	cfg.Compression = "none"
+
+	set := exportertest.NewNopSettings()
+	metricsExp, err := factory.CreateMetrics(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, metricsExp.Start(context.Background(), componenttest.NewNopHost()))
+	logsExp, err := factory.CreateLogs(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, logsExp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	require.NoError(t, metricsExp.ConsumeMetrics(context.Background(), md))
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	start := time.Now()
+	require.NoError(t, logsExp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, logsExp.ConsumeLogs(context.Background(), ld))
+	end := time.Now()
+	require.NoError(t, metricsExp.Shutdown(context.Background()))
+	require.NoError(t, logsExp.Shutdown(context.Background()))
+
+	// Every signal is written to its own file.
+	records := readRecords(t, filepath.Join(cfg.Directory, "metrics.jsonl"))
+	require.Len(t, records, 1)
+	gotMetrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(records[0].data)
+	require.NoError(t, err)
+	assert.Equal(t, md, gotMetrics)
+
+	records = readRecords(t, filepath.Join(cfg.Directory, "logs.jsonl"))
+	require.Len(t, records, 2)
+	for _, record := range records {
+		assert.False(t, record.captured.Before(start))
+		assert.False(t, record.captured.After(end))
+		gotLogs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(record.data)
+		require.NoError(t, err)
+		assert.Equal(t, ld, gotLogs)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	for _, compression := range []configcompression.Type{"none", configcompression.TypeZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Compression = compression
+			cfg.Rotation.MaxBackups = 2
+			e := newFileExporter(cfg, exportertest.NewNopSettings(), "traces")
+			// Every export is large enough to rotate the file.
+			e.writer.maxBytes = 1
+			require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+			for _, name := range []string{"first", "second", "third"} {
+				require.NoError(t, e.pushTraces(context.Background(), generateTraces(name)))
+			}
+			require.NoError(t, e.shutdown(context.Background()))
+
+			// The oldest rotated file is deleted, the current file is empty.
+			entries, err := os.ReadDir(cfg.Directory)
+			require.NoError(t, err)
+			require.Len(t, entries, 3)
+			assert.Equal(t, "traces"+e.writer.ext, entries[2].Name())
+			assert.Empty(t, readRecords(t, filepath.Join(cfg.Directory, entries[2].Name())))
+			for i, name := range []string{"second", "third"} {
+				assert.True(t, strings.HasPrefix(entries[i].Name(), "traces-"), entries[i].Name())
+				records := readRecords(t, filepath.Join(cfg.Directory, entries[i].Name()))
+				require.Len(t, records, 1)
+				td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(records[0].data)
+				require.NoError(t, err)
+				assert.Equal(t, generateTraces(name), td)
+			}
+		})
+	}
+}
+
+func TestRotationFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = filepath.Join(t.TempDir(), "capture")
+	core, observed := observer.New(zap.WarnLevel)
+	set := exportertest.NewNopSettings()
+	set.Logger = zap.New(core)
+	e := newFileExporter(cfg, set, "traces")
+	e.writer.maxBytes = 1
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+	// The file cannot be renamed once its directory is removed, the written record does not fail the export.
+	require.NoError(t, os.RemoveAll(cfg.Directory))
+	require.NoError(t, e.pushTraces(context.Background(), generateTraces("first")))
+	assert.Equal(t, 1, observed.FilterMessage("Failed to rotate the file").Len())
+
+	// The file was opened again for the next records.
+	require.NoError(t, e.pushTraces(context.Background(), generateTraces("second")))
+	require.NoError(t, e.shutdown(context.Background()))
+	entries, err := os.ReadDir(cfg.Directory)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestSharedDirectory(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	set := exportertest.NewNopSettings()
+	traces, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The same exporter writes all the signals to the directory.
+	logs, err := factory.CreateLogs(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Another exporter cannot use the directory until all the signals of the exporter are shut down.
+	otherSet := exportertest.NewNopSettings()
+	otherSet.ID = component.MustNewIDWithName("otlp_file", "other")
+	startOther := func() error {
+		other, err := factory.CreateTraces(context.Background(), otherSet, cfg)
+		require.NoError(t, err)
+		err = other.Start(context.Background(), componenttest.NewNopHost())
+		require.NoError(t, other.Shutdown(context.Background()))
+		return err
+	}
+	require.ErrorContains(t, startOther(), "is already used by the exporter")
+	require.NoError(t, traces.Shutdown(context.Background()))
+	require.ErrorContains(t, startOther(), "is already used by the exporter")
+	require.NoError(t, logs.Shutdown(context.Background()))
+	require.NoError(t, startOther())
+}
+
+func TestWriteAfterShutdown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	e := newFileExporter(cfg, exportertest.NewNopSettings(), "traces")
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, e.shutdown(context.Background()))
+	require.EqualError(t, e.pushTraces(context.Background(), generateTraces("late")), "file writer is closed")
+}
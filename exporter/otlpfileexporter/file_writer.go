@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+// rotationTimeFormat is the format of the time of the rotation, added to the name of the rotated files.
+// Its fixed width keeps the rotated files sorted by name in the order they were written.
+const rotationTimeFormat = "20060102T150405.000000000"
+
+// countingWriter counts the bytes written to the file.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// fileWriter writes the records of a signal to its file, and rotates the file once too large.
+// The current file is named <prefix><ext>, the rotated files <prefix>-<rotation time><ext>.
+type fileWriter struct {
+	dir        string
+	prefix     string
+	ext        string
+	compressed bool
+	maxBytes   int64
+	maxBackups int
+	logger     *zap.Logger
+
+	mu sync.Mutex
+	// file is nil once closed, or if the file could not be opened again after a rotation.
+	file   *os.File
+	cw     *countingWriter
+	zw     *zstd.Encoder
+	closed bool
+}
+
+func (fw *fileWriter) path() string {
+	return filepath.Join(fw.dir, fw.prefix+fw.ext)
+}
+
+// open opens the file, appending to it if it already exists. A compressed file gets a new zstd frame.
+func (fw *fileWriter) open() error {
+	if err := os.MkdirAll(fw.dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(fw.path(), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+	cw := &countingWriter{w: file, n: fi.Size()}
+	if fw.compressed {
+		if fw.zw, err = zstd.NewWriter(cw); err != nil {
+			return errors.Join(err, file.Close())
+		}
+	}
+	fw.file, fw.cw = file, cw
+	return nil
+}
+
+// write writes the record, flushed to the file, and rotates the file if it reached the maximum size.
+// A failed rotation does not fail the write of the record, it is only logged: the record would be written again
+// by the retries of the export. The file is opened again by the next write if the rotation could not open it.
+func (fw *fileWriter) write(record []byte) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.closed {
+		return errors.New("file writer is closed")
+	}
+	if fw.file == nil {
+		if err := fw.open(); err != nil {
+			return err
+		}
+	}
+	if fw.zw != nil {
+		if _, err := fw.zw.Write(record); err != nil {
+			return err
+		}
+		if err := fw.zw.Flush(); err != nil {
+			return err
+		}
+	} else if _, err := fw.cw.Write(record); err != nil {
+		return err
+	}
+	if fw.maxBytes > 0 && fw.cw.n >= fw.maxBytes {
+		if err := fw.rotate(); err != nil {
+			fw.logger.Warn("Failed to rotate the file", zap.String("path", fw.path()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// rotate renames the current file after the rotation time, deletes the oldest rotated files and opens a new file.
+func (fw *fileWriter) rotate() error {
+	if err := fw.closeFile(); err != nil {
+		return err
+	}
+	rotated := filepath.Join(fw.dir, fw.prefix+"-"+time.Now().UTC().Format(rotationTimeFormat)+fw.ext)
+	if err := os.Rename(fw.path(), rotated); err != nil {
+		return errors.Join(err, fw.open())
+	}
+	return errors.Join(fw.deleteOldBackups(), fw.open())
+}
+
+func (fw *fileWriter) deleteOldBackups() error {
+	if fw.maxBackups == 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(fw.dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, entry := range entries {
+		if name := entry.Name(); strings.HasPrefix(name, fw.prefix+"-") && strings.HasSuffix(name, fw.ext) {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= fw.maxBackups {
+		return nil
+	}
+	sort.Strings(backups)
+	var errs error
+	for _, name := range backups[:len(backups)-fw.maxBackups] {
+		errs = errors.Join(errs, os.Remove(filepath.Join(fw.dir, name)))
+	}
+	return errs
+}
+
+func (fw *fileWriter) closeFile() error {
+	var errs error
+	if fw.zw != nil {
+		errs = fw.zw.Close()
+		fw.zw = nil
+	}
+	errs = errors.Join(errs, fw.file.Close())
+	fw.file = nil
+	return errs
+}
+
+func (fw *fileWriter) close() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	fw.closed = true
+	if fw.file == nil {
+		return nil
+	}
+	return fw.closeFile()
+}
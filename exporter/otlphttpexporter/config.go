@@ -47,7 +47,13 @@ func (e *EncodingType) UnmarshalText(text []byte) error {
 type Config struct {
 	confighttp.ClientConfig    `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 	exporterhelper.QueueConfig `mapstructure:"sending_queue"`
-	RetryConfig                configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	RetryConfig                configretry.BackOffConfig           `mapstructure:"retry_on_failure"`
+	DeadLetterConfig           exporterhelper.DeadLetterConfig     `mapstructure:"dead_letter"`
+	CircuitBreakerConfig       exporterhelper.CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// Failover defines the endpoints the exports fail over to when the endpoint is unhealthy,
+	// and the hedging of slow exports. The signal paths are appended to the failover endpoints.
+	Failover FailoverConfig `mapstructure:"failover"`
 
 	// The URL to send traces to. If omitted the Endpoint + "/v1/traces" will be used.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -32,6 +33,7 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 }
 
 func TestUnmarshalConfig(t *testing.T) {
+	deadLetterStorageID := component.MustNewIDWithName("file_storage", "dead_letter")
 	defaultMaxIdleConns := http.DefaultTransport.(*http.Transport).MaxIdleConns
 	defaultMaxIdleConnsPerHost := http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost
 	defaultMaxConnsPerHost := http.DefaultTransport.(*http.Transport).MaxConnsPerHost
@@ -51,11 +53,32 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				Throttle:            configretry.NewDefaultThrottleConfig(),
 			},
 			QueueConfig: exporterhelper.QueueConfig{
-				Enabled:      true,
-				NumConsumers: 2,
-				QueueSize:    10,
+				Enabled:             true,
+				NumConsumers:        2,
+				QueueSize:           10,
+				Sizer:               exporterqueue.SizerTypeRequests,
+				AdaptiveConcurrency: exporterhelper.NewDefaultAdaptiveConcurrencyConfig(),
+			},
+			DeadLetterConfig: exporterhelper.DeadLetterConfig{
+				Enabled:   true,
+				StorageID: &deadLetterStorageID,
+			},
+			CircuitBreakerConfig: exporterhelper.CircuitBreakerConfig{
+				Enabled:          true,
+				FailureRatio:     0.8,
+				MinAttempts:      20,
+				Window:           time.Minute,
+				OpenDuration:     10 * time.Second,
+				HalfOpenAttempts: 2,
+				FailFast:         false,
+			},
+			Failover: FailoverConfig{
+				Endpoints:         []string{"https://5.6.7.8:1234"},
+				UnhealthyDuration: time.Minute,
+				HedgeAfter:        500 * time.Millisecond,
 			},
 			Encoding: EncodingProto,
 			ClientConfig: confighttp.ClientConfig{
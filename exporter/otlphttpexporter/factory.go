@@ -18,8 +18,11 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -43,10 +46,13 @@ func createDefaultConfig() component.Config {
 	clientConfig.WriteBufferSize = 512 * 1024
 
 	return &Config{
-		RetryConfig:  configretry.NewDefaultBackOffConfig(),
-		QueueConfig:  exporterhelper.NewDefaultQueueConfig(),
-		Encoding:     EncodingProto,
-		ClientConfig: clientConfig,
+		RetryConfig:          configretry.NewDefaultBackOffConfig(),
+		QueueConfig:          exporterhelper.NewDefaultQueueConfig(),
+		DeadLetterConfig:     exporterhelper.NewDefaultDeadLetterConfig(),
+		CircuitBreakerConfig: exporterhelper.NewDefaultCircuitBreakerConfig(),
+		Failover:             failover.NewDefaultConfig(),
+		Encoding:             EncodingProto,
+		ClientConfig:         clientConfig,
 	}
 }
 
@@ -66,11 +72,31 @@ func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string,
 	case oCfg.Endpoint == "":
 		return "", fmt.Errorf("either endpoint or %s_endpoint must be specified", signalName)
 	default:
-		if strings.HasSuffix(oCfg.Endpoint, "/") {
-			return oCfg.Endpoint + signalVersion + "/" + signalName, nil
+		return appendSignalPath(oCfg.Endpoint, signalName, signalVersion), nil
+	}
+}
+
+// composeSignalURLs composes the URL for the signal of the endpoint, followed by the URLs of the failover endpoints.
+func composeSignalURLs(oCfg *Config, signalOverrideURL string, signalName string, signalVersion string) ([]string, error) {
+	signalURL, err := composeSignalURL(oCfg, signalOverrideURL, signalName, signalVersion)
+	if err != nil {
+		return nil, err
+	}
+	urls := []string{signalURL}
+	for _, endpoint := range oCfg.Failover.Endpoints {
+		if _, err := url.Parse(endpoint); err != nil {
+			return nil, fmt.Errorf("failover endpoint %q must be a valid URL", endpoint)
 		}
-		return oCfg.Endpoint + "/" + signalVersion + "/" + signalName, nil
+		urls = append(urls, appendSignalPath(endpoint, signalName, signalVersion))
+	}
+	return urls, nil
+}
+
+func appendSignalPath(endpoint string, signalName string, signalVersion string) string {
+	if strings.HasSuffix(endpoint, "/") {
+		return endpoint + signalVersion + "/" + signalName
 	}
+	return endpoint + "/" + signalVersion + "/" + signalName
 }
 
 func createTraces(
@@ -84,7 +110,8 @@ func createTraces(
 	}
 	oCfg := cfg.(*Config)
 
-	oce.tracesURL, err = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces", "v1")
+	oce.signal = pipeline.SignalTraces
+	oce.tracesURLs, err = composeSignalURLs(oCfg, oCfg.TracesEndpoint, "traces", "v1")
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +119,14 @@ func createTraces(
 	return exporterhelper.NewTraces(ctx, set, cfg,
 		oce.pushTraces,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
-		exporterhelper.WithQueue(oCfg.QueueConfig))
+		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig))
 }
 
 func createMetrics(
@@ -110,7 +140,8 @@ func createMetrics(
 	}
 	oCfg := cfg.(*Config)
 
-	oce.metricsURL, err = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics", "v1")
+	oce.signal = pipeline.SignalMetrics
+	oce.metricsURLs, err = composeSignalURLs(oCfg, oCfg.MetricsEndpoint, "metrics", "v1")
 	if err != nil {
 		return nil, err
 	}
@@ -118,11 +149,14 @@ func createMetrics(
 	return exporterhelper.NewMetrics(ctx, set, cfg,
 		oce.pushMetrics,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
-		exporterhelper.WithQueue(oCfg.QueueConfig))
+		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig))
 }
 
 func createLogs(
@@ -135,7 +169,8 @@ func createLogs(
 		return nil, err
 	}
 	oCfg := cfg.(*Config)
-	oce.logsURL, err = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs", "v1")
+	oce.signal = pipeline.SignalLogs
+	oce.logsURLs, err = composeSignalURLs(oCfg, oCfg.LogsEndpoint, "logs", "v1")
 	if err != nil {
 		return nil, err
 	}
@@ -143,11 +178,14 @@ func createLogs(
 	return exporterhelper.NewLogs(ctx, set, cfg,
 		oce.pushLogs,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
-		exporterhelper.WithQueue(oCfg.QueueConfig))
+		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig))
 }
 
 func createProfiles(
@@ -161,7 +199,8 @@ func createProfiles(
 	}
 	oCfg := cfg.(*Config)
 
-	oce.profilesURL, err = composeSignalURL(oCfg, "", "profiles", "v1development")
+	oce.signal = xpipeline.SignalProfiles
+	oce.profilesURLs, err = composeSignalURLs(oCfg, "", "profiles", "v1development")
 	if err != nil {
 		return nil, err
 	}
@@ -169,9 +208,12 @@ func createProfiles(
 	return xexporterhelper.NewProfilesExporter(ctx, set, cfg,
 		oce.pushProfiles,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
-		exporterhelper.WithQueue(oCfg.QueueConfig))
+		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig))
 }
@@ -232,3 +232,23 @@ func TestComposeSignalURL(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "http://localhost:4318/v2/traces", url)
 }
+
+func TestComposeSignalURLs(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "http://localhost:4318"
+	cfg.Failover.Endpoints = []string{"http://secondary:4318/", "http://tertiary:4318"}
+
+	urls, err := composeSignalURLs(cfg, "", "traces", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://localhost:4318/v1/traces", "http://secondary:4318/v1/traces", "http://tertiary:4318/v1/traces"}, urls)
+
+	// The signal specific URL only overrides the URL of the endpoint.
+	urls, err = composeSignalURLs(cfg, "http://localhost:4318/custom", "traces", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://localhost:4318/custom", "http://secondary:4318/v1/traces", "http://tertiary:4318/v1/traces"}, urls)
+
+	cfg.Failover.Endpoints = []string{":invalid"}
+	_, err = composeSignalURLs(cfg, "", "traces", "v1")
+	require.EqualError(t, err, `failover endpoint ":invalid" must be a valid URL`)
+}
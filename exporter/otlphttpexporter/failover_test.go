@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadatatest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// failoverBackend counts the trace requests and responds with the configured status after the configured delay.
+type failoverBackend struct {
+	requests atomic.Int64
+	status   atomic.Int64
+	delay    time.Duration
+}
+
+func (b *failoverBackend) handle(w http.ResponseWriter, _ *http.Request) {
+	b.requests.Add(1)
+	time.Sleep(b.delay)
+	w.WriteHeader(int(b.status.Load()))
+}
+
+func startFailoverBackend(t *testing.T, delay time.Duration) (*failoverBackend, string) {
+	b := &failoverBackend{delay: delay}
+	b.status.Store(http.StatusOK)
+	srv := createBackend("/v1/traces", b.handle)
+	t.Cleanup(srv.Close)
+	return b, srv.URL
+}
+
+func newFailoverTracesConfig(primary, secondary string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.Enabled = false
+	cfg.Endpoint = primary
+	cfg.Failover.Endpoints = []string{secondary}
+	return cfg
+}
+
+func TestSendTracesFailover(t *testing.T) {
+	primary, primaryURL := startFailoverBackend(t, 0)
+	secondary, secondaryURL := startFailoverBackend(t, 0)
+	primary.status.Store(http.StatusServiceUnavailable)
+
+	cfg := newFailoverTracesConfig(primaryURL, secondaryURL)
+	exp, err := NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(context.Background())) })
+
+	// The export fails over to the secondary endpoint.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.EqualValues(t, 1, primary.requests.Load())
+	assert.EqualValues(t, 1, secondary.requests.Load())
+
+	// The unhealthy primary endpoint is skipped.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.EqualValues(t, 1, primary.requests.Load())
+	assert.EqualValues(t, 2, secondary.requests.Load())
+
+	// Permanent errors are not failed over.
+	secondary.status.Store(http.StatusBadRequest)
+	require.Error(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.EqualValues(t, 1, primary.requests.Load())
+	assert.EqualValues(t, 3, secondary.requests.Load())
+}
+
+func TestSendTracesHedging(t *testing.T) {
+	_, primaryURL := startFailoverBackend(t, 200*time.Millisecond)
+	secondary, secondaryURL := startFailoverBackend(t, 0)
+
+	cfg := newFailoverTracesConfig(primaryURL, secondaryURL)
+	cfg.Failover.HedgeAfter = 10 * time.Millisecond
+	exp, err := NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(context.Background())) })
+
+	// The slow export is also sent to the secondary endpoint, which responds first.
+	start := time.Now()
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+	assert.EqualValues(t, 1, secondary.requests.Load())
+}
+
+func TestEndpointsTelemetry(t *testing.T) {
+	tel := metadatatest.SetupTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	endpoints := failover.NewEndpoints(failover.NewDefaultConfig(), []int{0, 1})
+	et, err := newEndpointsTelemetry(component.MustNewID("otlphttp"), pipeline.SignalTraces, tel.NewTelemetrySettings(), endpoints, []string{"primary:4317", "secondary:4317"})
+	require.NoError(t, err)
+
+	require.NoError(t, endpoints.Export(context.Background(), func(_ context.Context, i int) error {
+		if i == 0 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}))
+	endpoints.OnHedge(context.Background())
+
+	tel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_endpoint_healthy",
+			Description: "Whether the endpoint is healthy (1) or not (0), reported for every endpoint when failover endpoints are configured.",
+			Unit:        "1",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlphttp"), attribute.String("data_type", "traces"), attribute.String("endpoint", "primary:4317")),
+						Value:      0,
+					},
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlphttp"), attribute.String("data_type", "traces"), attribute.String("endpoint", "secondary:4317")),
+						Value:      1,
+					},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_exporter_hedged_requests",
+			Description: "Number of requests also sent to the next endpoint because the previous endpoints did not respond within the hedging delay. [alpha]",
+			Unit:        "{requests}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlphttp"), attribute.String("data_type", "traces")),
+						Value:      1,
+					},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp())
+
+	// The health of the endpoints is no longer reported once shut down.
+	require.NoError(t, et.shutdown())
+	tel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_exporter_hedged_requests",
+			Description: "Number of requests also sent to the next endpoint because the previous endpoints did not respond within the hedging delay. [alpha]",
+			Unit:        "{requests}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("exporter", "otlphttp"), attribute.String("data_type", "traces")),
+						Value:      1,
+					},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
@@ -0,0 +1,85 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("go.opentelemetry.io/collector/exporter/otlphttpexporter")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("go.opentelemetry.io/collector/exporter/otlphttpexporter")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                   metric.Meter
+	ExporterEndpointHealthy metric.Int64ObservableGauge
+	ExporterHedgedRequests  metric.Int64Counter
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// InitExporterEndpointHealthy configures the ExporterEndpointHealthy metric.
+func (builder *TelemetryBuilder) InitExporterEndpointHealthy(cb func() int64, opts ...metric.ObserveOption) (metric.Registration, error) {
+	var err error
+	builder.ExporterEndpointHealthy, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_endpoint_healthy",
+		metric.WithDescription("Whether the endpoint is healthy (1) or not (0), reported for every endpoint when failover endpoints are configured."),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := builder.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(builder.ExporterEndpointHealthy, cb(), opts...)
+		return nil
+	}, builder.ExporterEndpointHealthy)
+	return reg, err
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.ExporterHedgedRequests, err = getLeveledMeter(builder.meter, configtelemetry.LevelBasic, settings.MetricsLevel).Int64Counter(
+		"otelcol_exporter_hedged_requests",
+		metric.WithDescription("Number of requests also sent to the next endpoint because the previous endpoints did not respond within the hedging delay. [alpha]"),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
+
+func getLeveledMeter(meter metric.Meter, cfgLevel, srvLevel configtelemetry.Level) metric.Meter {
+	if cfgLevel <= srvLevel {
+		return meter
+	}
+	return noopmetric.Meter{}
+}
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/internal/httphelper"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -32,18 +33,25 @@ import (
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
 )
 
 type baseExporter struct {
 	// Input configuration.
-	config      *Config
-	client      *http.Client
-	tracesURL   string
-	metricsURL  string
-	logsURL     string
-	profilesURL string
-	logger      *zap.Logger
-	settings    component.TelemetrySettings
+	config *Config
+	client *http.Client
+	// URLs of the endpoint followed by the failover endpoints.
+	tracesURLs   []string
+	metricsURLs  []string
+	logsURLs     []string
+	profilesURLs []string
+	// endpoints sends the exports to the URLs, by their index.
+	endpoints *failover.Endpoints[int]
+	id        component.ID
+	signal    pipeline.Signal
+	logger    *zap.Logger
+	settings  component.TelemetrySettings
+	telemetry *endpointsTelemetry
 	// Default user-agent header.
 	userAgent string
 }
@@ -73,6 +81,7 @@ func newExporter(cfg component.Config, set exporter.Settings) (*baseExporter, er
 	// client construction is deferred to start
 	return &baseExporter{
 		config:    oCfg,
+		id:        set.ID,
 		logger:    set.Logger,
 		userAgent: userAgent,
 		settings:  set.TelemetrySettings,
@@ -87,6 +96,25 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	e.client = client
+
+	endpoints := append([]string{e.config.Endpoint}, e.config.Failover.Endpoints...)
+	indexes := make([]int, len(endpoints))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	e.endpoints = failover.NewEndpoints(e.config.Failover, indexes)
+	if len(endpoints) > 1 {
+		if e.telemetry, err = newEndpointsTelemetry(e.id, e.signal, e.settings, e.endpoints, endpoints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *baseExporter) shutdown(context.Context) error {
+	if e.telemetry != nil {
+		return e.telemetry.shutdown()
+	}
 	return nil
 }
 
@@ -108,7 +136,7 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.tracesURL, request, e.tracesPartialSuccessHandler)
+	return e.export(ctx, e.tracesURLs, request, e.tracesPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
@@ -128,7 +156,7 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	return e.export(ctx, e.metricsURL, request, e.metricsPartialSuccessHandler)
+	return e.export(ctx, e.metricsURLs, request, e.metricsPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
@@ -149,7 +177,7 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.logsURL, request, e.logsPartialSuccessHandler)
+	return e.export(ctx, e.logsURLs, request, e.logsPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) error {
@@ -170,10 +198,17 @@ func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) e
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.profilesURL, request, e.profilesPartialSuccessHandler)
+	return e.export(ctx, e.profilesURLs, request, e.profilesPartialSuccessHandler)
+}
+
+// export sends the request to the first healthy URL, failing over to the next ones.
+func (e *baseExporter) export(ctx context.Context, urls []string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+	return e.endpoints.Export(ctx, func(ctx context.Context, i int) error {
+		return e.exportTo(ctx, urls[i], request, partialSuccessHandler)
+	})
 }
 
-func (e *baseExporter) export(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+func (e *baseExporter) exportTo(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
@@ -225,23 +260,33 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	if isRetryableStatusCode(resp.StatusCode) {
 		// A retry duration of 0 seconds will trigger the default backoff policy
 		// of our caller (retry handler).
-		retryAfter := 0
+		var retryAfter time.Duration
 
 		// Check if the server is overwhelmed.
 		// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#otlphttp-throttling
 		isThrottleError := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
 		if val := resp.Header.Get(headerRetryAfter); isThrottleError && val != "" {
-			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
-			}
+			retryAfter = parseRetryAfter(val, time.Now())
 		}
 
-		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
+		return exporterhelper.NewThrottleRetry(formattedErr, retryAfter)
 	}
 
 	return consumererror.NewPermanent(formattedErr)
 }
 
+// parseRetryAfter returns the delay of a Retry-After header, either a number of seconds or an HTTP date.
+// See https://www.rfc-editor.org/rfc/rfc9110#field.retry-after
+func parseRetryAfter(val string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(val); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(val); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
 // Determine if the status code is retryable according to the specification.
 // For more, see https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures-1
 func isRetryableStatusCode(code int) bool {
@@ -255,6 +255,25 @@ func TestErrorResponses(t *testing.T) {
 	}
 }
 
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		val      string
+		expected time.Duration
+	}{
+		{val: "30", expected: 30 * time.Second},
+		{val: "-1", expected: 0},
+		{val: "Mon, 01 Jan 2024 12:01:30 GMT", expected: 90 * time.Second},
+		{val: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0},
+		{val: "soon", expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryAfter(tt.val, now))
+		})
+	}
+}
+
 func TestErrorResponseInvalidResponseBody(t *testing.T) {
 	resp := &http.Response{
 		StatusCode:    http.StatusBadRequest,
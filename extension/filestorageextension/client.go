@@ -0,0 +1,512 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// The data of a client is stored in an append-only log file. Every batch of operations is appended as a single
+// record, so the operations of a batch are applied atomically:
+//
+//	┌──────────────┬──────────────┬──────────────────────────────────────────────┐
+//	│ length (u32) │ crc32c (u32) │ operations: type, key length, key[, value]... │
+//	└──────────────┴──────────────┴──────────────────────────────────────────────┘
+//
+// The file is read when the client is opened to index the offset of the value of every key, a partially written
+// record at the end of the file is truncated. Once the overwritten and deleted data are larger than the current
+// data, the file is compacted by rewriting only the current values.
+const (
+	recordHeaderSize = 8
+
+	opSet    byte = 1
+	opDelete byte = 2
+
+	// minCompactionSize is the minimum size of the obsolete data in the file triggering a compaction.
+	minCompactionSize = 1 << 20
+	// maxCompactionRecordSize is the size after which the compaction starts a new record.
+	maxCompactionRecordSize = 1 << 20
+
+	compactionSuffix = ".compact"
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errClientClosed    = errors.New("storage client is closed")
+	errStorageFull     = errors.New("storage size limit reached")
+	errCorruptedRecord = errors.New("corrupted record")
+	errClientFailed    = errors.New("storage client failed")
+
+	// reopenFile opens the storage file again after it was replaced by the compaction.
+	reopenFile = func(path string) (*os.File, error) { return os.OpenFile(path, os.O_RDWR, 0o600) }
+	// syncDir flushes the entries of the directory to the disk, so that a rename in it survives a machine crash.
+	syncDir = func(dir string) error {
+		if runtime.GOOS == "windows" {
+			// Directories cannot be opened to be synced on Windows.
+			return nil
+		}
+		d, err := os.Open(dir)
+		if err != nil {
+			return err
+		}
+		return errors.Join(d.Sync(), d.Close())
+	}
+)
+
+// valueRef locates the current value of a key in the file.
+type valueRef struct {
+	offset int64
+	length int
+	// size is the size of the whole operation that set the value.
+	size int64
+}
+
+// logOp is an operation read from a record.
+type logOp struct {
+	key     string
+	ref     valueRef
+	deleted bool
+}
+
+type fileClient struct {
+	path    string
+	fsync   FSyncPolicy
+	maxSize int64
+	logger  *zap.Logger
+	onClose func()
+
+	// mu guards everything declared below.
+	mu    sync.Mutex
+	file  *os.File
+	index map[string]valueRef
+	// size is the size of the file.
+	size int64
+	// liveSize is the size of the operations setting the current values.
+	liveSize int64
+	dirty    bool
+	closed   bool
+	// failed is set if the file cannot be used anymore, every operation returns it.
+	failed error
+
+	stopSync chan struct{}
+	syncDone chan struct{}
+}
+
+var _ storage.Client = (*fileClient)(nil)
+
+func openFileClient(path string, cfg *Config, logger *zap.Logger, onClose func()) (*fileClient, error) {
+	// A leftover of an interrupted compaction, the original file is still complete.
+	if err := os.Remove(path + compactionSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the storage file: %w", err)
+	}
+	c := &fileClient{
+		path:  path,
+		fsync: cfg.FSync,
+		// nolint: gosec
+		maxSize: int64(cfg.MaxSizeMiB) << 20,
+		logger:  logger,
+		onClose: onClose,
+		file:    file,
+		index:   map[string]valueRef{},
+	}
+	if err = c.load(); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to read the storage file: %w", err), file.Close())
+	}
+	if c.needsCompaction() {
+		if err = c.compact(); err != nil {
+			c.logger.Warn("Failed to compact the storage file", zap.Error(err))
+		}
+	}
+	if c.fsync == FSyncInterval {
+		c.stopSync = make(chan struct{})
+		c.syncDone = make(chan struct{})
+		go c.syncPeriodically(cfg.FSyncInterval)
+	}
+	return c, nil
+}
+
+// load indexes the records of the file and truncates the file after the last valid record.
+func (c *fileClient) load() error {
+	info, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+	fileSize := info.Size()
+	r := bufio.NewReader(io.NewSectionReader(c.file, 0, fileSize))
+	header := make([]byte, recordHeaderSize)
+	var offset int64
+	for offset < fileSize {
+		if _, err = io.ReadFull(r, header); err != nil {
+			return c.truncate(offset, err)
+		}
+		length := int64(binary.LittleEndian.Uint32(header))
+		if length > fileSize-offset-recordHeaderSize {
+			return c.truncate(offset, io.ErrUnexpectedEOF)
+		}
+		payload := make([]byte, length)
+		if _, err = io.ReadFull(r, payload); err != nil {
+			return c.truncate(offset, err)
+		}
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:]) {
+			return c.truncate(offset, errCorruptedRecord)
+		}
+		var ops []logOp
+		if ops, err = parseRecord(offset+recordHeaderSize, payload); err != nil {
+			return c.truncate(offset, err)
+		}
+		c.apply(ops)
+		offset += recordHeaderSize + length
+	}
+	c.size = offset
+	return nil
+}
+
+// truncate drops the unreadable end of the file starting at the given offset.
+func (c *fileClient) truncate(offset int64, cause error) error {
+	if !errors.Is(cause, io.ErrUnexpectedEOF) && !errors.Is(cause, errCorruptedRecord) {
+		return cause
+	}
+	c.logger.Warn("Truncating the unreadable end of the storage file", zap.Int64("offset", offset), zap.Error(cause))
+	c.size = offset
+	return c.file.Truncate(offset)
+}
+
+// parseRecord returns the operations of the payload of the record, offset being the position of the payload in the file.
+func parseRecord(offset int64, payload []byte) ([]logOp, error) {
+	var ops []logOp
+	for pos := 0; pos < len(payload); {
+		start := pos
+		opType := payload[pos]
+		pos++
+		keyLen, n := binary.Uvarint(payload[pos:])
+		if n <= 0 || keyLen > uint64(len(payload)-pos-n) {
+			return nil, errCorruptedRecord
+		}
+		pos += n
+		op := logOp{key: string(payload[pos : pos+int(keyLen)])}
+		pos += int(keyLen)
+		switch opType {
+		case opSet:
+			valueLen, m := binary.Uvarint(payload[pos:])
+			if m <= 0 || valueLen > uint64(len(payload)-pos-m) {
+				return nil, errCorruptedRecord
+			}
+			pos += m
+			op.ref = valueRef{offset: offset + int64(pos), length: int(valueLen)}
+			pos += int(valueLen)
+			op.ref.size = int64(pos - start)
+		case opDelete:
+			op.deleted = true
+		default:
+			return nil, errCorruptedRecord
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func appendOp(payload []byte, opType byte, key string, value []byte) []byte {
+	payload = append(payload, opType)
+	payload = binary.AppendUvarint(payload, uint64(len(key)))
+	payload = append(payload, key...)
+	if opType == opSet {
+		payload = binary.AppendUvarint(payload, uint64(len(value)))
+		payload = append(payload, value...)
+	}
+	return payload
+}
+
+// apply updates the index with the operations of a record. Caller must hold the lock.
+func (c *fileClient) apply(ops []logOp) {
+	for _, op := range ops {
+		if old, ok := c.index[op.key]; ok {
+			c.liveSize -= old.size
+			delete(c.index, op.key)
+		}
+		if !op.deleted {
+			c.index[op.key] = op.ref
+			c.liveSize += op.ref.size
+		}
+	}
+}
+
+// Get returns the value of the key, or nil if the key is not found.
+func (c *fileClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set stores the value of the key.
+func (c *fileClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete removes the key.
+func (c *fileClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch executes the operations in order. The Set and Delete operations are written to the file
+// as a single record, either all of them are stored or none.
+func (c *fileClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errClientClosed
+	}
+	if c.failed != nil {
+		return c.failed
+	}
+
+	var payload []byte
+	// pending holds the values written by the previous operations of the batch, nil for the deleted keys.
+	pending := map[string][]byte{}
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			if value, ok := pending[op.Key]; ok {
+				op.Value = bytes.Clone(value)
+				continue
+			}
+			value, err := c.read(op.Key)
+			if err != nil {
+				return err
+			}
+			op.Value = value
+		case storage.Set:
+			payload = appendOp(payload, opSet, op.Key, op.Value)
+			pending[op.Key] = op.Value
+		case storage.Delete:
+			payload = appendOp(payload, opDelete, op.Key, nil)
+			pending[op.Key] = nil
+		default:
+			return errors.New("wrong operation type")
+		}
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+	return c.write(payload)
+}
+
+// read returns the current value of the key. Caller must hold the lock.
+func (c *fileClient) read(key string) ([]byte, error) {
+	ref, ok := c.index[key]
+	if !ok {
+		return nil, nil
+	}
+	value := make([]byte, ref.length)
+	if _, err := c.file.ReadAt(value, ref.offset); err != nil {
+		return nil, fmt.Errorf("failed to read the value of %q: %w", key, err)
+	}
+	return value, nil
+}
+
+// write appends a record with the given payload. Caller must hold the lock.
+func (c *fileClient) write(payload []byte) error {
+	recordSize := int64(recordHeaderSize + len(payload))
+	if c.maxSize > 0 && c.size+recordSize > c.maxSize {
+		// Reclaim the space of the obsolete data before rejecting the write.
+		if c.size > c.liveSize {
+			if err := c.compact(); err != nil {
+				c.logger.Warn("Failed to compact the storage file", zap.Error(err))
+			}
+		}
+		if c.size+recordSize > c.maxSize {
+			return fmt.Errorf("%w: writing %d bytes would exceed %d bytes", errStorageFull, recordSize, c.maxSize)
+		}
+	}
+
+	ops, err := writeRecord(c.file, c.size, payload)
+	if err != nil {
+		// Drop the partially written record, if any, so it doesn't hide the next records.
+		return errors.Join(err, c.file.Truncate(c.size))
+	}
+	c.apply(ops)
+	c.size += recordSize
+
+	if c.fsync == FSyncAlways {
+		if err = c.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync the storage file: %w", err)
+		}
+	} else {
+		c.dirty = true
+	}
+
+	if c.needsCompaction() {
+		if err = c.compact(); err != nil {
+			c.logger.Warn("Failed to compact the storage file", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// writeRecord writes the record at the given offset of the file and returns its operations.
+func writeRecord(file *os.File, offset int64, payload []byte) ([]logOp, error) {
+	record := make([]byte, recordHeaderSize, recordHeaderSize+len(payload))
+	// nolint: gosec
+	binary.LittleEndian.PutUint32(record, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:], crc32.Checksum(payload, crcTable))
+	record = append(record, payload...)
+	if _, err := file.WriteAt(record, offset); err != nil {
+		return nil, fmt.Errorf("failed to write the storage file: %w", err)
+	}
+	return parseRecord(offset+recordHeaderSize, payload)
+}
+
+// needsCompaction returns true if the obsolete data are large enough to compact the file. Caller must hold the lock.
+func (c *fileClient) needsCompaction() bool {
+	obsolete := c.size - c.liveSize
+	return obsolete >= minCompactionSize && obsolete > c.liveSize
+}
+
+// compact rewrites the current values to a new file replacing the current one. Caller must hold the lock.
+func (c *fileClient) compact() error {
+	tmpPath := c.path + compactionSuffix
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	cleanup := func(err error) error {
+		return errors.Join(err, tmp.Close(), os.Remove(tmpPath))
+	}
+
+	index := make(map[string]valueRef, len(c.index))
+	var size, liveSize int64
+	var payload []byte
+	flush := func() error {
+		ops, writeErr := writeRecord(tmp, size, payload)
+		if writeErr != nil {
+			return writeErr
+		}
+		for _, op := range ops {
+			index[op.key] = op.ref
+			liveSize += op.ref.size
+		}
+		size += int64(recordHeaderSize + len(payload))
+		payload = payload[:0]
+		return nil
+	}
+	for key := range c.index {
+		var value []byte
+		if value, err = c.read(key); err != nil {
+			return cleanup(err)
+		}
+		payload = appendOp(payload, opSet, key, value)
+		if len(payload) >= maxCompactionRecordSize {
+			if err = flush(); err != nil {
+				return cleanup(err)
+			}
+		}
+	}
+	if len(payload) > 0 {
+		if err = flush(); err != nil {
+			return cleanup(err)
+		}
+	}
+	if err = tmp.Sync(); err != nil {
+		return cleanup(err)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+
+	// The files are closed before the rename so it also succeeds on Windows.
+	if err = c.file.Close(); err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+	renameErr := os.Rename(tmpPath, c.path)
+	file, err := reopenFile(c.path)
+	if err != nil {
+		// The file is closed and the index may not match the file anymore, the client cannot be used.
+		c.failed = fmt.Errorf("%w: failed to reopen the storage file: %w", errClientFailed, err)
+		c.logger.Error("Failed to reopen the storage file after the compaction", zap.Error(err))
+		return errors.Join(renameErr, c.failed)
+	}
+	c.file = file
+	if renameErr != nil {
+		// Keep using the original file.
+		return errors.Join(renameErr, os.Remove(tmpPath))
+	}
+	if err = syncDir(filepath.Dir(c.path)); err != nil {
+		// The compaction is done, but a machine crash may revert it along with the writes that follow.
+		c.logger.Warn("Failed to sync the storage directory after the compaction", zap.Error(err))
+	}
+	c.logger.Debug("Compacted the storage file", zap.Int64("previous_size", c.size), zap.Int64("size", size))
+	c.index = index
+	c.size = size
+	c.liveSize = liveSize
+	c.dirty = false
+	return nil
+}
+
+func (c *fileClient) syncPeriodically(interval time.Duration) {
+	defer close(c.syncDone)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stopSync:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			if c.dirty && !c.closed && c.failed == nil {
+				if err := c.file.Sync(); err != nil {
+					c.logger.Warn("Failed to sync the storage file", zap.Error(err))
+				}
+				c.dirty = false
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Close flushes the data to the disk and closes the file.
+func (c *fileClient) Close(context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	if c.stopSync != nil {
+		close(c.stopSync)
+		<-c.syncDone
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onClose()
+	if c.failed != nil {
+		// The file was already closed by the failed compaction.
+		return nil
+	}
+	return errors.Join(c.file.Sync(), c.file.Close())
+}
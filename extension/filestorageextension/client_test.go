@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func newTestClient(t *testing.T, path string, cfg *Config) *fileClient {
+	client, err := openFileClient(path, cfg, zap.NewNop(), func() {})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close(context.Background())) })
+	return client
+}
+
+func testConfig(fsync FSyncPolicy) *Config {
+	return &Config{FSync: fsync, FSyncInterval: 10 * time.Millisecond}
+}
+
+func TestFileClient_Batch(t *testing.T) {
+	for _, fsync := range []FSyncPolicy{FSyncAlways, FSyncInterval, FSyncNever} {
+		t.Run(string(fsync), func(t *testing.T) {
+			client := newTestClient(t, filepath.Join(t.TempDir(), "test.log"), testConfig(fsync))
+			ctx := context.Background()
+
+			val, err := client.Get(ctx, "missing")
+			require.NoError(t, err)
+			assert.Nil(t, val)
+
+			require.NoError(t, client.Set(ctx, "a", []byte("1")))
+			getA := storage.GetOperation("a")
+			getB := storage.GetOperation("b")
+			getDeleted := storage.GetOperation("a")
+			// The Get operations see the result of the previous operations of the batch.
+			require.NoError(t, client.Batch(ctx,
+				getA,
+				storage.SetOperation("b", []byte("2")),
+				getB,
+				storage.DeleteOperation("a"),
+				getDeleted,
+			))
+			assert.Equal(t, []byte("1"), getA.Value)
+			assert.Equal(t, []byte("2"), getB.Value)
+			assert.Nil(t, getDeleted.Value)
+
+			val, err = client.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.Nil(t, val)
+			require.NoError(t, client.Delete(ctx, "missing"))
+			require.Error(t, client.Batch(ctx, &storage.Operation{Key: "c", Type: storage.OpType(10)}))
+		})
+	}
+}
+
+func TestFileClient_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	client, err := openFileClient(path, testConfig(FSyncNever), zap.NewNop(), func() {})
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Set(context.Background(), strconv.Itoa(i), []byte("value"+strconv.Itoa(i))))
+	}
+	require.NoError(t, client.Delete(context.Background(), "3"))
+	require.NoError(t, client.Close(context.Background()))
+	require.ErrorIs(t, client.Set(context.Background(), "key", nil), errClientClosed)
+
+	client = newTestClient(t, path, testConfig(FSyncNever))
+	for i := 0; i < 10; i++ {
+		val, err := client.Get(context.Background(), strconv.Itoa(i))
+		require.NoError(t, err)
+		if i == 3 {
+			assert.Nil(t, val)
+			continue
+		}
+		assert.Equal(t, []byte("value"+strconv.Itoa(i)), val)
+	}
+}
+
+func TestFileClient_TruncateUnreadableEnd(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, path string)
+	}{
+		{
+			name: "partial_record",
+			corrupt: func(t *testing.T, path string) {
+				info, err := os.Stat(path)
+				require.NoError(t, err)
+				require.NoError(t, os.Truncate(path, info.Size()-3))
+			},
+		},
+		{
+			name: "invalid_checksum",
+			corrupt: func(t *testing.T, path string) {
+				data, err := os.ReadFile(path)
+				require.NoError(t, err)
+				data[len(data)-1]++
+				require.NoError(t, os.WriteFile(path, data, 0o600))
+			},
+		},
+		{
+			name: "garbage",
+			corrupt: func(t *testing.T, path string) {
+				f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+				require.NoError(t, err)
+				_, err = f.Write([]byte{0xff, 0xff, 0xff, 0x0f, 1, 2})
+				require.NoError(t, err)
+				require.NoError(t, f.Close())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.log")
+			client, err := openFileClient(path, testConfig(FSyncAlways), zap.NewNop(), func() {})
+			require.NoError(t, err)
+			require.NoError(t, client.Set(context.Background(), "a", []byte("1")))
+			require.NoError(t, client.Set(context.Background(), "b", []byte("2")))
+			require.NoError(t, client.Close(context.Background()))
+			tt.corrupt(t, path)
+
+			client = newTestClient(t, path, testConfig(FSyncAlways))
+			val, err := client.Get(context.Background(), "a")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("1"), val)
+			if tt.name != "garbage" {
+				val, err = client.Get(context.Background(), "b")
+				require.NoError(t, err)
+				assert.Nil(t, val)
+			}
+
+			// New records are written after the last valid one.
+			require.NoError(t, client.Set(context.Background(), "c", []byte("3")))
+			require.NoError(t, client.Close(context.Background()))
+			client = newTestClient(t, path, testConfig(FSyncAlways))
+			val, err = client.Get(context.Background(), "c")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("3"), val)
+		})
+	}
+}
+
+func TestFileClient_Compaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	var syncedDirs []string
+	origSyncDir := syncDir
+	syncDir = func(dir string) error {
+		syncedDirs = append(syncedDirs, dir)
+		return origSyncDir(dir)
+	}
+	t.Cleanup(func() { syncDir = origSyncDir })
+	client := newTestClient(t, path, testConfig(FSyncNever))
+	value := bytes.Repeat([]byte{1}, 64<<10)
+	require.NoError(t, client.Set(context.Background(), "kept", []byte("kept")))
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(context.Background(), "overwritten", value))
+	}
+
+	// The obsolete values are dropped once they are larger than the current values.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Less(t, info.Size(), int64(minCompactionSize+2*len(value)))
+	assert.Equal(t, info.Size(), client.size)
+	assert.NoFileExists(t, path+compactionSuffix)
+	// The rename of the compacted file is synced.
+	require.NotEmpty(t, syncedDirs)
+	assert.Equal(t, filepath.Dir(path), syncedDirs[0])
+
+	val, err := client.Get(context.Background(), "kept")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("kept"), val)
+	val, err = client.Get(context.Background(), "overwritten")
+	require.NoError(t, err)
+	assert.Equal(t, value, val)
+}
+
+func TestFileClient_CompactionReopenFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	client := newTestClient(t, path, testConfig(FSyncNever))
+	require.NoError(t, client.Set(context.Background(), "kept", []byte("kept")))
+
+	reopenErr := errors.New("reopen failure")
+	origReopenFile := reopenFile
+	reopenFile = func(string) (*os.File, error) { return nil, reopenErr }
+	t.Cleanup(func() { reopenFile = origReopenFile })
+	client.mu.Lock()
+	require.ErrorIs(t, client.compact(), reopenErr)
+	client.mu.Unlock()
+
+	// The client doesn't use the closed file anymore.
+	_, err := client.Get(context.Background(), "kept")
+	require.ErrorIs(t, err, errClientFailed)
+	require.ErrorIs(t, client.Set(context.Background(), "a", []byte("1")), errClientFailed)
+
+	// The compacted file is complete, it can be opened again.
+	require.NoError(t, client.Close(context.Background()))
+	client = newTestClient(t, path, testConfig(FSyncNever))
+	val, err := client.Get(context.Background(), "kept")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("kept"), val)
+}
+
+func TestFileClient_MaxSize(t *testing.T) {
+	cfg := testConfig(FSyncNever)
+	cfg.MaxSizeMiB = 1
+	client := newTestClient(t, filepath.Join(t.TempDir(), "test.log"), cfg)
+	value := bytes.Repeat([]byte{1}, 400<<10)
+
+	require.NoError(t, client.Set(context.Background(), "a", value))
+	require.NoError(t, client.Set(context.Background(), "b", value))
+	require.ErrorIs(t, client.Set(context.Background(), "c", value), errStorageFull)
+
+	// The space of the obsolete values is reclaimed before rejecting a write.
+	require.NoError(t, client.Delete(context.Background(), "a"))
+	require.NoError(t, client.Set(context.Background(), "c", value))
+	val, err := client.Get(context.Background(), "c")
+	require.NoError(t, err)
+	assert.Equal(t, value, val)
+	assert.LessOrEqual(t, client.size, int64(1<<20))
+}
+
+func TestFileClient_IntervalSync(t *testing.T) {
+	client := newTestClient(t, filepath.Join(t.TempDir(), "test.log"), testConfig(FSyncInterval))
+	require.NoError(t, client.Set(context.Background(), "a", []byte("1")))
+	assert.Eventually(t, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return !client.dirty
+	}, time.Second, 10*time.Millisecond)
+}
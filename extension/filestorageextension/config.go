@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// FSyncPolicy defines when the data written by the clients is flushed to the disk.
+type FSyncPolicy string
+
+const (
+	// FSyncAlways flushes the data to the disk after every write, no acknowledged write is lost on a machine crash.
+	FSyncAlways FSyncPolicy = "always"
+	// FSyncInterval flushes the data to the disk periodically, the writes of the last interval can be lost
+	// on a machine crash.
+	FSyncInterval FSyncPolicy = "interval"
+	// FSyncNever leaves flushing the data to the operating system. Only a clean shutdown flushes the data.
+	FSyncNever FSyncPolicy = "never"
+)
+
+// Validate checks if the FSyncPolicy is valid.
+func (p FSyncPolicy) Validate() error {
+	switch p {
+	case FSyncAlways, FSyncInterval, FSyncNever:
+		return nil
+	}
+	return fmt.Errorf("unsupported fsync policy %q, must be one of %q, %q or %q", p, FSyncAlways, FSyncInterval, FSyncNever)
+}
+
+// Config defines the configuration for the file storage extension.
+type Config struct {
+	// Directory is the directory where the data is stored. Every component gets its own subdirectory.
+	Directory string `mapstructure:"directory"`
+	// FSync defines when the data is flushed to the disk, one of "always", "interval" or "never".
+	FSync FSyncPolicy `mapstructure:"fsync"`
+	// FSyncInterval is the period at which the data is flushed to the disk when FSync is "interval".
+	FSyncInterval time.Duration `mapstructure:"fsync_interval"`
+	// MaxSizeMiB is the maximum size of the file of every storage client in MiB. Writes that would exceed the size
+	// are rejected. Zero means no limit.
+	MaxSizeMiB uint64 `mapstructure:"max_size_mib"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("`directory` must be set")
+	}
+	if err := cfg.FSync.Validate(); err != nil {
+		return fmt.Errorf("`fsync` is invalid: %w", err)
+	}
+	if cfg.FSync == FSyncInterval && cfg.FSyncInterval <= 0 {
+		return errors.New("`fsync_interval` must be positive when `fsync` is \"interval\"")
+	}
+	return nil
+}
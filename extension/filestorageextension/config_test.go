@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Directory:     "/var/lib/otelcol/storage",
+			FSync:         FSyncAlways,
+			FSyncInterval: time.Second,
+			MaxSizeMiB:    512,
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     func() *Config
+		wantErr string
+	}{
+		{
+			name: "default",
+			cfg:  func() *Config { return createDefaultConfig().(*Config) },
+		},
+		{
+			name: "missing_directory",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Directory = ""
+				return cfg
+			},
+			wantErr: "`directory` must be set",
+		},
+		{
+			name: "invalid_fsync",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.FSync = "sometimes"
+				return cfg
+			},
+			wantErr: "`fsync` is invalid: unsupported fsync policy \"sometimes\", must be one of \"always\", \"interval\" or \"never\"",
+		},
+		{
+			name: "zero_fsync_interval",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.FSyncInterval = 0
+				return cfg
+			},
+			wantErr: "`fsync_interval` must be positive when `fsync` is \"interval\"",
+		},
+		{
+			name: "zero_fsync_interval_never",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.FSync = FSyncNever
+				cfg.FSyncInterval = 0
+				return cfg
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg().Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
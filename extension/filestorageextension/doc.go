@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package filestorageextension implements an extension storing the state of the components, like the persistent
+// queue of the exporters, in append-only log files on the local disk.
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+const defaultStorageName = "default"
+
+// fileStorage is a storage extension keeping the data of every client in its own file,
+// under a directory dedicated to the component owning the client.
+type fileStorage struct {
+	component.StartFunc
+	cfg    *Config
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	clients map[string]*fileClient
+}
+
+var _ storage.Extension = (*fileStorage)(nil)
+
+func newFileStorage(cfg *Config, logger *zap.Logger) *fileStorage {
+	return &fileStorage{
+		cfg:     cfg,
+		logger:  logger,
+		clients: map[string]*fileClient{},
+	}
+}
+
+// GetClient returns a client storing the data in the file of the given component and storage name.
+// Only one client can use a file at a time.
+func (fs *fileStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, storageName string) (storage.Client, error) {
+	if storageName == "" {
+		storageName = defaultStorageName
+	}
+	dir := filepath.Join(fs.cfg.Directory, componentDir(kind, id))
+	path := filepath.Join(dir, sanitize(storageName)+".log")
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if _, ok := fs.clients[path]; ok {
+		return nil, fmt.Errorf("storage %q of %s %q is already in use", storageName, kind, id)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the storage directory: %w", err)
+	}
+	client, err := openFileClient(path, fs.cfg, fs.logger.With(zap.String("path", path)), func() {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		delete(fs.clients, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fs.clients[path] = client
+	return client, nil
+}
+
+// Shutdown closes the clients that were not closed by their components.
+func (fs *fileStorage) Shutdown(ctx context.Context) error {
+	fs.mu.Lock()
+	clients := make([]*fileClient, 0, len(fs.clients))
+	for _, client := range fs.clients {
+		clients = append(clients, client)
+	}
+	fs.mu.Unlock()
+
+	var errs error
+	for _, client := range clients {
+		errs = errors.Join(errs, client.Close(ctx))
+	}
+	return errs
+}
+
+// componentDir returns the name of the directory of the component. The kind, type and name are separated by "_",
+// which is escaped within them so that different components never share a directory.
+func componentDir(kind component.Kind, id component.ID) string {
+	parts := []string{strings.ToLower(kind.String()), id.Type().String(), id.Name()}
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(sanitize(part), "_", "~005F")
+	}
+	return strings.Join(parts, "_")
+}
+
+// sanitize escapes the characters that are not safe in a file name.
+func sanitize(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			sb.WriteRune(r)
+		default:
+			fmt.Fprintf(&sb, "~%04X", r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newTestFileStorage(t *testing.T) *fileStorage {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	fs := newFileStorage(cfg, zap.NewNop())
+	require.NoError(t, fs.Start(context.Background(), componenttest.NewNopHost()))
+	return fs
+}
+
+func TestFileStorage_GetClient(t *testing.T) {
+	fs := newTestFileStorage(t)
+	id := component.MustNewIDWithName("otlp", "backend")
+
+	client, err := fs.GetClient(context.Background(), component.KindExporter, id, "traces")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(fs.cfg.Directory, "exporter_otlp_backend", "traces.log"))
+	require.NoError(t, client.Set(context.Background(), "key", []byte("value")))
+
+	// A file can only be used by one client at a time.
+	_, err = fs.GetClient(context.Background(), component.KindExporter, id, "traces")
+	require.EqualError(t, err, `storage "traces" of Exporter "otlp/backend" is already in use`)
+
+	// Other storage names and components use their own files.
+	other, err := fs.GetClient(context.Background(), component.KindExporter, id, "")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(fs.cfg.Directory, "exporter_otlp_backend", "default.log"))
+	val, err := other.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+	require.NoError(t, other.Close(context.Background()))
+
+	require.NoError(t, client.Close(context.Background()))
+	client, err = fs.GetClient(context.Background(), component.KindExporter, id, "traces")
+	require.NoError(t, err)
+	val, err = client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+
+	// Shutdown closes the clients left open by the components.
+	require.NoError(t, fs.Shutdown(context.Background()))
+	require.ErrorIs(t, client.Set(context.Background(), "key", nil), errClientClosed)
+	assert.Empty(t, fs.clients)
+}
+
+func TestComponentDir(t *testing.T) {
+	assert.Equal(t, "exporter_otlp_backend", componentDir(component.KindExporter, component.MustNewIDWithName("otlp", "backend")))
+	// The separator is escaped in the type and name, the components do not share a directory.
+	assert.Equal(t, "exporter_otlp_a~005Fb", componentDir(component.KindExporter, component.MustNewIDWithName("otlp", "a_b")))
+	assert.Equal(t, "exporter_otlp~005Fa_b", componentDir(component.KindExporter, component.MustNewIDWithName("otlp_a", "b")))
+	assert.Equal(t, "receiver_otlp_", componentDir(component.KindReceiver, component.MustNewID("otlp")))
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "exporter_otlp_backend-1", sanitize("exporter_otlp_backend-1"))
+	assert.Equal(t, "~002E~002E~002Ftraces", sanitize("../traces"))
+	assert.Equal(t, "~00E9t~00E9", sanitize("été"))
+}
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/memorylimiterextension/internal/metadata"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
 // NewFactory returns a new factory for the Memory Limiter extension.
@@ -29,5 +30,9 @@ func createDefaultConfig() component.Config {
 }
 
 func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
-	return newMemoryLimiter(cfg.(*Config), set.TelemetrySettings.Logger)
+	mlCfg := cfg.(*Config)
+	if mlCfg.Mode == memorylimiter.ModeInFlightBytes {
+		return newBytesLimiter(mlCfg, set.TelemetrySettings.Logger)
+	}
+	return newMemoryLimiter(mlCfg, set.TelemetrySettings.Logger)
 }
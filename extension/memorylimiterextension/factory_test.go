@@ -13,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/xextension/limiter"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
@@ -48,3 +49,26 @@ func TestCreate(t *testing.T) {
 	// verify that no monitoring routine is running
 	assert.ErrorIs(t, tp.Shutdown(context.Background()), memorylimiter.ErrShutdownNotStarted)
 }
+
+func TestCreateInFlightBytes(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Mode = memorylimiter.ModeInFlightBytes
+	cfg.MemoryLimitMiB = 1
+	cfg.WaitTimeout = time.Second
+	require.NoError(t, cfg.Validate())
+
+	ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	bl, ok := ext.(limiter.BytesLimiter)
+	require.True(t, ok)
+	assert.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	release, err := bl.Acquire(context.Background(), 1024)
+	require.NoError(t, err)
+	release()
+	_, err = bl.Acquire(context.Background(), 2<<20)
+	require.ErrorIs(t, err, memorylimiter.ErrDataRefused)
+
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
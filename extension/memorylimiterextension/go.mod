@@ -9,6 +9,7 @@ require (
 	go.opentelemetry.io/collector/confmap v1.23.0
 	go.opentelemetry.io/collector/extension v0.117.0
 	go.opentelemetry.io/collector/extension/extensiontest v0.117.0
+	go.opentelemetry.io/collector/extension/xextension v0.117.0
 	go.opentelemetry.io/collector/internal/memorylimiter v0.117.0
 	go.uber.org/goleak v1.3.0
 	go.uber.org/zap v1.27.0
@@ -68,3 +69,5 @@ replace go.opentelemetry.io/collector/config/configtelemetry => ../../config/con
 replace go.opentelemetry.io/collector/internal/memorylimiter => ../../internal/memorylimiter
 
 replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
+
+replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
@@ -9,9 +9,15 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/limiter"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
+var (
+	_ limiter.MemoryLimiter = (*memoryLimiterExtension)(nil)
+	_ limiter.BytesLimiter  = (*bytesLimiterExtension)(nil)
+)
+
 type memoryLimiterExtension struct {
 	memLimiter *memorylimiter.MemoryLimiter
 }
@@ -38,3 +44,25 @@ func (ml *memoryLimiterExtension) Shutdown(ctx context.Context) error {
 func (ml *memoryLimiterExtension) MustRefuse() bool {
 	return ml.memLimiter.MustRefuse()
 }
+
+type bytesLimiterExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	bytesLimiter *memorylimiter.BytesLimiter
+}
+
+// newBytesLimiter returns a new memorylimiter extension in the in-flight bytes mode.
+func newBytesLimiter(cfg *Config, logger *zap.Logger) (*bytesLimiterExtension, error) {
+	bl, err := memorylimiter.NewBytesLimiter(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bytesLimiterExtension{bytesLimiter: bl}, nil
+}
+
+// Acquire waits until n bytes can be admitted, and returns the function releasing them.
+func (bl *bytesLimiterExtension) Acquire(ctx context.Context, n int64) (func(), error) {
+	return bl.bytesLimiter.Acquire(ctx, n)
+}
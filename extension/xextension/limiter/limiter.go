@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package limiter defines the interfaces of the extensions limiting the data received by the collector.
+package limiter // import "go.opentelemetry.io/collector/extension/xextension/limiter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/extension"
+)
+
+// MemoryLimiter is an extension refusing the incoming data once the memory usage of the collector reaches its limit.
+// Receivers consult it before reading the incoming requests.
+type MemoryLimiter interface {
+	extension.Extension
+
+	// MustRefuse returns true if the incoming data must be refused.
+	MustRefuse() bool
+}
+
+// BytesLimiter is an extension limiting the size of the requests being processed by the collector.
+// Receivers acquire the size of every request before processing it, and release it once the pipeline returns.
+type BytesLimiter interface {
+	extension.Extension
+
+	// Acquire waits until n bytes can be admitted, and returns the function releasing them.
+	// It returns an error if the bytes cannot be admitted, for instance when the wait times out.
+	Acquire(ctx context.Context, n int64) (release func(), err error)
+}
@@ -1,5 +1,6 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-// Package filter provides an interface for matching strings against a set of string filters.
+// Package filter provides an interface for matching strings against a set of string filters,
+// and expressions matching attributes.
 package filter // import "go.opentelemetry.io/collector/filter"
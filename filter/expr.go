@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "go.opentelemetry.io/collector/filter"
+
+import (
+	"encoding"
+	"math"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Expression is a compiled filter expression matching attributes, for instance the attributes of
+// a resource, a span, a log record or a data point. Expressions combine conditions on the attributes:
+//
+//	http.response.status_code >= 500 and not exists(error.type)
+//	service.name in ["checkout", "cart"] or url.path glob "/health*"
+//
+// The supported conditions are:
+//   - key == value, key != value: the attribute is, or is not, equal to the value.
+//   - key < number, key <= number, key > number, key >= number: the attribute is a number compared to the number.
+//   - key in [value, ...]: the attribute is equal to one of the values.
+//   - key glob "pattern": the attribute is a string matching the pattern, where "*" matches any sequence
+//     of characters, "?" matches any character, and "\" escapes the next character.
+//   - exists(key): the attribute is set.
+//
+// The values are double quoted strings, numbers, true or false. A string is only equal to a string attribute,
+// a boolean to a boolean attribute, and a number to an integer or double attribute with the same numeric value.
+// The conditions on an unset attribute are false, except key != value, which is the negation of key == value.
+// The conditions are combined with "and", "or", "not" and parentheses, "not" binding the tightest and "or" the
+// loosest. The keys containing other characters than letters, digits, "_", ".", "-", "/" and ":" are quoted
+// with backticks.
+//
+// The zero Expression matches all the attributes.
+type Expression struct {
+	src  string
+	root node
+}
+
+var (
+	_ encoding.TextUnmarshaler = (*Expression)(nil)
+	_ encoding.TextMarshaler   = Expression{}
+)
+
+// CompileExpression compiles the given source into an Expression, returning an error if it is invalid.
+func CompileExpression(src string) (Expression, error) {
+	root, err := parse(src)
+	if err != nil {
+		return Expression{}, err
+	}
+	return Expression{src: src, root: root}, nil
+}
+
+// MustCompileExpression is like CompileExpression but panics if the source is invalid.
+func MustCompileExpression(src string) Expression {
+	expr, err := CompileExpression(src)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// Matches returns true if the given attributes match the expression.
+func (e Expression) Matches(attrs pcommon.Map) bool {
+	if e.root == nil {
+		return true
+	}
+	return e.root.matches(attrs)
+}
+
+// String returns the source of the expression.
+func (e Expression) String() string {
+	return e.src
+}
+
+// UnmarshalText compiles the expression, so that invalid expressions are reported when loading the configuration.
+func (e *Expression) UnmarshalText(text []byte) error {
+	expr, err := CompileExpression(string(text))
+	if err != nil {
+		return err
+	}
+	*e = expr
+	return nil
+}
+
+// MarshalText returns the source of the expression.
+func (e Expression) MarshalText() ([]byte, error) {
+	return []byte(e.src), nil
+}
+
+type node interface {
+	matches(attrs pcommon.Map) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) matches(attrs pcommon.Map) bool {
+	return n.left.matches(attrs) && n.right.matches(attrs)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) matches(attrs pcommon.Map) bool {
+	return n.left.matches(attrs) || n.right.matches(attrs)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) matches(attrs pcommon.Map) bool {
+	return !n.operand.matches(attrs)
+}
+
+type existsNode struct {
+	key string
+}
+
+func (n existsNode) matches(attrs pcommon.Map) bool {
+	_, ok := attrs.Get(n.key)
+	return ok
+}
+
+type compareNode struct {
+	key   string
+	op    string
+	value literal
+}
+
+func (n compareNode) matches(attrs pcommon.Map) bool {
+	v, ok := attrs.Get(n.key)
+	switch n.op {
+	case "==":
+		return ok && n.value.equal(v)
+	case "!=":
+		return !ok || !n.value.equal(v)
+	}
+	if !ok {
+		return false
+	}
+	c, ok := n.value.compare(v)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+type inNode struct {
+	key    string
+	values []literal
+}
+
+func (n inNode) matches(attrs pcommon.Map) bool {
+	v, ok := attrs.Get(n.key)
+	if !ok {
+		return false
+	}
+	for _, value := range n.values {
+		if value.equal(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type globNode struct {
+	key string
+	re  *regexp.Regexp
+}
+
+func (n globNode) matches(attrs pcommon.Map) bool {
+	v, ok := attrs.Get(n.key)
+	return ok && v.Type() == pcommon.ValueTypeStr && n.re.MatchString(v.Str())
+}
+
+type literalKind int
+
+const (
+	literalString literalKind = iota
+	literalBool
+	literalInt
+	literalDouble
+)
+
+// literal is a value of an expression.
+type literal struct {
+	kind literalKind
+	str  string
+	b    bool
+	i    int64
+	d    float64
+}
+
+func (l literal) equal(v pcommon.Value) bool {
+	switch l.kind {
+	case literalString:
+		return v.Type() == pcommon.ValueTypeStr && v.Str() == l.str
+	case literalBool:
+		return v.Type() == pcommon.ValueTypeBool && v.Bool() == l.b
+	}
+	c, ok := l.compare(v)
+	return ok && c == 0
+}
+
+// compare returns the comparison of the numeric value with the literal, and false if either is not a number.
+func (l literal) compare(v pcommon.Value) (int, bool) {
+	if l.kind == literalInt && v.Type() == pcommon.ValueTypeInt {
+		return compareNumbers(v.Int(), l.i), true
+	}
+	var lf, vf float64
+	switch l.kind {
+	case literalInt:
+		lf = float64(l.i)
+	case literalDouble:
+		lf = l.d
+	default:
+		return 0, false
+	}
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		vf = float64(v.Int())
+	case pcommon.ValueTypeDouble:
+		vf = v.Double()
+		if math.IsNaN(vf) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return compareNumbers(vf, lf), true
+}
+
+func compareNumbers[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,357 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "go.opentelemetry.io/collector/filter"
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedKey
+	tokenString
+	tokenNumber
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	// text is the text of the token, unquoted for the strings and the quoted keys.
+	text string
+	// pos is the byte offset of the token in the source.
+	pos int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	case tokenQuotedKey:
+		return "`" + t.text + "`"
+	}
+	return strconv.Quote(t.text)
+}
+
+// isKeyRune returns true if the rune can be part of an unquoted key.
+func isKeyRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-/:", r)
+}
+
+// tokenize splits the source of an expression into tokens.
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(src); {
+		r, size := utf8.DecodeRuneInString(src[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r == '"':
+			end := pos + 1
+			for ; end < len(src) && src[end] != '"'; end++ {
+				if src[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", pos)
+			}
+			str, err := strconv.Unquote(src[pos : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %w", pos, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: str, pos: pos})
+			pos = end + 1
+		case r == '`':
+			end := strings.IndexByte(src[pos+1:], '`')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted key at offset %d", pos)
+			}
+			tokens = append(tokens, token{kind: tokenQuotedKey, text: src[pos+1 : pos+1+end], pos: pos})
+			pos += end + 2
+		case r == '-' || r == '+' || unicode.IsDigit(r):
+			end := pos + 1
+			for end < len(src) && strings.IndexByte("0123456789abcdefABCDEFxX._+-", src[end]) >= 0 {
+				// A sign is only part of a number after an exponent.
+				if (src[end] == '+' || src[end] == '-') && src[end-1] != 'e' && src[end-1] != 'E' {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[pos:end], pos: pos})
+			pos = end
+		case unicode.IsLetter(r) || r == '_':
+			end := pos
+			for end < len(src) {
+				r, size := utf8.DecodeRuneInString(src[end:])
+				if !isKeyRune(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[pos:end], pos: pos})
+			pos = end
+		default:
+			punct := string(r)
+			if pos+1 < len(src) && strings.Contains("=!<>", punct) && src[pos+1] == '=' {
+				punct += "="
+			}
+			switch punct {
+			case "(", ")", "[", "]", ",", "==", "!=", "<", "<=", ">", ">=":
+			default:
+				return nil, fmt.Errorf("unexpected %q at offset %d", punct, pos)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: punct, pos: pos})
+			pos += len(punct)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// parser is a recursive descent parser of the expressions:
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | primary
+//	primary    = "(" or ")" | "exists" "(" key ")" | key condition
+//	condition  = ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) value | "in" "[" [ value { "," value } ] "]" | "glob" string
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(src string) (node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression %q: %w", src, err)
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = p.unexpected()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression %q: %w", src, err)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the given keyword or punctuation.
+func (p *parser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokenIdent || t.kind == tokenPunct) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return fmt.Errorf("expected %q, found %v at offset %d", text, p.peek(), p.peek().pos)
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	return fmt.Errorf("unexpected %v at offset %d", p.peek(), p.peek().pos)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.accept("(") {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(")")
+	}
+	if t := p.peek(); t.kind == tokenIdent && t.text == "exists" && p.tokens[p.pos+1].text == "(" {
+		p.pos += 2
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		return existsNode{key: key}, p.expect(")")
+	}
+	key, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseCondition(key)
+}
+
+func (p *parser) parseKey() (string, error) {
+	t := p.peek()
+	if t.kind != tokenIdent && t.kind != tokenQuotedKey {
+		return "", fmt.Errorf("expected an attribute key, found %v at offset %d", t, t.pos)
+	}
+	if t.kind == tokenIdent {
+		switch t.text {
+		case "and", "or", "not", "in", "glob", "true", "false":
+			return "", fmt.Errorf("expected an attribute key, found the keyword %q at offset %d, quote the key with backticks", t.text, t.pos)
+		}
+	}
+	p.pos++
+	return t.text, nil
+}
+
+func (p *parser) parseCondition(key string) (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenPunct && (t.text == "==" || t.text == "!="):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{key: key, op: t.text, value: value}, nil
+	case t.kind == tokenPunct && (t.text == "<" || t.text == "<=" || t.text == ">" || t.text == ">="):
+		valueToken := p.peek()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if value.kind != literalInt && value.kind != literalDouble {
+			return nil, fmt.Errorf("%q expects a number, found %v at offset %d", t.text, valueToken, valueToken.pos)
+		}
+		return compareNode{key: key, op: t.text, value: value}, nil
+	case t.kind == tokenIdent && t.text == "in":
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+		var values []literal
+		for !p.accept("]") {
+			if len(values) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return inNode{key: key, values: values}, nil
+	case t.kind == tokenIdent && t.text == "glob":
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, fmt.Errorf("\"glob\" expects a string, found %v at offset %d", pattern, pattern.pos)
+		}
+		return globNode{key: key, re: compileGlob(pattern.text)}, nil
+	}
+	return nil, fmt.Errorf("expected a condition on %q, found %v at offset %d", key, t, t.pos)
+}
+
+func (p *parser) parseValue() (literal, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return literal{kind: literalString, str: t.text}, nil
+	case tokenNumber:
+		if i, err := strconv.ParseInt(t.text, 0, 64); err == nil {
+			return literal{kind: literalInt, i: i}, nil
+		}
+		d, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return literal{}, fmt.Errorf("invalid number %q at offset %d", t.text, t.pos)
+		}
+		return literal{kind: literalDouble, d: d}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return literal{kind: literalBool, b: true}, nil
+		case "false":
+			return literal{kind: literalBool, b: false}, nil
+		}
+	}
+	return literal{}, fmt.Errorf("expected a value, found %v at offset %d", t, t.pos)
+}
+
+// compileGlob compiles a glob pattern into a regular expression matching the whole string.
+func compileGlob(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString(`^(?s:`)
+	for i := 0; i < len(pattern); {
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		i += size
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		case '\\':
+			if i < len(pattern) {
+				r, size = utf8.DecodeRuneInString(pattern[i:])
+				i += size
+			}
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString(`)$`)
+	return regexp.MustCompile(sb.String())
+}
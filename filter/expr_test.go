@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func testAttributes() pcommon.Map {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("service.name", "checkout")
+	attrs.PutStr("url.path", "/health/live")
+	attrs.PutInt("http.response.status_code", 503)
+	attrs.PutDouble("duration", 1.5)
+	attrs.PutDouble("nan", math.NaN())
+	attrs.PutBool("sampled", true)
+	attrs.PutStr("key with spaces", "value")
+	attrs.PutStr("and", "keyword")
+	attrs.PutEmptySlice("slice").AppendEmpty().SetStr("a")
+	return attrs
+}
+
+func TestExpressionMatches(t *testing.T) {
+	tests := []struct {
+		expr    string
+		matches bool
+	}{
+		{expr: `service.name == "checkout"`, matches: true},
+		{expr: `service.name == "cart"`, matches: false},
+		{expr: `service.name != "cart"`, matches: true},
+		{expr: `missing != "cart"`, matches: true},
+		{expr: `missing == "cart"`, matches: false},
+		{expr: `http.response.status_code == 503`, matches: true},
+		{expr: `http.response.status_code == 503.0`, matches: true},
+		{expr: `http.response.status_code == "503"`, matches: false},
+		{expr: `http.response.status_code >= 500`, matches: true},
+		{expr: `http.response.status_code > 503`, matches: false},
+		{expr: `http.response.status_code < 0x200`, matches: true},
+		{expr: `http.response.status_code <= 503`, matches: true},
+		{expr: `duration > 1`, matches: true},
+		{expr: `duration < 1.5e0`, matches: false},
+		{expr: `duration == 1.5`, matches: true},
+		{expr: `duration > -2`, matches: true},
+		{expr: `nan == 0`, matches: false},
+		{expr: `nan < 1`, matches: false},
+		{expr: `service.name > 1`, matches: false},
+		{expr: `missing > 1`, matches: false},
+		{expr: `sampled == true`, matches: true},
+		{expr: `sampled == false`, matches: false},
+		{expr: `sampled == 1`, matches: false},
+		{expr: `service.name in ["cart", "checkout"]`, matches: true},
+		{expr: `service.name in ["cart"]`, matches: false},
+		{expr: `service.name in []`, matches: false},
+		{expr: `http.response.status_code in [500, 503]`, matches: true},
+		{expr: `missing in ["cart"]`, matches: false},
+		{expr: `url.path glob "/health*"`, matches: true},
+		{expr: `url.path glob "/health/????"`, matches: true},
+		{expr: `url.path glob "/health"`, matches: false},
+		{expr: `url.path glob "/health/l\\*"`, matches: false},
+		{expr: `http.response.status_code glob "5*"`, matches: false},
+		{expr: `exists(service.name)`, matches: true},
+		{expr: `exists(missing)`, matches: false},
+		{expr: "exists(`key with spaces`)", matches: true},
+		{expr: "`key with spaces` == \"value\"", matches: true},
+		{expr: "`and` == \"keyword\"", matches: true},
+		{expr: `exists(slice)`, matches: true},
+		{expr: `slice == "a"`, matches: false},
+		{expr: `not exists(missing)`, matches: true},
+		{expr: `not not exists(missing)`, matches: false},
+		{expr: `exists(service.name) and exists(missing)`, matches: false},
+		{expr: `exists(service.name) or exists(missing)`, matches: true},
+		{expr: `exists(missing) or exists(service.name) and sampled == false`, matches: false},
+		{expr: `(exists(missing) or exists(service.name)) and sampled == true`, matches: true},
+		{expr: `not exists(missing) and not (sampled == false)`, matches: true},
+		{expr: "http.response.status_code >= 500\n\tand not url.path glob \"/health*\"", matches: false},
+	}
+	attrs := testAttributes()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := CompileExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, expr.Matches(attrs))
+			assert.Equal(t, tt.expr, expr.String())
+		})
+	}
+}
+
+func TestExpressionInvalid(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{expr: ``, err: `expected an attribute key, found end of expression at offset 0`},
+		{expr: `service.name`, err: `expected a condition on "service.name", found end of expression at offset 12`},
+		{expr: `service.name = "a"`, err: `unexpected "=" at offset 13`},
+		{expr: `service.name == `, err: `expected a value, found end of expression at offset 16`},
+		{expr: `service.name == checkout`, err: `expected a value, found "checkout" at offset 16`},
+		{expr: `service.name == "checkout`, err: `unterminated string at offset 16`},
+		{expr: `service.name == "\q"`, err: `invalid string at offset 16: invalid syntax`},
+		{expr: "`service.name == 1", err: "unterminated quoted key at offset 0"},
+		{expr: `service.name > "a"`, err: `">" expects a number, found "a" at offset 15`},
+		{expr: `service.name <= true`, err: `"<=" expects a number, found "true" at offset 16`},
+		{expr: `duration == 1.2.3`, err: `invalid number "1.2.3" at offset 12`},
+		{expr: `url.path glob 1`, err: `"glob" expects a string, found "1" at offset 14`},
+		{expr: `service.name in "a"`, err: `expected "[", found "a" at offset 16`},
+		{expr: `service.name in ["a" "b"]`, err: `expected ",", found "b" at offset 21`},
+		{expr: `exists(service.name`, err: `expected ")", found end of expression at offset 19`},
+		{expr: `(exists(a)`, err: `expected ")", found end of expression at offset 10`},
+		{expr: `exists(a) exists(b)`, err: `unexpected "exists" at offset 10`},
+		{expr: `exists(a) and`, err: `expected an attribute key, found end of expression at offset 13`},
+		{expr: `and == 1`, err: `expected an attribute key, found the keyword "and" at offset 0, quote the key with backticks`},
+		{expr: `a == 1 && b == 2`, err: `unexpected "&" at offset 7`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := CompileExpression(tt.expr)
+			assert.EqualError(t, err, fmt.Sprintf("invalid filter expression %q: %s", tt.expr, tt.err))
+		})
+	}
+	assert.Panics(t, func() { MustCompileExpression(`a ==`) })
+}
+
+func TestExpressionZero(t *testing.T) {
+	assert.True(t, Expression{}.Matches(pcommon.NewMap()))
+}
+
+func TestExpressionConfig(t *testing.T) {
+	type config struct {
+		Expressions []Expression `mapstructure:"expressions"`
+	}
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_expressions.yaml"))
+	require.NoError(t, err)
+	var cfg config
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t, config{Expressions: []Expression{
+		MustCompileExpression(`http.response.status_code >= 500`),
+		MustCompileExpression(`service.name in ["checkout", "cart"] and not url.path glob "/health*"`),
+	}}, cfg)
+
+	conf := confmap.New()
+	require.NoError(t, conf.Marshal(cfg))
+	var unmarshaled config
+	require.NoError(t, conf.Unmarshal(&unmarshaled))
+	assert.Equal(t, cfg, unmarshaled)
+
+	cm = confmap.NewFromStringMap(map[string]any{"expressions": []any{"a =="}})
+	assert.ErrorContains(t, cm.Unmarshal(&cfg), `invalid filter expression "a ==": expected a value`)
+}
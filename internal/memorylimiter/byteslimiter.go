@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter // import "go.opentelemetry.io/collector/internal/memorylimiter"
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BytesLimiter limits the size of the requests being processed. The requests acquire their size before being
+// processed, and wait in a queue, in order of arrival, until enough bytes are released or the wait times out.
+type BytesLimiter struct {
+	limit       int64
+	waitTimeout time.Duration
+
+	mu       sync.Mutex
+	inFlight int64
+	// waiters holds the *bytesWaiter of the requests waiting to be admitted.
+	waiters list.List
+}
+
+type bytesWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// NewBytesLimiter returns a new in-flight bytes limiter.
+func NewBytesLimiter(cfg *Config, logger *zap.Logger) (*BytesLimiter, error) {
+	if cfg.Mode != ModeInFlightBytes {
+		return nil, fmt.Errorf("'mode' %q is not supported by the in-flight bytes limiter", string(cfg.Mode))
+	}
+	usageChecker, err := getMemUsageChecker(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("In-flight bytes limiter configured",
+		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
+		zap.Duration("wait_timeout", cfg.WaitTimeout))
+
+	return &BytesLimiter{
+		limit:       int64(usageChecker.memAllocLimit),
+		waitTimeout: cfg.WaitTimeout,
+	}, nil
+}
+
+// Acquire waits until n bytes can be admitted, and returns the function releasing them once the request is processed.
+// It returns an error wrapping ErrDataRefused if the bytes are not admitted before the wait timeout,
+// or the error of the context if it is done before.
+func (bl *BytesLimiter) Acquire(ctx context.Context, n int64) (func(), error) {
+	if n > bl.limit {
+		return nil, fmt.Errorf("%w: the request of %d bytes is larger than the limit of %d bytes", ErrDataRefused, n, bl.limit)
+	}
+
+	bl.mu.Lock()
+	if bl.waiters.Len() == 0 && bl.inFlight+n <= bl.limit {
+		bl.inFlight += n
+		bl.mu.Unlock()
+		return bl.releaseFunc(n), nil
+	}
+	if bl.waitTimeout == 0 {
+		bl.mu.Unlock()
+		return nil, fmt.Errorf("%w: the in-flight bytes limit is reached", ErrDataRefused)
+	}
+	w := &bytesWaiter{n: n, ready: make(chan struct{})}
+	elem := bl.waiters.PushBack(w)
+	bl.mu.Unlock()
+
+	timer := time.NewTimer(bl.waitTimeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-w.ready:
+		return bl.releaseFunc(n), nil
+	case <-timer.C:
+		err = fmt.Errorf("%w: timed out waiting for the in-flight bytes to be released", ErrDataRefused)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	select {
+	case <-w.ready:
+		// The bytes were admitted while giving up, keep them.
+		return bl.releaseFunc(n), nil
+	default:
+	}
+	bl.waiters.Remove(elem)
+	// The next requests may fit now that this one does not wait in front of them.
+	bl.admitWaitersLocked()
+	return nil, err
+}
+
+// InFlightBytes returns the size of the requests being processed.
+func (bl *BytesLimiter) InFlightBytes() int64 {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	return bl.inFlight
+}
+
+func (bl *BytesLimiter) releaseFunc(n int64) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			bl.mu.Lock()
+			defer bl.mu.Unlock()
+			bl.inFlight -= n
+			bl.admitWaitersLocked()
+		})
+	}
+}
+
+// admitWaitersLocked admits the waiting requests, in order of arrival, while they fit in the limit.
+func (bl *BytesLimiter) admitWaitersLocked() {
+	for elem := bl.waiters.Front(); elem != nil; elem = bl.waiters.Front() {
+		w := elem.Value.(*bytesWaiter)
+		if bl.inFlight+w.n > bl.limit {
+			return
+		}
+		bl.inFlight += w.n
+		bl.waiters.Remove(elem)
+		close(w.ready)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestBytesLimiter(t *testing.T, waitTimeout time.Duration) *BytesLimiter {
+	bl, err := NewBytesLimiter(&Config{Mode: ModeInFlightBytes, MemoryLimitMiB: 1, WaitTimeout: waitTimeout}, zap.NewNop())
+	require.NoError(t, err)
+	return bl
+}
+
+func TestNewBytesLimiterMode(t *testing.T) {
+	_, err := NewBytesLimiter(&Config{MemoryLimitMiB: 1}, zap.NewNop())
+	require.EqualError(t, err, `'mode' "" is not supported by the in-flight bytes limiter`)
+	_, err = NewMemoryLimiter(&Config{Mode: ModeInFlightBytes, MemoryLimitMiB: 1}, zap.NewNop())
+	require.EqualError(t, err, `'mode' "in_flight_bytes" is not supported by the memory usage limiter`)
+}
+
+func TestBytesLimiterNoWait(t *testing.T) {
+	bl := newTestBytesLimiter(t, 0)
+
+	release, err := bl.Acquire(context.Background(), mibBytes/2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(mibBytes/2), bl.InFlightBytes())
+
+	_, err = bl.Acquire(context.Background(), mibBytes)
+	require.ErrorIs(t, err, ErrDataRefused)
+
+	release()
+	// Releasing twice has no effect.
+	release()
+	assert.Equal(t, int64(0), bl.InFlightBytes())
+
+	release, err = bl.Acquire(context.Background(), mibBytes)
+	require.NoError(t, err)
+	release()
+
+	_, err = bl.Acquire(context.Background(), mibBytes+1)
+	require.ErrorIs(t, err, ErrDataRefused)
+	assert.Equal(t, int64(0), bl.InFlightBytes())
+}
+
+func TestBytesLimiterWait(t *testing.T) {
+	bl := newTestBytesLimiter(t, time.Minute)
+
+	release, err := bl.Acquire(context.Background(), mibBytes)
+	require.NoError(t, err)
+
+	admitted := make(chan func())
+	for i := 0; i < 2; i++ {
+		go func() {
+			r, acquireErr := bl.Acquire(context.Background(), mibBytes/2)
+			assert.NoError(t, acquireErr)
+			admitted <- r
+		}()
+	}
+	assert.Eventually(t, func() bool {
+		bl.mu.Lock()
+		defer bl.mu.Unlock()
+		return bl.waiters.Len() == 2
+	}, time.Second, time.Millisecond)
+
+	release()
+	release1, release2 := <-admitted, <-admitted
+	assert.Equal(t, int64(mibBytes), bl.InFlightBytes())
+	release1()
+	release2()
+	assert.Equal(t, int64(0), bl.InFlightBytes())
+}
+
+func TestBytesLimiterWaitInOrder(t *testing.T) {
+	bl := newTestBytesLimiter(t, time.Minute)
+
+	release, err := bl.Acquire(context.Background(), mibBytes/2)
+	require.NoError(t, err)
+
+	// The large request waits for the first one to be released, and the small request waits behind it,
+	// even though it would fit.
+	largeAdmitted := make(chan func())
+	go func() {
+		r, acquireErr := bl.Acquire(context.Background(), mibBytes)
+		assert.NoError(t, acquireErr)
+		largeAdmitted <- r
+	}()
+	assert.Eventually(t, func() bool {
+		bl.mu.Lock()
+		defer bl.mu.Unlock()
+		return bl.waiters.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = bl.Acquire(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	(<-largeAdmitted)()
+	assert.Equal(t, int64(0), bl.InFlightBytes())
+}
+
+func TestBytesLimiterWaitTimeout(t *testing.T) {
+	bl := newTestBytesLimiter(t, 10*time.Millisecond)
+
+	release, err := bl.Acquire(context.Background(), mibBytes)
+	require.NoError(t, err)
+	_, err = bl.Acquire(context.Background(), 1)
+	require.ErrorIs(t, err, ErrDataRefused)
+
+	bl.mu.Lock()
+	assert.Equal(t, 0, bl.waiters.Len())
+	bl.mu.Unlock()
+	release()
+	assert.Equal(t, int64(0), bl.InFlightBytes())
+}
@@ -5,6 +5,7 @@ package memorylimiter // import "go.opentelemetry.io/collector/internal/memoryli
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -17,17 +18,43 @@ var (
 	errSpikeLimitPercentageOutOfRange = errors.New("'spike_limit_percentage' must be smaller than 'limit_percentage'")
 	errLimitPercentageOutOfRange      = errors.New(
 		"'limit_percentage' and 'spike_limit_percentage' must be greater than zero and less than or equal to hundred")
+	errWaitTimeoutOutOfRange = errors.New("'wait_timeout' must be greater than or equal to zero")
+	errSetGoMemoryLimitMode  = errors.New("'set_go_memory_limit' is not supported in the \"in_flight_bytes\" mode")
 )
 
+// Mode is the strategy used to limit the memory.
+type Mode string
+
+const (
+	// ModeMemoryUsage samples the memory usage of the process every check interval, and refuses the data
+	// while it is above the soft limit. It is the default mode.
+	ModeMemoryUsage Mode = "memory_usage"
+	// ModeInFlightBytes tracks the size of the requests being processed, and makes the new requests wait
+	// until it is below the limit.
+	ModeInFlightBytes Mode = "in_flight_bytes"
+)
+
+// Validate checks if the mode is supported.
+func (m Mode) Validate() error {
+	switch m {
+	case "", ModeMemoryUsage, ModeInFlightBytes:
+		return nil
+	}
+	return fmt.Errorf("'mode' %q is not supported", string(m))
+}
+
 // Config defines configuration for memory memoryLimiter processor.
 type Config struct {
+	// Mode is the strategy used to limit the memory, either "memory_usage" (default) or "in_flight_bytes".
+	Mode Mode `mapstructure:"mode,omitempty"`
+
 	// CheckInterval is the time between measurements of memory usage for the
 	// purposes of avoiding going over the limits. Defaults to zero, so no
 	// checks will be performed.
 	CheckInterval time.Duration `mapstructure:"check_interval"`
 
 	// MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be
-	// allocated by the process.
+	// allocated by the process. In the "in_flight_bytes" mode, it is the maximum size of the requests being processed.
 	MemoryLimitMiB uint32 `mapstructure:"limit_mib"`
 
 	// MemorySpikeLimitMiB is the maximum, in MiB, spike expected between the
@@ -36,18 +63,38 @@ type Config struct {
 
 	// MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be
 	// allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.
+	// In the "in_flight_bytes" mode, it is the maximum size of the requests being processed, in % of the total memory.
 	MemoryLimitPercentage uint32 `mapstructure:"limit_percentage"`
 
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// WaitTimeout is the maximum time a request waits for the in-flight bytes to be released in the
+	// "in_flight_bytes" mode, before being refused. Defaults to zero, so the requests are refused without waiting.
+	WaitTimeout time.Duration `mapstructure:"wait_timeout,omitempty"`
+
+	// SetGoMemoryLimit sets the soft memory limit of the Go runtime to the memory limit, updated when the total
+	// memory changes with the percentage settings. The garbage collection is then driven by the Go runtime,
+	// and the memory usage is read from its metrics instead of forcing garbage collections.
+	SetGoMemoryLimit bool `mapstructure:"set_go_memory_limit,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.CheckInterval <= 0 {
+	if err := cfg.Mode.Validate(); err != nil {
+		return err
+	}
+	if cfg.Mode == ModeInFlightBytes {
+		if cfg.WaitTimeout < 0 {
+			return errWaitTimeoutOutOfRange
+		}
+		if cfg.SetGoMemoryLimit {
+			return errSetGoMemoryLimitMode
+		}
+	} else if cfg.CheckInterval <= 0 {
 		return errCheckIntervalOutOfRange
 	}
 	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
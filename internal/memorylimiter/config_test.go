@@ -4,6 +4,7 @@
 package memorylimiter
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
@@ -84,6 +85,42 @@ func TestConfigValidate(t *testing.T) {
 			},
 			err: errSpikeLimitPercentageOutOfRange,
 		},
+		{
+			name: "in-flight bytes without check interval",
+			cfg: &Config{
+				Mode:           ModeInFlightBytes,
+				MemoryLimitMiB: 100,
+				WaitTimeout:    time.Second,
+			},
+			err: nil,
+		},
+		{
+			name: "invalid wait timeout",
+			cfg: &Config{
+				Mode:           ModeInFlightBytes,
+				MemoryLimitMiB: 100,
+				WaitTimeout:    -time.Second,
+			},
+			err: errWaitTimeoutOutOfRange,
+		},
+		{
+			name: "go memory limit in in-flight bytes mode",
+			cfg: &Config{
+				Mode:             ModeInFlightBytes,
+				MemoryLimitMiB:   100,
+				SetGoMemoryLimit: true,
+			},
+			err: errSetGoMemoryLimitMode,
+		},
+		{
+			name: "invalid mode",
+			cfg: &Config{
+				Mode:           "unknown",
+				CheckInterval:  time.Second,
+				MemoryLimitMiB: 100,
+			},
+			err: errors.New(`'mode' "unknown" is not supported`),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter // import "go.opentelemetry.io/collector/internal/memorylimiter"
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// processGoMemoryLimits owns the soft memory limit of the Go runtime for all the memory limiters of the process.
+var processGoMemoryLimits = newGoMemoryLimits(debug.SetMemoryLimit)
+
+// goMemoryLimits sets the soft memory limit of the Go runtime, which is global to the process, on behalf of the
+// memory limiters setting it: the lowest of their limits is applied, and the limit set before the first of them
+// is restored once the last of them is removed.
+type goMemoryLimits struct {
+	setMemoryLimitFn func(limit int64) int64
+
+	mu     sync.Mutex
+	limits map[*MemoryLimiter]int64
+	// previous is the soft memory limit of the Go runtime before the first memory limiter set it.
+	previous int64
+}
+
+func newGoMemoryLimits(setMemoryLimitFn func(limit int64) int64) *goMemoryLimits {
+	return &goMemoryLimits{
+		setMemoryLimitFn: setMemoryLimitFn,
+		limits:           map[*MemoryLimiter]int64{},
+	}
+}
+
+// set sets or updates the limit of the memory limiter.
+func (gl *goMemoryLimits) set(ml *MemoryLimiter, limit int64) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+	if len(gl.limits) == 0 {
+		// A negative limit reads the current limit without changing it.
+		gl.previous = gl.setMemoryLimitFn(-1)
+	}
+	gl.limits[ml] = limit
+	gl.applyLocked()
+}
+
+// remove removes the limit of the memory limiter, restoring the previous limit if it was the last one.
+func (gl *goMemoryLimits) remove(ml *MemoryLimiter) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+	if _, ok := gl.limits[ml]; !ok {
+		return
+	}
+	delete(gl.limits, ml)
+	if len(gl.limits) == 0 {
+		gl.setMemoryLimitFn(gl.previous)
+		return
+	}
+	gl.applyLocked()
+}
+
+func (gl *goMemoryLimits) applyLocked() {
+	lowest := int64(-1)
+	for _, limit := range gl.limits {
+		if lowest < 0 || limit < lowest {
+			lowest = limit
+		}
+	}
+	gl.setMemoryLimitFn(lowest)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"runtime/metrics"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -56,6 +57,17 @@ type MemoryLimiter struct {
 	// testing different values.
 	readMemStatsFn func(m *runtime.MemStats)
 
+	// goMemoryLimit is true when the soft memory limit of the Go runtime is set to the memory limit.
+	goMemoryLimit bool
+	// goMemoryLimits sets the soft memory limit of the Go runtime, shared with the other memory limiters.
+	goMemoryLimits *goMemoryLimits
+	// limitPercentage and spikePercentage are set to update the limits when the total memory changes.
+	limitPercentage uint64
+	spikePercentage uint64
+
+	// The function to read the heap metrics is set as a reference to help with testing.
+	readHeapMetricsFn func() heapMetrics
+
 	// Fields used for logging.
 	logger *zap.Logger
 
@@ -67,6 +79,9 @@ type MemoryLimiter struct {
 
 // NewMemoryLimiter returns a new memory limiter component
 func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
+	if cfg.Mode == ModeInFlightBytes {
+		return nil, fmt.Errorf("'mode' %q is not supported by the memory usage limiter", string(cfg.Mode))
+	}
 	usageChecker, err := getMemUsageChecker(cfg, logger)
 	if err != nil {
 		return nil, err
@@ -75,16 +90,25 @@ func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
 	logger.Info("Memory limiter configured",
 		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
-		zap.Duration("check_interval", cfg.CheckInterval))
-
-	return &MemoryLimiter{
-		usageChecker:   *usageChecker,
-		memCheckWait:   cfg.CheckInterval,
-		ticker:         time.NewTicker(cfg.CheckInterval),
-		readMemStatsFn: ReadMemStatsFn,
-		logger:         logger,
-		mustRefuse:     &atomic.Bool{},
-	}, nil
+		zap.Duration("check_interval", cfg.CheckInterval),
+		zap.Bool("set_go_memory_limit", cfg.SetGoMemoryLimit))
+
+	ml := &MemoryLimiter{
+		usageChecker:      *usageChecker,
+		memCheckWait:      cfg.CheckInterval,
+		ticker:            time.NewTicker(cfg.CheckInterval),
+		readMemStatsFn:    ReadMemStatsFn,
+		logger:            logger,
+		mustRefuse:        &atomic.Bool{},
+		goMemoryLimit:     cfg.SetGoMemoryLimit,
+		goMemoryLimits:    processGoMemoryLimits,
+		readHeapMetricsFn: readHeapMetrics,
+	}
+	if cfg.SetGoMemoryLimit && cfg.MemoryLimitMiB == 0 {
+		ml.limitPercentage = uint64(cfg.MemoryLimitPercentage)
+		ml.spikePercentage = uint64(cfg.MemorySpikePercentage)
+	}
+	return ml, nil
 }
 
 // startMonitoring starts a single ticker'd goroutine per instance
@@ -95,6 +119,10 @@ func (ml *MemoryLimiter) startMonitoring() {
 
 	ml.refCounter++
 	if ml.refCounter == 1 {
+		if ml.goMemoryLimit {
+			ml.goMemoryLimits.set(ml, int64(ml.usageChecker.memAllocLimit))
+			ml.logger.Info("Go memory limit set", zap.Uint64("limit_mib", ml.usageChecker.memAllocLimit/mibBytes))
+		}
 		ml.closed = make(chan struct{})
 		ml.waitGroup.Add(1)
 		go func() {
@@ -128,6 +156,9 @@ func (ml *MemoryLimiter) Shutdown(context.Context) error {
 		ml.ticker.Stop()
 		close(ml.closed)
 		ml.waitGroup.Wait()
+		if ml.goMemoryLimit {
+			ml.goMemoryLimits.remove(ml)
+		}
 	}
 	ml.refCounter--
 	return nil
@@ -174,13 +205,67 @@ func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
 	return ms
 }
 
+// readLiveMemStats reads the memory stats, using the live heap of the last GC as the current memory usage.
+// The Go runtime collects the garbage as the heap goal approaches the Go memory limit, so no GC is forced.
+func (ml *MemoryLimiter) readLiveMemStats() *runtime.MemStats {
+	ms := ml.readMemStats()
+	hm := ml.readHeapMetricsFn()
+	// The live heap is zero until the first GC.
+	if hm.live > 0 && hm.live < ms.Alloc {
+		ms.Alloc = hm.live
+	}
+	ml.logger.Debug("Memory usage after the last GC.", memstatToZapField(ms), zap.Uint64("heap_goal_mib", hm.goal/mibBytes))
+	return ms
+}
+
+// heapMetrics holds the heap metrics of the Go runtime.
+type heapMetrics struct {
+	// live is the size of the heap marked as live by the last GC.
+	live uint64
+	// goal is the size of the heap at which the next GC is done.
+	goal uint64
+}
+
+func readHeapMetrics() heapMetrics {
+	samples := []metrics.Sample{{Name: "/gc/heap/live:bytes"}, {Name: "/gc/heap/goal:bytes"}}
+	metrics.Read(samples)
+	return heapMetrics{live: samples[0].Value.Uint64(), goal: samples[1].Value.Uint64()}
+}
+
+// updateLimits updates the limits, and the Go memory limit, when the total memory changes.
+func (ml *MemoryLimiter) updateLimits() {
+	totalMemory, err := GetMemoryFn()
+	if err != nil {
+		ml.logger.Warn("Failed to get total memory, keeping the current memory limits.", zap.Error(err))
+		return
+	}
+	usageChecker := newPercentageMemUsageChecker(totalMemory, ml.limitPercentage, ml.spikePercentage)
+	if usageChecker.memAllocLimit == ml.usageChecker.memAllocLimit {
+		return
+	}
+	ml.usageChecker = *usageChecker
+	ml.goMemoryLimits.set(ml, int64(usageChecker.memAllocLimit))
+	ml.logger.Info("Total memory changed, memory limits updated",
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
+		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
+		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes))
+}
+
 // CheckMemLimits inspects current memory usage against threshold and toggle mustRefuse when threshold is exceeded
 func (ml *MemoryLimiter) CheckMemLimits() {
+	if ml.limitPercentage != 0 {
+		ml.updateLimits()
+	}
+
 	ms := ml.readMemStats()
 
 	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
 
-	if ml.usageChecker.aboveHardLimit(ms) {
+	if ml.goMemoryLimit && ml.usageChecker.aboveSoftLimit(ms) {
+		// The Go runtime collects the garbage before reaching the Go memory limit,
+		// use the memory usage after the last GC instead of forcing a GC.
+		ms = ml.readLiveMemStats()
+	} else if ml.usageChecker.aboveHardLimit(ms) {
 		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
 		ms = ml.doGCandReadMemStats()
 	}
@@ -199,7 +284,7 @@ func (ml *MemoryLimiter) CheckMemLimits() {
 	if !wasRefusing && mustRefuse {
 		// We are above soft limit, do a GC if it wasn't done recently and see if
 		// it brings memory usage below the soft limit.
-		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+		if !ml.goMemoryLimit && time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
 			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
 			ms = ml.doGCandReadMemStats()
 			// Check the limit again to see if GC helped.
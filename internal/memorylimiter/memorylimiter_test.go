@@ -4,9 +4,12 @@
 package memorylimiter
 
 import (
+	"context"
+	"math"
 	"runtime"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -132,3 +135,126 @@ func TestRefuseDecision(t *testing.T) {
 		})
 	}
 }
+
+// fakeSetMemoryLimit returns a function setting the soft memory limit of the Go runtime to goMemoryLimit.
+func fakeSetMemoryLimit(goMemoryLimit *int64) func(int64) int64 {
+	return func(limit int64) int64 {
+		previous := *goMemoryLimit
+		if limit >= 0 {
+			*goMemoryLimit = limit
+		}
+		return previous
+	}
+}
+
+func TestGoMemoryLimit(t *testing.T) {
+	t.Cleanup(func() {
+		GetMemoryFn = iruntime.TotalMemory
+	})
+	totalMemory := uint64(1000 * mibBytes)
+	GetMemoryFn = func() (uint64, error) {
+		return totalMemory, nil
+	}
+	ml, err := NewMemoryLimiter(&Config{
+		CheckInterval:         time.Minute,
+		MemoryLimitPercentage: 50,
+		MemorySpikePercentage: 10,
+		SetGoMemoryLimit:      true,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	goMemoryLimit := int64(math.MaxInt64)
+	ml.goMemoryLimits = newGoMemoryLimits(fakeSetMemoryLimit(&goMemoryLimit))
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = 100 * mibBytes
+	}
+
+	require.NoError(t, ml.Start(context.Background(), nil))
+	assert.Equal(t, int64(500*mibBytes), goMemoryLimit)
+
+	// The limits follow the total memory.
+	totalMemory = 2000 * mibBytes
+	ml.CheckMemLimits()
+	assert.Equal(t, int64(1000*mibBytes), goMemoryLimit)
+	assert.Equal(t, uint64(200*mibBytes), ml.usageChecker.memSpikeLimit)
+
+	require.NoError(t, ml.Shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), goMemoryLimit)
+}
+
+func TestGoMemoryLimitShared(t *testing.T) {
+	goMemoryLimit := int64(math.MaxInt64)
+	gl := newGoMemoryLimits(fakeSetMemoryLimit(&goMemoryLimit))
+	newLimiter := func(limitMiB uint32) *MemoryLimiter {
+		ml, err := NewMemoryLimiter(&Config{
+			CheckInterval:    time.Minute,
+			MemoryLimitMiB:   limitMiB,
+			SetGoMemoryLimit: true,
+		}, zap.NewNop())
+		require.NoError(t, err)
+		ml.goMemoryLimits = gl
+		return ml
+	}
+	first := newLimiter(1000)
+	second := newLimiter(500)
+
+	// The lowest limit of the started memory limiters is applied.
+	require.NoError(t, first.Start(context.Background(), nil))
+	assert.Equal(t, int64(1000*mibBytes), goMemoryLimit)
+	require.NoError(t, second.Start(context.Background(), nil))
+	assert.Equal(t, int64(500*mibBytes), goMemoryLimit)
+	require.NoError(t, second.Shutdown(context.Background()))
+	assert.Equal(t, int64(1000*mibBytes), goMemoryLimit)
+
+	// The limit of the Go runtime is restored once no memory limiter sets it, whatever the order of their shutdowns.
+	require.NoError(t, second.Start(context.Background(), nil))
+	require.NoError(t, first.Shutdown(context.Background()))
+	assert.Equal(t, int64(500*mibBytes), goMemoryLimit)
+	require.NoError(t, second.Shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), goMemoryLimit)
+}
+
+func TestGoMemoryLimitLiveHeap(t *testing.T) {
+	var currentMemAlloc, liveHeap uint64
+	ml := &MemoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+			memSpikeLimit: 24,
+		},
+		mustRefuse: &atomic.Bool{},
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+		goMemoryLimit: true,
+		readHeapMetricsFn: func() heapMetrics {
+			return heapMetrics{live: liveHeap, goal: 2 * liveHeap}
+		},
+		logger: zap.NewNop(),
+	}
+
+	// Above the hard limit, but the live heap of the last GC is below the soft limit.
+	currentMemAlloc = 1800
+	liveHeap = 800
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// The live heap of the last GC is above the soft limit.
+	liveHeap = 1010
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// No GC was done yet.
+	liveHeap = 0
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	currentMemAlloc = 800
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+}
+
+func TestReadHeapMetrics(t *testing.T) {
+	runtime.GC()
+	hm := readHeapMetrics()
+	assert.Positive(t, hm.live)
+	assert.GreaterOrEqual(t, hm.goal, hm.live)
+}
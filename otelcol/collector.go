@@ -159,17 +159,60 @@ func (col *Collector) Shutdown() {
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	col.setCollectorState(StateStarting)
 
+	cfg, set, err := col.loadServiceSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	return col.startService(ctx, cfg, set)
+}
+
+// startService creates the service for the given config and settings, and starts it. If all the steps succeeds it
+// sets the col.service with the service currently running.
+func (col *Collector) startService(ctx context.Context, cfg *Config, set service.Settings) error {
+	var err error
+	col.service, err = service.New(ctx, set, cfg.Service)
+	if err != nil {
+		return err
+	}
+	if col.updateConfigProviderLogger != nil {
+		col.updateConfigProviderLogger(col.service.Logger().Core())
+	}
+	if col.bc != nil {
+		x := col.bc.TakeLogs()
+		for _, log := range x {
+			ce := col.service.Logger().Core().Check(log.Entry, nil)
+			if ce != nil {
+				ce.Write(log.Context...)
+			}
+		}
+	}
+
+	if !col.set.SkipSettingGRPCLogger {
+		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
+	}
+
+	if err = col.service.Start(ctx); err != nil {
+		return multierr.Combine(err, col.service.Shutdown(ctx))
+	}
+	col.setCollectorState(StateRunning)
+
+	return nil
+}
+
+// loadServiceSettings loads and validates the config, and returns it along with the settings to create the service.
+func (col *Collector) loadServiceSettings(ctx context.Context) (*Config, service.Settings, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return nil, service.Settings{}, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 	cfg, err := col.configProvider.Get(ctx, factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return nil, service.Settings{}, fmt.Errorf("failed to get config: %w", err)
 	}
 
 	if err = cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return nil, service.Settings{}, fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	col.serviceConfig = &cfg.Service
@@ -177,10 +220,10 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	conf := confmap.New()
 
 	if err = conf.Marshal(cfg); err != nil {
-		return fmt.Errorf("could not marshal configuration: %w", err)
+		return nil, service.Settings{}, fmt.Errorf("could not marshal configuration: %w", err)
 	}
 
-	col.service, err = service.New(ctx, service.Settings{
+	return cfg, service.Settings{
 		BuildInfo:     col.set.BuildInfo,
 		CollectorConf: conf,
 
@@ -204,36 +247,23 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		},
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
-	}, cfg.Service)
-	if err != nil {
-		return err
-	}
-	if col.updateConfigProviderLogger != nil {
-		col.updateConfigProviderLogger(col.service.Logger().Core())
-	}
-	if col.bc != nil {
-		x := col.bc.TakeLogs()
-		for _, log := range x {
-			ce := col.service.Logger().Core().Check(log.Entry, nil)
-			if ce != nil {
-				ce.Write(log.Context...)
-			}
-		}
-	}
-
-	if !col.set.SkipSettingGRPCLogger {
-		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
-	}
-
-	if err = col.service.Start(ctx); err != nil {
-		return multierr.Combine(err, col.service.Shutdown(ctx))
-	}
-	col.setCollectorState(StateRunning)
-
-	return nil
+	}, nil
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
+	cfg, set, loadErr := col.loadServiceSettings(ctx)
+	if loadErr == nil {
+		// Try to only restart the components that changed, and fall back to a full restart if the change requires it.
+		err := col.service.Reload(ctx, set, cfg.Service)
+		if err == nil {
+			col.service.Logger().Info("Config updated, restarted changed components")
+			return nil
+		}
+		if !errors.Is(err, service.ErrReloadRequiresRestart) {
+			return fmt.Errorf("failed to reload configuration: %w", err)
+		}
+	}
+
 	col.service.Logger().Warn("Config updated, restart service")
 	col.setCollectorState(StateClosing)
 
@@ -241,7 +271,11 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
 	}
 
-	if err := col.setupConfigurationComponents(ctx); err != nil {
+	col.setCollectorState(StateStarting)
+	if loadErr != nil {
+		return fmt.Errorf("failed to setup configuration components: %w", loadErr)
+	}
+	if err := col.startService(ctx, cfg, set); err != nil {
 		return fmt.Errorf("failed to setup configuration components: %w", err)
 	}
 
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	printConfigModeRedacted   = "redacted"
+	printConfigModeUnredacted = "unredacted"
+
+	printConfigFormatYAML = "yaml"
+	printConfigFormatJSON = "json"
+
+	// redactedValue is how the sensitive values are marshaled, see configopaque.String.
+	redactedValue = "[REDACTED]"
+)
+
+// newPrintConfigSubCommand constructs a new print-config sub command using the given CollectorSettings.
+func newPrintConfigSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var mode, format string
+	printConfigCmd := &cobra.Command{
+		Use:   "print-config",
+		Short: "Prints the fully resolved configuration without running the collector",
+		Long: `Prints the configuration after all the providers, converters and variable expansions have been applied,
+including the default values of the configured components. Sensitive values are redacted unless --mode=unredacted is used.
+The output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			return printConfig(cmd.Context(), cmd.OutOrStdout(), set, mode, format)
+		},
+	}
+	printConfigCmd.Flags().StringVar(&mode, "mode", printConfigModeRedacted,
+		fmt.Sprintf("Either %q to redact sensitive values, or %q to reveal them", printConfigModeRedacted, printConfigModeUnredacted))
+	printConfigCmd.Flags().StringVar(&format, "format", printConfigFormatYAML,
+		fmt.Sprintf("Output format, either %q or %q", printConfigFormatYAML, printConfigFormatJSON))
+	printConfigCmd.Flags().AddGoFlagSet(flagSet)
+	return printConfigCmd
+}
+
+// printConfig resolves the configuration for the given settings and writes it to w.
+func printConfig(ctx context.Context, w io.Writer, set CollectorSettings, mode string, format string) (err error) {
+	if mode != printConfigModeRedacted && mode != printConfigModeUnredacted {
+		return fmt.Errorf("unsupported mode %q, must be either %q or %q", mode, printConfigModeRedacted, printConfigModeUnredacted)
+	}
+	if format != printConfigFormatYAML && format != printConfigFormatJSON {
+		return fmt.Errorf("unsupported format %q, must be either %q or %q", format, printConfigFormatYAML, printConfigFormatJSON)
+	}
+
+	factories, err := set.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+
+	resolverSet := set.ConfigProviderSettings.ResolverSettings
+	resolverSet.ProviderSettings = confmap.ProviderSettings{Logger: zap.NewNop()}
+	resolverSet.ConverterSettings = confmap.ConverterSettings{Logger: zap.NewNop()}
+	resolver, err := confmap.NewResolver(resolverSet)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, resolver.Shutdown(ctx))
+	}()
+
+	resolved, err := resolver.Resolve(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot resolve the configuration: %w", err)
+	}
+	cfg, err := unmarshal(resolved, factories)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal the configuration: %w", err)
+	}
+
+	// Marshaling the unmarshalled configuration adds the default values of the components,
+	// and redacts the sensitive values since they are marshaled as "[REDACTED]".
+	effective := confmap.New()
+	if err = effective.Marshal(&Config{
+		Receivers:  cfg.Receivers.Configs(),
+		Processors: cfg.Processors.Configs(),
+		Exporters:  cfg.Exporters.Configs(),
+		Connectors: cfg.Connectors.Configs(),
+		Extensions: cfg.Extensions.Configs(),
+		Service:    cfg.Service,
+	}); err != nil {
+		return fmt.Errorf("could not marshal configuration: %w", err)
+	}
+	printed := effective.ToStringMap()
+	if mode == printConfigModeUnredacted {
+		// The resolved configuration holds the values as provided by the user, sensitive values included.
+		unredact(printed, resolved.ToStringMap())
+	}
+
+	var out []byte
+	switch format {
+	case printConfigFormatJSON:
+		out, err = json.MarshalIndent(printed, "", "  ")
+		out = append(out, '\n')
+	default:
+		out, err = yaml.Marshal(printed)
+	}
+	if err != nil {
+		return fmt.Errorf("could not encode configuration: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// unredact replaces the redacted values of the printed configuration with the values provided by the user
+// at the same path. The values not provided by the user stay redacted.
+func unredact(printed any, provided any) any {
+	switch p := printed.(type) {
+	case map[string]any:
+		providedMap, _ := provided.(map[string]any)
+		for k, v := range p {
+			p[k] = unredact(v, providedMap[k])
+		}
+	case []any:
+		providedSlice, _ := provided.([]any)
+		for i, v := range p {
+			var providedValue any
+			if i < len(providedSlice) {
+				providedValue = providedSlice[i]
+			}
+			p[i] = unredact(v, providedValue)
+		}
+	case string:
+		if p == redactedValue && provided != nil {
+			return provided
+		}
+	}
+	return printed
+}
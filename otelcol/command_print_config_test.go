@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type secretReceiverConfig struct {
+	APIKey  configopaque.String `mapstructure:"api_key"`
+	Retries int                 `mapstructure:"retries"`
+}
+
+func secretFactories() (Factories, error) {
+	factories, err := nopFactories()
+	if err != nil {
+		return Factories{}, err
+	}
+	secretFactory := receiver.NewFactory(component.MustNewType("secret"), func() component.Config {
+		return &secretReceiverConfig{Retries: 3}
+	})
+	factories.Receivers[secretFactory.Type()] = secretFactory
+	return factories, nil
+}
+
+func TestPrintConfigSubCommandNoConfig(t *testing.T) {
+	cmd := newPrintConfigSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	err := cmd.Execute()
+	require.ErrorContains(t, err, "at least one config flag must be provided")
+}
+
+func TestPrintConfigSubCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		unmarshal      func([]byte, any) error
+		expectedAPIKey string
+	}{
+		{
+			name:           "default",
+			unmarshal:      yaml.Unmarshal,
+			expectedAPIKey: "[REDACTED]",
+		},
+		{
+			name:           "redacted json",
+			args:           []string{"--mode", "redacted", "--format", "json"},
+			unmarshal:      json.Unmarshal,
+			expectedAPIKey: "[REDACTED]",
+		},
+		{
+			name:           "unredacted yaml",
+			args:           []string{"--mode", "unredacted", "--format", "yaml"},
+			unmarshal:      yaml.Unmarshal,
+			expectedAPIKey: "localhost",
+		},
+		{
+			name:           "unredacted json",
+			args:           []string{"--mode=unredacted", "--format=json"},
+			unmarshal:      json.Unmarshal,
+			expectedAPIKey: "localhost",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newPrintConfigSubCommand(CollectorSettings{
+				Factories:              secretFactories,
+				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-printconfig.yaml")}),
+			}, flags(featuregate.GlobalRegistry()))
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs(tt.args)
+			require.NoError(t, cmd.Execute())
+
+			var printed map[string]any
+			require.NoError(t, tt.unmarshal(out.Bytes(), &printed))
+			receivers := printed["receivers"].(map[string]any)
+			secret := receivers["secret"].(map[string]any)
+			assert.Equal(t, tt.expectedAPIKey, secret["api_key"])
+			// Default values of the components are printed as well.
+			assert.EqualValues(t, 3, secret["retries"])
+			// Components without configuration are printed with their default values in all modes.
+			defaults := receivers["secret/defaults"].(map[string]any)
+			assert.Equal(t, "[REDACTED]", defaults["api_key"])
+			assert.EqualValues(t, 3, defaults["retries"])
+			assert.Contains(t, printed["exporters"], "nop")
+			assert.Contains(t, printed["service"], "pipelines")
+		})
+	}
+}
+
+func TestPrintConfigSubCommandInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "mode",
+			args:        []string{"--mode", "invalid"},
+			expectedErr: `unsupported mode "invalid"`,
+		},
+		{
+			name:        "format",
+			args:        []string{"--format", "invalid"},
+			expectedErr: `unsupported format "invalid"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newPrintConfigSubCommand(CollectorSettings{
+				Factories:              secretFactories,
+				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-printconfig.yaml")}),
+			}, flags(featuregate.GlobalRegistry()))
+			cmd.SetArgs(tt.args)
+			require.ErrorContains(t, cmd.Execute(), tt.expectedErr)
+		})
+	}
+}
+
+func TestPrintConfigSubCommandInvalidComponents(t *testing.T) {
+	cmd := newPrintConfigSubCommand(CollectorSettings{
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid-components.yaml")}),
+	}, flags(featuregate.GlobalRegistry()))
+	require.ErrorContains(t, cmd.Execute(), "unknown type: \"nosuchprocessor\"")
+}
@@ -36,6 +36,21 @@ func TestProtoSizerEmptyLogs(t *testing.T) {
 	assert.Equal(t, 0, sizer.LogsSize(NewLogs()))
 }
 
+func TestProtoSizerElements(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	sl := rl.ScopeLogs().AppendEmpty()
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetSeverityText("error")
+
+	// Every nested message adds a one byte tag and a one byte length for messages shorter than 128 bytes,
+	// the empty resource and scope are always serialized.
+	assert.Equal(t, marshaler.LogRecordSize(lr)+4, marshaler.ScopeLogsSize(sl))
+	assert.Equal(t, marshaler.ScopeLogsSize(sl)+4, marshaler.ResourceLogsSize(rl))
+	assert.Equal(t, marshaler.ResourceLogsSize(rl)+2, marshaler.LogsSize(ld))
+}
+
 func BenchmarkLogsToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	logs := generateBenchmarkLogs(128)
@@ -22,6 +22,42 @@ func (e *ProtoMarshaler) MetricsSize(md Metrics) int {
 	return pb.Size()
 }
 
+// ResourceMetricsSize returns the size in bytes of the given ResourceMetrics when serialized as protobuf.
+func (e *ProtoMarshaler) ResourceMetricsSize(rm ResourceMetrics) int {
+	return rm.orig.Size()
+}
+
+// ScopeMetricsSize returns the size in bytes of the given ScopeMetrics when serialized as protobuf.
+func (e *ProtoMarshaler) ScopeMetricsSize(sm ScopeMetrics) int {
+	return sm.orig.Size()
+}
+
+// MetricSize returns the size in bytes of the given Metric when serialized as protobuf.
+func (e *ProtoMarshaler) MetricSize(m Metric) int {
+	return m.orig.Size()
+}
+
+// NumberDataPointSize returns the size in bytes of the given NumberDataPoint when serialized as protobuf.
+func (e *ProtoMarshaler) NumberDataPointSize(ndp NumberDataPoint) int {
+	return ndp.orig.Size()
+}
+
+// HistogramDataPointSize returns the size in bytes of the given HistogramDataPoint when serialized as protobuf.
+func (e *ProtoMarshaler) HistogramDataPointSize(hdp HistogramDataPoint) int {
+	return hdp.orig.Size()
+}
+
+// ExponentialHistogramDataPointSize returns the size in bytes of the given ExponentialHistogramDataPoint
+// when serialized as protobuf.
+func (e *ProtoMarshaler) ExponentialHistogramDataPointSize(ehdp ExponentialHistogramDataPoint) int {
+	return ehdp.orig.Size()
+}
+
+// SummaryDataPointSize returns the size in bytes of the given SummaryDataPoint when serialized as protobuf.
+func (e *ProtoMarshaler) SummaryDataPointSize(sdp SummaryDataPoint) int {
+	return sdp.orig.Size()
+}
+
 type ProtoUnmarshaler struct{}
 
 func (d *ProtoUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
@@ -36,6 +36,34 @@ func TestProtoSizerEmptyMetrics(t *testing.T) {
 	assert.Equal(t, 0, sizer.MetricsSize(NewMetrics()))
 }
 
+func TestProtoSizerElements(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("foo")
+	ndp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	ndp.SetIntValue(1)
+
+	// Every nested message adds a one byte tag and a one byte length for messages shorter than 128 bytes,
+	// the empty resource and scope are always serialized.
+	assert.Equal(t, 5+marshaler.NumberDataPointSize(ndp)+4, marshaler.MetricSize(m))
+	assert.Equal(t, marshaler.MetricSize(m)+4, marshaler.ScopeMetricsSize(sm))
+	assert.Equal(t, marshaler.ScopeMetricsSize(sm)+4, marshaler.ResourceMetricsSize(rm))
+	assert.Equal(t, marshaler.ResourceMetricsSize(rm)+2, marshaler.MetricsSize(md))
+
+	hdp := NewHistogramDataPoint()
+	hdp.SetCount(1)
+	assert.Equal(t, 9, marshaler.HistogramDataPointSize(hdp))
+	ehdp := NewExponentialHistogramDataPoint()
+	ehdp.SetCount(1)
+	assert.Positive(t, marshaler.ExponentialHistogramDataPointSize(ehdp))
+	sdp := NewSummaryDataPoint()
+	sdp.SetCount(1)
+	assert.Equal(t, 9, marshaler.SummaryDataPointSize(sdp))
+}
+
 func BenchmarkMetricsToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	metrics := generateBenchmarkMetrics(128)
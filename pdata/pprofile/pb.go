@@ -22,6 +22,21 @@ func (e *ProtoMarshaler) ProfilesSize(td Profiles) int {
 	return pb.Size()
 }
 
+// ResourceProfilesSize returns the size in bytes of the given ResourceProfiles when serialized as protobuf.
+func (e *ProtoMarshaler) ResourceProfilesSize(rp ResourceProfiles) int {
+	return rp.orig.Size()
+}
+
+// ScopeProfilesSize returns the size in bytes of the given ScopeProfiles when serialized as protobuf.
+func (e *ProtoMarshaler) ScopeProfilesSize(sp ScopeProfiles) int {
+	return sp.orig.Size()
+}
+
+// ProfileSize returns the size in bytes of the given Profile when serialized as protobuf.
+func (e *ProtoMarshaler) ProfileSize(p Profile) int {
+	return p.orig.Size()
+}
+
 type ProtoUnmarshaler struct{}
 
 func (d *ProtoUnmarshaler) UnmarshalProfiles(buf []byte) (Profiles, error) {
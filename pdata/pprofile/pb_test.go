@@ -36,6 +36,22 @@ func TestProtoSizerEmptyProfiles(t *testing.T) {
 	assert.Equal(t, 0, sizer.ProfilesSize(NewProfiles()))
 }
 
+func TestProtoSizerElements(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	td := NewProfiles()
+	rp := td.ResourceProfiles().AppendEmpty()
+	sp := rp.ScopeProfiles().AppendEmpty()
+	p := sp.Profiles().AppendEmpty()
+	p.StringTable().Append("foobar")
+
+	// Every nested message adds a one byte tag and a one byte length for messages shorter than 128 bytes,
+	// the empty resource and scope are always serialized.
+	assert.Positive(t, marshaler.ProfileSize(p))
+	assert.Equal(t, marshaler.ProfileSize(p)+4, marshaler.ScopeProfilesSize(sp))
+	assert.Equal(t, marshaler.ScopeProfilesSize(sp)+4, marshaler.ResourceProfilesSize(rp))
+	assert.Equal(t, marshaler.ResourceProfilesSize(rp)+2, marshaler.ProfilesSize(td))
+}
+
 func BenchmarkProfilesToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	profiles := generateBenchmarkProfiles(128)
@@ -37,6 +37,21 @@ func TestProtoSizerEmptyTraces(t *testing.T) {
 	assert.Equal(t, 0, sizer.TracesSize(NewTraces()))
 }
 
+func TestProtoSizerElements(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	ss := rs.ScopeSpans().AppendEmpty()
+	span := ss.Spans().AppendEmpty()
+	span.SetName("foo")
+
+	// Every nested message adds a one byte tag and a one byte length for messages shorter than 128 bytes,
+	// the empty resource and scope are always serialized.
+	assert.Equal(t, marshaler.SpanSize(span)+4, marshaler.ScopeSpansSize(ss))
+	assert.Equal(t, marshaler.ScopeSpansSize(ss)+4, marshaler.ResourceSpansSize(rs))
+	assert.Equal(t, marshaler.ResourceSpansSize(rs)+2, marshaler.TracesSize(td))
+}
+
 func BenchmarkTracesToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	traces := generateBenchmarkTraces(128)
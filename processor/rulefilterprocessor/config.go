@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulefilterprocessor // import "go.opentelemetry.io/collector/processor/rulefilterprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// Action is what a rule does with the matching items.
+type Action string
+
+const (
+	// ActionExclude drops the items matching the rule. It is the default action.
+	ActionExclude Action = "exclude"
+	// ActionInclude drops the items not matching the rule.
+	ActionInclude Action = "include"
+)
+
+// Validate checks if the action is supported.
+func (a Action) Validate() error {
+	switch a {
+	case "", ActionExclude, ActionInclude:
+		return nil
+	}
+	return fmt.Errorf("action %q is not supported", string(a))
+}
+
+// Rule matches the items of a level by their name and their attributes. An item matches the rule
+// when it matches all the configured matchers.
+type Rule struct {
+	// Name identifies the rule in the telemetry of the processor. Defaults to the level and the index
+	// of the rule, for instance "spans[0]".
+	Name string `mapstructure:"name,omitempty"`
+
+	// Action is "exclude" (default) to drop the matching items, or "include" to drop the other items.
+	Action Action `mapstructure:"action,omitempty"`
+
+	// Names matches the name of the items: the scope name, the span name, the span event name,
+	// the log record event name or the metric name of the data points. Not supported for resources.
+	Names []filter.Config `mapstructure:"names,omitempty"`
+
+	// Attributes is an expression matching the attributes of the items.
+	Attributes filter.Expression `mapstructure:"attributes,omitempty"`
+
+	// Severities matches the severity text of the log records, or the name of the severity number when the
+	// severity text is empty. Only supported for log records.
+	Severities []filter.Config `mapstructure:"severities,omitempty"`
+}
+
+// Config defines configuration for the rule filter processor. The rules of every level are applied in order,
+// and an item is dropped by the first rule dropping it.
+type Config struct {
+	// Resources are the rules applied to the resources, dropping all their data.
+	Resources []Rule `mapstructure:"resources,omitempty"`
+
+	// Scopes are the rules applied to the instrumentation scopes, dropping all their data.
+	Scopes []Rule `mapstructure:"scopes,omitempty"`
+
+	// Spans are the rules applied to the spans.
+	Spans []Rule `mapstructure:"spans,omitempty"`
+
+	// SpanEvents are the rules applied to the span events.
+	SpanEvents []Rule `mapstructure:"span_events,omitempty"`
+
+	// LogRecords are the rules applied to the log records.
+	LogRecords []Rule `mapstructure:"log_records,omitempty"`
+
+	// DataPoints are the rules applied to the metric data points.
+	DataPoints []Rule `mapstructure:"data_points,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	names := map[string]bool{}
+	var errs []error
+	for _, level := range cfg.levels() {
+		for i, r := range level.rules {
+			name := ruleName(level.name, i, r)
+			if names[name] {
+				errs = append(errs, fmt.Errorf("%s: rule name %q is used more than once", name, name))
+			}
+			names[name] = true
+			if err := r.validate(level.name); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r Rule) validate(level string) error {
+	if err := r.Action.Validate(); err != nil {
+		return err
+	}
+	if len(r.Names) == 0 && r.Attributes.String() == "" && len(r.Severities) == 0 {
+		return errors.New("at least one of names, attributes or severities must be specified")
+	}
+	if len(r.Names) > 0 && level == levelResources {
+		return errors.New("names are not supported for resources")
+	}
+	if len(r.Severities) > 0 && level != levelLogRecords {
+		return errors.New("severities are only supported for log records")
+	}
+	return nil
+}
+
+const (
+	levelResources  = "resources"
+	levelScopes     = "scopes"
+	levelSpans      = "spans"
+	levelSpanEvents = "span_events"
+	levelLogRecords = "log_records"
+	levelDataPoints = "data_points"
+)
+
+type levelRules struct {
+	name  string
+	rules []Rule
+}
+
+func (cfg *Config) levels() []levelRules {
+	return []levelRules{
+		{name: levelResources, rules: cfg.Resources},
+		{name: levelScopes, rules: cfg.Scopes},
+		{name: levelSpans, rules: cfg.Spans},
+		{name: levelSpanEvents, rules: cfg.SpanEvents},
+		{name: levelLogRecords, rules: cfg.LogRecords},
+		{name: levelDataPoints, rules: cfg.DataPoints},
+	}
+}
+
+// ruleName returns the name of the rule, or its level and index when it has no name.
+func ruleName(level string, index int, r Rule) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("%s[%d]", level, index)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulefilterprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/filter"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Resources: []Rule{{
+				Name:       "drop_test_services",
+				Attributes: filter.MustCompileExpression(`deployment.environment == "test"`),
+			}},
+			Scopes: []Rule{{
+				Names: []filter.Config{{Regex: `^internal\..*`}},
+			}},
+			Spans: []Rule{{
+				Name:       "drop_health_checks",
+				Names:      []filter.Config{{Strict: "GET /health"}},
+				Attributes: filter.MustCompileExpression(`http.response.status_code < 400`),
+			}},
+			SpanEvents: []Rule{{
+				Action: ActionInclude,
+				Names:  []filter.Config{{Strict: "exception"}},
+			}},
+			LogRecords: []Rule{{
+				Severities: []filter.Config{{Regex: `(?i)^(trace|debug)`}},
+			}},
+			DataPoints: []Rule{{
+				Names:      []filter.Config{{Regex: `^system\.cpu\..*`}},
+				Attributes: filter.MustCompileExpression(`state in ["idle", "wait"]`),
+			}},
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	names := []filter.Config{{Strict: "name"}}
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "empty",
+			cfg:  &Config{},
+		},
+		{
+			name: "invalid action",
+			cfg:  &Config{Spans: []Rule{{Action: "keep", Names: names}}},
+			err:  `spans[0]: action "keep" is not supported`,
+		},
+		{
+			name: "no matcher",
+			cfg:  &Config{Spans: []Rule{{Names: names}, {Action: ActionInclude}}},
+			err:  "spans[1]: at least one of names, attributes or severities must be specified",
+		},
+		{
+			name: "resource names",
+			cfg:  &Config{Resources: []Rule{{Name: "services", Names: names}}},
+			err:  "services: names are not supported for resources",
+		},
+		{
+			name: "span severities",
+			cfg:  &Config{Spans: []Rule{{Severities: names}}},
+			err:  "spans[0]: severities are only supported for log records",
+		},
+		{
+			name: "duplicate names",
+			cfg: &Config{
+				Spans:      []Rule{{Name: "drop", Names: names}},
+				LogRecords: []Rule{{Name: "drop", Names: names}},
+			},
+			err: `drop: rule name "drop" is used more than once`,
+		},
+		{
+			name: "multiple errors",
+			cfg: &Config{
+				Scopes:     []Rule{{}},
+				DataPoints: []Rule{{Severities: names}},
+			},
+			err: "scopes[0]: at least one of names, attributes or severities must be specified\n" +
+				"data_points[0]: severities are only supported for log records",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package rulefilterprocessor implements a processor dropping the resources, scopes, spans, span events, log records
+// and metric data points matching, or not matching, a list of rules.
+package rulefilterprocessor // import "go.opentelemetry.io/collector/processor/rulefilterprocessor"
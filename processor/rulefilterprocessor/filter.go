@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulefilterprocessor // import "go.opentelemetry.io/collector/processor/rulefilterprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/internal"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/rulefilterprocessor/internal/metadata"
+)
+
+const ruleKey = "rule"
+
+// rule is a compiled Rule.
+type rule struct {
+	include    bool
+	names      filter.Filter
+	attributes filter.Expression
+	severities filter.Filter
+	// attrs are the attributes of the telemetry of the rule.
+	attrs metric.MeasurementOption
+}
+
+func newRules(set processor.Settings, level string, rules []Rule) []*rule {
+	compiled := make([]*rule, 0, len(rules))
+	for i, r := range rules {
+		cr := &rule{
+			include:    r.Action == ActionInclude,
+			attributes: r.Attributes,
+			attrs: metric.WithAttributeSet(attribute.NewSet(
+				attribute.String(internal.ProcessorKey, set.ID.String()),
+				attribute.String(ruleKey, ruleName(level, i, r)))),
+		}
+		if len(r.Names) > 0 {
+			cr.names = filter.CreateFilter(r.Names)
+		}
+		if len(r.Severities) > 0 {
+			cr.severities = filter.CreateFilter(r.Severities)
+		}
+		compiled = append(compiled, cr)
+	}
+	return compiled
+}
+
+func (r *rule) matches(name string, attrs pcommon.Map, severity string) bool {
+	if r.names != nil && !r.names.Matches(name) {
+		return false
+	}
+	if r.severities != nil && !r.severities.Matches(severity) {
+		return false
+	}
+	return r.attributes.Matches(attrs)
+}
+
+// dropRule returns the first rule dropping the item, or nil if the item is kept.
+func dropRule(rules []*rule, name string, attrs pcommon.Map, severity string) *rule {
+	for _, r := range rules {
+		if r.matches(name, attrs, severity) != r.include {
+			return r
+		}
+	}
+	return nil
+}
+
+type filterProcessor struct {
+	resources  []*rule
+	scopes     []*rule
+	spans      []*rule
+	spanEvents []*rule
+	logRecords []*rule
+	dataPoints []*rule
+
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+func newFilterProcessor(set processor.Settings, cfg *Config) (*filterProcessor, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return &filterProcessor{
+		resources:        newRules(set, levelResources, cfg.Resources),
+		scopes:           newRules(set, levelScopes, cfg.Scopes),
+		spans:            newRules(set, levelSpans, cfg.Spans),
+		spanEvents:       newRules(set, levelSpanEvents, cfg.SpanEvents),
+		logRecords:       newRules(set, levelLogRecords, cfg.LogRecords),
+		dataPoints:       newRules(set, levelDataPoints, cfg.DataPoints),
+		telemetryBuilder: telemetryBuilder,
+	}, nil
+}
+
+func (fp *filterProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		if r := dropRule(fp.resources, "", rs.Resource().Attributes(), ""); r != nil {
+			count := 0
+			for i := 0; i < rs.ScopeSpans().Len(); i++ {
+				count += rs.ScopeSpans().At(i).Spans().Len()
+			}
+			fp.telemetryBuilder.ProcessorRulefilterDroppedSpans.Add(ctx, int64(count), r.attrs)
+			return true
+		}
+		scopes := rs.ScopeSpans().Len()
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			if r := dropRule(fp.scopes, ss.Scope().Name(), ss.Scope().Attributes(), ""); r != nil {
+				fp.telemetryBuilder.ProcessorRulefilterDroppedSpans.Add(ctx, int64(ss.Spans().Len()), r.attrs)
+				return true
+			}
+			spans := ss.Spans().Len()
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if r := dropRule(fp.spans, span.Name(), span.Attributes(), ""); r != nil {
+					fp.telemetryBuilder.ProcessorRulefilterDroppedSpans.Add(ctx, 1, r.attrs)
+					return true
+				}
+				if len(fp.spanEvents) > 0 {
+					span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+						if r := dropRule(fp.spanEvents, event.Name(), event.Attributes(), ""); r != nil {
+							fp.telemetryBuilder.ProcessorRulefilterDroppedSpanEvents.Add(ctx, 1, r.attrs)
+							return true
+						}
+						return false
+					})
+				}
+				return false
+			})
+			return spans > 0 && ss.Spans().Len() == 0
+		})
+		return scopes > 0 && rs.ScopeSpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (fp *filterProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		if r := dropRule(fp.resources, "", rl.Resource().Attributes(), ""); r != nil {
+			count := 0
+			for i := 0; i < rl.ScopeLogs().Len(); i++ {
+				count += rl.ScopeLogs().At(i).LogRecords().Len()
+			}
+			fp.telemetryBuilder.ProcessorRulefilterDroppedLogRecords.Add(ctx, int64(count), r.attrs)
+			return true
+		}
+		scopes := rl.ScopeLogs().Len()
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			if r := dropRule(fp.scopes, sl.Scope().Name(), sl.Scope().Attributes(), ""); r != nil {
+				fp.telemetryBuilder.ProcessorRulefilterDroppedLogRecords.Add(ctx, int64(sl.LogRecords().Len()), r.attrs)
+				return true
+			}
+			records := sl.LogRecords().Len()
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				if r := dropRule(fp.logRecords, lr.EventName(), lr.Attributes(), severity(lr)); r != nil {
+					fp.telemetryBuilder.ProcessorRulefilterDroppedLogRecords.Add(ctx, 1, r.attrs)
+					return true
+				}
+				return false
+			})
+			return records > 0 && sl.LogRecords().Len() == 0
+		})
+		return scopes > 0 && rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// severity returns the severity text of the log record, or the name of its severity number.
+func severity(lr plog.LogRecord) string {
+	if lr.SeverityText() != "" {
+		return lr.SeverityText()
+	}
+	return lr.SeverityNumber().String()
+}
+
+func (fp *filterProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if r := dropRule(fp.resources, "", rm.Resource().Attributes(), ""); r != nil {
+			count := 0
+			for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+				count += scopeDataPointCount(rm.ScopeMetrics().At(i))
+			}
+			fp.telemetryBuilder.ProcessorRulefilterDroppedMetricPoints.Add(ctx, int64(count), r.attrs)
+			return true
+		}
+		scopes := rm.ScopeMetrics().Len()
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			if r := dropRule(fp.scopes, sm.Scope().Name(), sm.Scope().Attributes(), ""); r != nil {
+				fp.telemetryBuilder.ProcessorRulefilterDroppedMetricPoints.Add(ctx, int64(scopeDataPointCount(sm)), r.attrs)
+				return true
+			}
+			if len(fp.dataPoints) == 0 {
+				return false
+			}
+			metrics := sm.Metrics().Len()
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				dataPoints := dataPointCount(m)
+				fp.filterDataPoints(ctx, m)
+				return dataPoints > 0 && dataPointCount(m) == 0
+			})
+			return metrics > 0 && sm.Metrics().Len() == 0
+		})
+		return scopes > 0 && rm.ScopeMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+func (fp *filterProcessor) filterDataPoints(ctx context.Context, m pmetric.Metric) {
+	drop := func(attrs pcommon.Map) bool {
+		if r := dropRule(fp.dataPoints, m.Name(), attrs, ""); r != nil {
+			fp.telemetryBuilder.ProcessorRulefilterDroppedMetricPoints.Add(ctx, 1, r.attrs)
+			return true
+		}
+		return false
+	}
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return drop(dp.Attributes()) })
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return drop(dp.Attributes()) })
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return drop(dp.Attributes()) })
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return drop(dp.Attributes()) })
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return drop(dp.Attributes()) })
+	}
+}
+
+func scopeDataPointCount(sm pmetric.ScopeMetrics) int {
+	count := 0
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		count += dataPointCount(sm.Metrics().At(i))
+	}
+	return count
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulefilterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/rulefilterprocessor/internal/metadatatest"
+)
+
+func droppedMetric(name, description, unit string, counts map[string]int64) metricdata.Metrics {
+	dataPoints := make([]metricdata.DataPoint[int64], 0, len(counts))
+	for rule, count := range counts {
+		dataPoints = append(dataPoints, metricdata.DataPoint[int64]{
+			Attributes: attribute.NewSet(attribute.String("processor", "rulefilter"), attribute.String("rule", rule)),
+			Value:      count,
+		})
+	}
+	return metricdata.Metrics{
+		Name:        name,
+		Description: description,
+		Unit:        unit,
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  dataPoints,
+		},
+	}
+}
+
+func itemsMetrics(signal string, incoming, outgoing int64) []metricdata.Metrics {
+	attrs := attribute.NewSet(attribute.String("processor", "rulefilter"), attribute.String("otel.signal", signal))
+	sum := func(value int64) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: value}},
+		}
+	}
+	return []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_incoming_items",
+			Description: "Number of items passed to the processor. [alpha]",
+			Unit:        "{items}",
+			Data:        sum(incoming),
+		},
+		{
+			Name:        "otelcol_processor_outgoing_items",
+			Description: "Number of items emitted from the processor. [alpha]",
+			Unit:        "{items}",
+			Data:        sum(outgoing),
+		},
+	}
+}
+
+func TestFilterTraces(t *testing.T) {
+	tel := metadatatest.SetupTelemetry()
+	sink := new(consumertest.TracesSink)
+	cfg := &Config{
+		Resources: []Rule{{
+			Name:       "test_services",
+			Attributes: filter.MustCompileExpression(`deployment.environment == "test"`),
+		}},
+		Scopes: []Rule{{
+			Names: []filter.Config{{Regex: `^internal\.`}},
+		}},
+		Spans: []Rule{{
+			Name:       "health_checks",
+			Names:      []filter.Config{{Strict: "GET /health"}},
+			Attributes: filter.MustCompileExpression(`http.response.status_code < 400`),
+		}},
+		SpanEvents: []Rule{{
+			Action: ActionInclude,
+			Names:  []filter.Config{{Strict: "exception"}},
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+	tp, err := NewFactory().CreateTraces(context.Background(), tel.NewSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("deployment.environment", "test")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Spans().AppendEmpty().SetName("checkout")
+	ss.Spans().AppendEmpty().SetName("payment")
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("deployment.environment", "production")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("internal.telemetry")
+	ss.Spans().AppendEmpty().SetName("export")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("app")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /health")
+	span.Attributes().PutInt("http.response.status_code", 200)
+	span = ss.Spans().AppendEmpty()
+	span.SetName("GET /health")
+	span.Attributes().PutInt("http.response.status_code", 503)
+	span.Events().AppendEmpty().SetName("exception")
+	span.Events().AppendEmpty().SetName("retry")
+	// Empty containers are kept.
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("empty")
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0]
+	require.Equal(t, 1, got.ResourceSpans().Len())
+	scopes := got.ResourceSpans().At(0).ScopeSpans()
+	require.Equal(t, 2, scopes.Len())
+	assert.Equal(t, "app", scopes.At(0).Scope().Name())
+	assert.Equal(t, "empty", scopes.At(1).Scope().Name())
+	require.Equal(t, 1, scopes.At(0).Spans().Len())
+	span = scopes.At(0).Spans().At(0)
+	status, _ := span.Attributes().Get("http.response.status_code")
+	assert.Equal(t, int64(503), status.Int())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "exception", span.Events().At(0).Name())
+
+	tel.AssertMetrics(t, append(itemsMetrics("traces", 5, 1),
+		droppedMetric("otelcol_processor_rulefilter_dropped_spans",
+			"Number of spans dropped by the rule filter processor, per rule.", "{spans}",
+			map[string]int64{"test_services": 2, "scopes[0]": 1, "health_checks": 1}),
+		droppedMetric("otelcol_processor_rulefilter_dropped_span_events",
+			"Number of span events dropped by the rule filter processor, per rule.", "{events}",
+			map[string]int64{"span_events[0]": 1}),
+	), metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestFilterTracesAllDropped(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := &Config{Spans: []Rule{{Action: ActionInclude, Names: []filter.Config{{Strict: "checkout"}}}}}
+	tp, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("payment")
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestFilterLogs(t *testing.T) {
+	tel := metadatatest.SetupTelemetry()
+	sink := new(consumertest.LogsSink)
+	cfg := &Config{
+		Resources: []Rule{{
+			Action:     ActionInclude,
+			Attributes: filter.MustCompileExpression(`exists(service.name)`),
+		}},
+		LogRecords: []Rule{
+			{
+				Name:       "debug",
+				Severities: []filter.Config{{Regex: `(?i)^(trace|debug)`}},
+			},
+			{
+				Name:       "noisy_events",
+				Names:      []filter.Config{{Strict: "cache.miss"}},
+				Attributes: filter.MustCompileExpression(`not exists(error)`),
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	lp, err := NewFactory().CreateLogs(context.Background(), tel.NewSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("no service")
+
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "cart")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	lr := records.AppendEmpty()
+	lr.SetSeverityText("DEBUG")
+	lr = records.AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberTrace2)
+	lr = records.AppendEmpty()
+	lr.SetSeverityText("INFO")
+	lr.SetEventName("cache.miss")
+	lr = records.AppendEmpty()
+	lr.SetSeverityText("ERROR")
+	lr.SetEventName("cache.miss")
+	lr.Attributes().PutStr("error", "timeout")
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllLogs(), 1)
+	got := sink.AllLogs()[0]
+	require.Equal(t, 1, got.LogRecordCount())
+	assert.Equal(t, "ERROR", got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+
+	tel.AssertMetrics(t, append(itemsMetrics("logs", 5, 1),
+		droppedMetric("otelcol_processor_rulefilter_dropped_log_records",
+			"Number of log records dropped by the rule filter processor, per rule.", "{records}",
+			map[string]int64{"resources[0]": 1, "debug": 2, "noisy_events": 1}),
+	), metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestFilterMetrics(t *testing.T) {
+	tel := metadatatest.SetupTelemetry()
+	sink := new(consumertest.MetricsSink)
+	cfg := &Config{
+		Scopes: []Rule{{
+			Name:       "debug_scopes",
+			Attributes: filter.MustCompileExpression(`debug == true`),
+		}},
+		DataPoints: []Rule{{
+			Name:       "idle_cpu",
+			Names:      []filter.Config{{Regex: `^system\.cpu\.`}},
+			Attributes: filter.MustCompileExpression(`state in ["idle", "wait"]`),
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+	mp, err := NewFactory().CreateMetrics(context.Background(), tel.NewSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().Attributes().PutBool("debug", true)
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("debug.gauge")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	m.Gauge().DataPoints().AppendEmpty().SetIntValue(2)
+
+	sm = rm.ScopeMetrics().AppendEmpty()
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("system.cpu.time")
+	dps := m.SetEmptySum().DataPoints()
+	for _, state := range []string{"idle", "user", "wait"} {
+		dps.AppendEmpty().Attributes().PutStr("state", state)
+	}
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("system.cpu.utilization")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("state", "idle")
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("system.memory.usage")
+	m.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("state", "idle")
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	got := sink.AllMetrics()[0]
+	require.Equal(t, 1, got.ResourceMetrics().At(0).ScopeMetrics().Len())
+	metrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "system.cpu.time", metrics.At(0).Name())
+	require.Equal(t, 1, metrics.At(0).Sum().DataPoints().Len())
+	state, _ := metrics.At(0).Sum().DataPoints().At(0).Attributes().Get("state")
+	assert.Equal(t, "user", state.Str())
+	assert.Equal(t, "system.memory.usage", metrics.At(1).Name())
+
+	tel.AssertMetrics(t, append(itemsMetrics("metrics", 7, 2),
+		droppedMetric("otelcol_processor_rulefilter_dropped_metric_points",
+			"Number of metric points dropped by the rule filter processor, per rule.", "{datapoints}",
+			map[string]int64{"debug_scopes": 2, "idle_cpu": 3}),
+	), metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
@@ -0,0 +1,79 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadatatest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/processor/rulefilterprocessor/internal/metadata"
+)
+
+func TestSetupTelemetry(t *testing.T) {
+	testTel := SetupTelemetry()
+	tb, err := metadata.NewTelemetryBuilder(
+		testTel.NewTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, tb)
+	tb.ProcessorRulefilterDroppedLogRecords.Add(context.Background(), 1)
+	tb.ProcessorRulefilterDroppedMetricPoints.Add(context.Background(), 1)
+	tb.ProcessorRulefilterDroppedSpanEvents.Add(context.Background(), 1)
+	tb.ProcessorRulefilterDroppedSpans.Add(context.Background(), 1)
+
+	testTel.AssertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_rulefilter_dropped_log_records",
+			Description: "Number of log records dropped by the rule filter processor, per rule.",
+			Unit:        "{records}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_processor_rulefilter_dropped_metric_points",
+			Description: "Number of metric points dropped by the rule filter processor, per rule.",
+			Unit:        "{datapoints}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_processor_rulefilter_dropped_span_events",
+			Description: "Number of span events dropped by the rule filter processor, per rule.",
+			Unit:        "{events}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_processor_rulefilter_dropped_spans",
+			Description: "Number of spans dropped by the rule filter processor, per rule.",
+			Unit:        "{spans}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{},
+				},
+			},
+		},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+	require.NoError(t, testTel.Shutdown(context.Background()))
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the OTLP file receiver.
+type Config struct {
+	// Directory is the directory of the files written by the OTLP file exporter.
+	Directory string `mapstructure:"directory"`
+	// Speed is the pace of the replay relative to the original pace of the data, computed from its timestamps:
+	// 1 replays the data at its original pace, 10 ten times faster. Zero replays the data as fast as possible.
+	Speed float64 `mapstructure:"speed"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("`directory` must be set")
+	}
+	if cfg.Speed < 0 {
+		return errors.New("`speed` must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package otlpfilereceiver implements a receiver replaying the files written by the OTLP file exporter,
+// at the original pace of the data or faster.
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpfilereceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const defaultSpeed = 1
+
+// NewFactory creates a factory for the OTLP file receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, metadata.TracesStability),
+		receiver.WithMetrics(createMetrics, metadata.MetricsStability),
+		receiver.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Speed: defaultSpeed,
+	}
+}
+
+func newObsReport(set receiver.Settings) (*receiverhelper.ObsReport, error) {
+	return receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              "file",
+		ReceiverCreateSettings: set,
+	})
+}
+
+func createTraces(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	obsrecv, err := newObsReport(set)
+	if err != nil {
+		return nil, err
+	}
+	return newFileReceiver(cfg.(*Config), set.Logger, signal[ptrace.Traces]{
+		prefix:         "traces",
+		protoUnmarshal: (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces,
+		jsonUnmarshal:  (&ptrace.JSONUnmarshaler{}).UnmarshalTraces,
+		count:          ptrace.Traces.SpanCount,
+		consume:        next.ConsumeTraces,
+		startOp:        obsrecv.StartTracesOp,
+		endOp:          obsrecv.EndTracesOp,
+	}), nil
+}
+
+func createMetrics(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	obsrecv, err := newObsReport(set)
+	if err != nil {
+		return nil, err
+	}
+	return newFileReceiver(cfg.(*Config), set.Logger, signal[pmetric.Metrics]{
+		prefix:         "metrics",
+		protoUnmarshal: (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics,
+		jsonUnmarshal:  (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics,
+		count:          pmetric.Metrics.DataPointCount,
+		consume:        next.ConsumeMetrics,
+		startOp:        obsrecv.StartMetricsOp,
+		endOp:          obsrecv.EndMetricsOp,
+	}), nil
+}
+
+func createLogs(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	obsrecv, err := newObsReport(set)
+	if err != nil {
+		return nil, err
+	}
+	return newFileReceiver(cfg.(*Config), set.Logger, signal[plog.Logs]{
+		prefix:         "logs",
+		protoUnmarshal: (&plog.ProtoUnmarshaler{}).UnmarshalLogs,
+		jsonUnmarshal:  (&plog.JSONUnmarshaler{}).UnmarshalLogs,
+		count:          plog.Logs.LogRecordCount,
+		consume:        next.ConsumeLogs,
+		startOp:        obsrecv.StartLogsOp,
+		endOp:          obsrecv.EndLogsOp,
+	}), nil
+}
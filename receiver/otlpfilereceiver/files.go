@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	protoExt = ".pb"
+	jsonExt  = ".jsonl"
+	zstdExt  = ".zst"
+)
+
+// signalFiles returns the files of the signal in the directory, named after prefix: first the rotated files,
+// in the order they were written, then the current files.
+func signalFiles(dir string, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var rotated, current []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), zstdExt)
+		var base string
+		switch {
+		case strings.HasSuffix(name, protoExt):
+			base = strings.TrimSuffix(name, protoExt)
+		case strings.HasSuffix(name, jsonExt):
+			base = strings.TrimSuffix(name, jsonExt)
+		default:
+			continue
+		}
+		switch {
+		case base == prefix:
+			current = append(current, filepath.Join(dir, entry.Name()))
+		case strings.HasPrefix(base, prefix+"-"):
+			rotated = append(rotated, filepath.Join(dir, entry.Name()))
+		}
+	}
+	// The rotated files are named after the time of the rotation, in a format sorted like the time.
+	sort.Strings(rotated)
+	return append(rotated, current...), nil
+}
+
+// errInvalidRecord is returned for a record which cannot be decoded, the following records can still be read.
+var errInvalidRecord = errors.New("invalid record")
+
+// record is the message of an export of the OTLP file exporter, with the time of the export.
+type record struct {
+	captured time.Time
+	data     []byte
+}
+
+// recordReader reads the records of a file written by the OTLP file exporter: OTLP protobuf messages preceded
+// by their capture time as a 8 bytes big endian integer and their size as a 4 bytes big endian integer, or JSON
+// objects on their own lines with the capture time and the OTLP JSON message.
+type recordReader struct {
+	file *os.File
+	zr   *zstd.Decoder
+	r    *bufio.Reader
+	json bool
+}
+
+func openRecords(path string) (*recordReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	rr := &recordReader{
+		file: file,
+		json: strings.HasSuffix(strings.TrimSuffix(path, zstdExt), jsonExt),
+	}
+	var r io.Reader = file
+	if strings.HasSuffix(path, zstdExt) {
+		if rr.zr, err = zstd.NewReader(file); err != nil {
+			return nil, errors.Join(err, file.Close())
+		}
+		r = rr.zr
+	}
+	rr.r = bufio.NewReader(r)
+	return rr, nil
+}
+
+// next returns the next record, or io.EOF once all the records are read.
+func (rr *recordReader) next() (record, error) {
+	if rr.json {
+		for {
+			line, err := rr.r.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				return decodeJSONRecord(line)
+			}
+			if err != nil {
+				return record{}, err
+			}
+		}
+	}
+	var header [12]byte
+	if _, err := io.ReadFull(rr.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return record{}, errors.New("truncated record header")
+		}
+		return record{}, err
+	}
+	captured := time.Unix(0, int64(binary.BigEndian.Uint64(header[:8])))
+	size := binary.BigEndian.Uint32(header[8:])
+	// The record is not allocated upfront, so that a corrupted size fails on the end of the file.
+	buf := &bytes.Buffer{}
+	if _, err := io.CopyN(buf, rr.r, int64(size)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return record{}, fmt.Errorf("truncated record of %d bytes", size)
+		}
+		return record{}, err
+	}
+	return record{captured: captured, data: buf.Bytes()}, nil
+}
+
+func decodeJSONRecord(line []byte) (record, error) {
+	var r struct {
+		CaptureTimeUnixNano int64           `json:"captureTimeUnixNano,string"`
+		Data                json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(line, &r); err != nil {
+		return record{}, fmt.Errorf("%w: %w", errInvalidRecord, err)
+	}
+	return record{captured: time.Unix(0, r.CaptureTimeUnixNano), data: r.Data}, nil
+}
+
+func (rr *recordReader) close() error {
+	if rr.zr != nil {
+		rr.zr.Close()
+	}
+	return rr.file.Close()
+}
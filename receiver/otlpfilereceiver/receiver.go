@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	dataFormatProtobuf = "protobuf"
+	dataFormatJSON     = "json"
+)
+
+// signal defines how the data of a signal is decoded and consumed.
+type signal[T any] struct {
+	// prefix is the name of the files of the signal.
+	prefix         string
+	protoUnmarshal func([]byte) (T, error)
+	jsonUnmarshal  func([]byte) (T, error)
+	count          func(T) int
+	consume        func(context.Context, T) error
+	startOp        func(context.Context) context.Context
+	endOp          func(ctx context.Context, format string, numItems int, err error)
+}
+
+// fileReceiver replays the files of a signal once started, until all the files are read or the receiver is shut down.
+type fileReceiver[T any] struct {
+	cfg    *Config
+	logger *zap.Logger
+	signal signal[T]
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newFileReceiver[T any](cfg *Config, logger *zap.Logger, sig signal[T]) *fileReceiver[T] {
+	return &fileReceiver[T]{
+		cfg:    cfg,
+		logger: logger,
+		signal: sig,
+	}
+}
+
+func (r *fileReceiver[T]) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		r.replay(ctx)
+	}()
+	return nil
+}
+
+func (r *fileReceiver[T]) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+		<-r.done
+	}
+	return nil
+}
+
+func (r *fileReceiver[T]) replay(ctx context.Context) {
+	files, err := signalFiles(r.cfg.Directory, r.signal.prefix)
+	if err != nil {
+		r.logger.Error("Failed to list the files to replay", zap.String("directory", r.cfg.Directory), zap.Error(err))
+		return
+	}
+	p := &pacer{speed: r.cfg.Speed}
+	records := 0
+	for _, path := range files {
+		n, err := r.replayFile(ctx, path, p)
+		records += n
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.logger.Error("Failed to replay the file", zap.String("path", path), zap.Error(err))
+		}
+	}
+	r.logger.Info("Replay completed", zap.String("signal", r.signal.prefix), zap.Int("files", len(files)), zap.Int("records", records))
+}
+
+// replayFile consumes the records of the file, returning the number of replayed records.
+func (r *fileReceiver[T]) replayFile(ctx context.Context, path string, p *pacer) (int, error) {
+	rr, err := openRecords(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rr.close() }()
+	unmarshal, format := r.signal.protoUnmarshal, dataFormatProtobuf
+	if rr.json {
+		unmarshal, format = r.signal.jsonUnmarshal, dataFormatJSON
+	}
+	records := 0
+	for {
+		rec, err := rr.next()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if errors.Is(err, errInvalidRecord) {
+			r.logger.Warn("Failed to decode a record", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		if err != nil {
+			return records, err
+		}
+		data, err := unmarshal(rec.data)
+		if err != nil {
+			// The following records can still be decoded, the framing of the records being intact.
+			r.logger.Warn("Failed to decode a record", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		if err = p.wait(ctx, rec.captured); err != nil {
+			return records, err
+		}
+		opCtx := r.signal.startOp(ctx)
+		err = r.signal.consume(opCtx, data)
+		r.signal.endOp(opCtx, format, r.signal.count(data), err)
+		if err != nil {
+			r.logger.Debug("Failed to consume a record", zap.String("path", path), zap.Error(err))
+		}
+		records++
+	}
+}
+
+// pacer waits between the records for the time elapsed between their exports, divided by the speed.
+type pacer struct {
+	speed float64
+	// last is the latest capture time of the records replayed so far.
+	last time.Time
+}
+
+func (p *pacer) wait(ctx context.Context, captured time.Time) error {
+	if !captured.After(p.last) {
+		// The record was captured before the previous record, it is replayed right away.
+		return ctx.Err()
+	}
+	prev := p.last
+	p.last = captured
+	if p.speed == 0 || prev.IsZero() {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Duration(float64(captured.Sub(prev)) / p.speed)):
+		return nil
+	}
+}
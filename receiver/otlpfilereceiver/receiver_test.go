@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// writeFile writes the records like the OTLP file exporter, with the format and compression of the file extension.
+func writeFile(t *testing.T, path string, records ...record) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+	var zw *zstd.Encoder
+	write := f.Write
+	if filepath.Ext(path) == zstdExt {
+		zw, err = zstd.NewWriter(f)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, zw.Close()) }()
+		write = zw.Write
+	}
+	for _, rec := range records {
+		if filepath.Ext(strings.TrimSuffix(path, zstdExt)) == jsonExt {
+			_, err = write([]byte(fmt.Sprintf(`{"captureTimeUnixNano":"%d","data":%s}`+"\n", rec.captured.UnixNano(), rec.data)))
+		} else {
+			header := binary.BigEndian.AppendUint64(nil, uint64(rec.captured.UnixNano()))
+			header = binary.BigEndian.AppendUint32(header, uint32(len(rec.data)))
+			_, err = write(append(header, rec.data...))
+		}
+		require.NoError(t, err)
+	}
+}
+
+func generateTraces(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+func tracesRecord(t *testing.T, td ptrace.Traces, captured time.Time) record {
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	return record{captured: captured, data: buf}
+}
+
+func newConfig(dir string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = dir
+	cfg.Speed = 0
+	return cfg
+}
+
+func TestReplayTraces(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	first := generateTraces("first")
+	second := generateTraces("second")
+	third := generateTraces("third")
+	// The rotated files are replayed in the order of the rotations, before the current file.
+	writeFile(t, filepath.Join(dir, "traces.pb"), tracesRecord(t, third, now.Add(2*time.Second)))
+	writeFile(t, filepath.Join(dir, "traces-20250101T000001.000000000.pb.zst"), tracesRecord(t, second, now.Add(time.Second)))
+	writeFile(t, filepath.Join(dir, "traces-20250101T000000.000000000.pb"), tracesRecord(t, first, now))
+	writeFile(t, filepath.Join(dir, "traces.txt"), record{data: []byte("not replayed")})
+	writeFile(t, filepath.Join(dir, "tracesold.pb"), tracesRecord(t, first, now))
+
+	sink := new(consumertest.TracesSink)
+	rcv, err := NewFactory().CreateTraces(context.Background(), receivertest.NewNopSettings(), newConfig(dir), sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+
+	require.Eventually(t, func() bool { return len(sink.AllTraces()) == 3 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []ptrace.Traces{first, second, third}, sink.AllTraces())
+}
+
+func TestReplayJSON(t *testing.T) {
+	dir := t.TempDir()
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	mdJSON, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	ldJSON, err := (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	// The records which cannot be decoded are skipped.
+	now := time.Now()
+	writeFile(t, filepath.Join(dir, "metrics.jsonl.zst"),
+		record{captured: now, data: mdJSON},
+		record{captured: now, data: []byte(`{"resourceMetrics": [`)},
+		record{captured: now, data: []byte(`{"resourceMetrics": 1}`)},
+		record{captured: now, data: mdJSON})
+	writeFile(t, filepath.Join(dir, "logs.jsonl"), record{captured: now, data: ldJSON})
+
+	metricsSink := new(consumertest.MetricsSink)
+	metricsRcv, err := NewFactory().CreateMetrics(context.Background(), receivertest.NewNopSettings(), newConfig(dir), metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, metricsRcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, metricsRcv.Shutdown(context.Background())) })
+	logsSink := new(consumertest.LogsSink)
+	logsRcv, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(), newConfig(dir), logsSink)
+	require.NoError(t, err)
+	require.NoError(t, logsRcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, logsRcv.Shutdown(context.Background())) })
+
+	require.Eventually(t, func() bool { return len(metricsSink.AllMetrics()) == 2 && len(logsSink.AllLogs()) == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []pmetric.Metrics{md, md}, metricsSink.AllMetrics())
+	assert.Equal(t, []plog.Logs{ld}, logsSink.AllLogs())
+}
+
+func TestReplayTruncatedFile(t *testing.T) {
+	dir := t.TempDir()
+	rec := tracesRecord(t, generateTraces("first"), time.Now())
+	writeFile(t, filepath.Join(dir, "traces.pb"), rec, rec)
+	// Drop the end of the last record, like after a crash of the exporter.
+	require.NoError(t, os.Truncate(filepath.Join(dir, "traces.pb"), int64(2*(12+len(rec.data))-1)))
+
+	sink := new(consumertest.TracesSink)
+	r := newFileReceiver(newConfig(dir), componenttest.NewNopTelemetrySettings().Logger, signal[ptrace.Traces]{
+		prefix:         "traces",
+		protoUnmarshal: (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces,
+		count:          ptrace.Traces.SpanCount,
+		consume:        sink.ConsumeTraces,
+		startOp:        func(ctx context.Context) context.Context { return ctx },
+		endOp:          func(context.Context, string, int, error) {},
+	})
+	n, err := r.replayFile(context.Background(), filepath.Join(dir, "traces.pb"), &pacer{})
+	require.EqualError(t, err, fmt.Sprintf("truncated record of %d bytes", len(rec.data)))
+	assert.Equal(t, 1, n)
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestReplayPace(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeFile(t, filepath.Join(dir, "traces.pb"),
+		tracesRecord(t, generateTraces("first"), now),
+		tracesRecord(t, generateTraces("second"), now.Add(time.Hour)))
+
+	// The second record, captured one hour after the first one, is replayed one hour later, until the receiver is
+	// shut down.
+	sink := new(consumertest.TracesSink)
+	cfg := newConfig(dir)
+	cfg.Speed = 1
+	rcv, err := NewFactory().CreateTraces(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return len(sink.AllTraces()) == 1 }, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestPacer(t *testing.T) {
+	now := time.Now()
+	p := &pacer{speed: 10}
+	require.NoError(t, p.wait(context.Background(), now))
+
+	// The time elapsed between the captures is divided by the speed.
+	start := time.Now()
+	require.NoError(t, p.wait(context.Background(), now.Add(time.Second)))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// The records captured before the previous record are replayed right away.
+	start = time.Now()
+	require.NoError(t, p.wait(context.Background(), now))
+	require.NoError(t, p.wait(context.Background(), time.Time{}))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// The wait is interrupted by the cancellation of the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, p.wait(ctx, now.Add(time.Hour)), context.Canceled)
+}
@@ -13,8 +13,11 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/xextension/limiter"
 )
 
+var errMemoryLimiterNotFound = errors.New("memory limiter not found")
+
 const (
 	// Protocol values.
 	protoGRPC = "protocols::grpc"
@@ -32,6 +35,21 @@ type HTTPConfig struct {
 
 	// The URL path to receive logs on. If omitted "/v1/logs" will be used.
 	LogsURLPath string `mapstructure:"logs_url_path,omitempty"`
+
+	// EnableNDJSON enables the requests with the "application/x-ndjson" content type on the traces, metrics
+	// and logs URL paths, where every line of the body is a JSON encoded export request.
+	EnableNDJSON bool `mapstructure:"enable_ndjson,omitempty"`
+}
+
+// defaultMaxRequestBodySize is the maximum size of the request bodies applied by confighttp when none is set.
+const defaultMaxRequestBodySize = 20 * 1024 * 1024
+
+// maxRequestBodySize returns the maximum size of the request bodies accepted by the HTTP server, once decompressed.
+func (cfg *HTTPConfig) maxRequestBodySize() int64 {
+	if cfg.MaxRequestBodySize <= 0 {
+		return defaultMaxRequestBodySize
+	}
+	return cfg.MaxRequestBodySize
 }
 
 // Protocols is the configuration for the supported protocols.
@@ -40,10 +58,23 @@ type Protocols struct {
 	HTTP *HTTPConfig              `mapstructure:"http"`
 }
 
+// AdmissionConfig defines how the receiver admits the incoming requests, before reading them.
+type AdmissionConfig struct {
+	// MemoryLimiterID specifies the name of the memory limiter extension consulted before reading every request.
+	MemoryLimiterID *component.ID `mapstructure:"memory_limiter"`
+
+	// MaxInFlightBytes is the size of the requests being processed from which the new requests are refused.
+	// 0 means no limit.
+	MaxInFlightBytes int64 `mapstructure:"max_in_flight_bytes"`
+}
+
 // Config defines configuration for OTLP receiver.
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// Admission is the configuration of the admission of the requests, shared by the protocols.
+	Admission AdmissionConfig `mapstructure:"admission"`
 }
 
 var (
@@ -56,9 +87,29 @@ func (cfg *Config) Validate() error {
 	if cfg.GRPC == nil && cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.Admission.MaxInFlightBytes < 0 {
+		return errors.New("admission::max_in_flight_bytes must be non-negative")
+	}
 	return nil
 }
 
+// getLimiters returns the memory limiter extension, if configured, as a memory limiter, a bytes limiter, or both.
+func (ac AdmissionConfig) getLimiters(extensions map[component.ID]component.Component) (limiter.MemoryLimiter, limiter.BytesLimiter, error) {
+	if ac.MemoryLimiterID == nil {
+		return nil, nil, nil
+	}
+	ext, found := extensions[*ac.MemoryLimiterID]
+	if !found {
+		return nil, nil, fmt.Errorf("failed to resolve memory limiter %q: %w", ac.MemoryLimiterID, errMemoryLimiterNotFound)
+	}
+	memoryLimiter, isMemoryLimiter := ext.(limiter.MemoryLimiter)
+	bytesLimiter, isBytesLimiter := ext.(limiter.BytesLimiter)
+	if !isMemoryLimiter && !isBytesLimiter {
+		return nil, nil, fmt.Errorf("extension %q is not a memory limiter", ac.MemoryLimiterID)
+	}
+	return memoryLimiter, bytesLimiter, nil
+}
+
 // Unmarshal a confmap.Conf into the config struct.
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
 	// first load the config normally
@@ -79,6 +79,7 @@ func TestUnmarshalConfigOnlyHTTPEmptyMap(t *testing.T) {
 }
 
 func TestUnmarshalConfig(t *testing.T) {
+	memoryLimiterID := component.MustNewID("memory_limiter")
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 	factory := NewFactory()
@@ -138,8 +139,13 @@ func TestUnmarshalConfig(t *testing.T) {
 					TracesURLPath:  "/traces",
 					MetricsURLPath: "/v2/metrics",
 					LogsURLPath:    "/log/ingest",
+					EnableNDJSON:   true,
 				},
 			},
+			Admission: AdmissionConfig{
+				MemoryLimiterID:  &memoryLimiterID,
+				MaxInFlightBytes: 64 << 20,
+			},
 		}, cfg)
 }
 
@@ -226,6 +232,42 @@ func TestUnmarshalConfigInvalidSignalPath(t *testing.T) {
 	}
 }
 
+func TestValidateNegativeMaxInFlightBytes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Admission.MaxInFlightBytes = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "admission::max_in_flight_bytes must be non-negative")
+}
+
+func TestGetLimiters(t *testing.T) {
+	memoryLimiterID := component.MustNewID("memory_limiter")
+	memoryLimiter, bytesLimiter, err := AdmissionConfig{}.getLimiters(nil)
+	require.NoError(t, err)
+	assert.Nil(t, memoryLimiter)
+	assert.Nil(t, bytesLimiter)
+
+	ac := AdmissionConfig{MemoryLimiterID: &memoryLimiterID}
+	_, _, err = ac.getLimiters(nil)
+	require.ErrorIs(t, err, errMemoryLimiterNotFound)
+
+	_, _, err = ac.getLimiters(map[component.ID]component.Component{memoryLimiterID: struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}})
+	require.EqualError(t, err, `extension "memory_limiter" is not a memory limiter`)
+
+	ext := &fakeMemoryLimiter{}
+	memoryLimiter, bytesLimiter, err = ac.getLimiters(map[component.ID]component.Component{memoryLimiterID: ext})
+	require.NoError(t, err)
+	assert.Equal(t, ext, memoryLimiter)
+	assert.Nil(t, bytesLimiter)
+
+	bytesExt := &fakeBytesLimiter{}
+	memoryLimiter, bytesLimiter, err = ac.getLimiters(map[component.ID]component.Component{memoryLimiterID: bytesExt})
+	require.NoError(t, err)
+	assert.Nil(t, memoryLimiter)
+	assert.Equal(t, bytesExt, bytesLimiter)
+}
+
 func TestUnmarshalConfigEmpty(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
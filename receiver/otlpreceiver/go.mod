@@ -20,6 +20,7 @@ require (
 	go.opentelemetry.io/collector/consumer/consumererror v0.117.0
 	go.opentelemetry.io/collector/consumer/consumertest v0.117.0
 	go.opentelemetry.io/collector/consumer/xconsumer v0.117.0
+	go.opentelemetry.io/collector/extension/xextension v0.117.0
 	go.opentelemetry.io/collector/internal/sharedcomponent v0.117.0
 	go.opentelemetry.io/collector/pdata v1.23.0
 	go.opentelemetry.io/collector/pdata/pprofile v0.117.0
@@ -138,3 +139,5 @@ retract (
 )
 
 replace go.opentelemetry.io/collector/extension/auth/authtest => ../../extension/auth/authtest
+
+replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
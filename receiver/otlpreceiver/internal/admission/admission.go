@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package admission decides whether the receiver admits the incoming requests, before processing them.
+package admission // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/extension/xextension/limiter"
+)
+
+// RetryDelay is the delay after which the clients can retry the refused requests.
+const RetryDelay = time.Second
+
+var (
+	// ErrMemoryLimit is returned when the memory limiter refuses the requests.
+	ErrMemoryLimit = errors.New("memory limit reached, the request is refused")
+	// ErrInFlightBytesLimit is returned when the requests being processed reach the in-flight bytes limit.
+	ErrInFlightBytesLimit = errors.New("in-flight bytes limit reached, the request is refused")
+)
+
+// Controller admits the requests of a receiver, until the memory limiter refuses them or the size of the requests
+// being processed reaches the in-flight bytes limit. When a bytes limiter is set, the size of every request is
+// also acquired from it before handling the request, and released once the request is handled.
+type Controller struct {
+	memoryLimiter    limiter.MemoryLimiter
+	bytesLimiter     limiter.BytesLimiter
+	maxInFlightBytes int64
+	inFlightBytes    atomic.Int64
+}
+
+// NewController returns a Controller. The memory and bytes limiters are optional, and a maxInFlightBytes of 0
+// means no limit.
+func NewController(memoryLimiter limiter.MemoryLimiter, bytesLimiter limiter.BytesLimiter, maxInFlightBytes int64) *Controller {
+	return &Controller{
+		memoryLimiter:    memoryLimiter,
+		bytesLimiter:     bytesLimiter,
+		maxInFlightBytes: maxInFlightBytes,
+	}
+}
+
+// Admit returns an error if a new request must be refused.
+func (c *Controller) Admit() error {
+	return c.admit(0)
+}
+
+// admit returns an error if a request must be refused, ignoring the own in-flight bytes of the request.
+func (c *Controller) admit(own int64) error {
+	if c.memoryLimiter != nil && c.memoryLimiter.MustRefuse() {
+		return ErrMemoryLimit
+	}
+	if c.maxInFlightBytes > 0 && c.inFlightBytes.Load()-own >= c.maxInFlightBytes {
+		return ErrInFlightBytesLimit
+	}
+	return nil
+}
+
+// InFlightBytes returns the size of the requests being processed.
+func (c *Controller) InFlightBytes() int64 {
+	return c.inFlightBytes.Load()
+}
+
+// GRPCServerOptions returns the options of a gRPC server refusing the requests before handling them, with the
+// RESOURCE_EXHAUSTED code and a RetryInfo of RetryDelay, and tracking the size of the requests being processed.
+// The requests are refused by interceptors rather than before being read, since gRPC drops the details of the
+// statuses of the requests refused before being read.
+func (c *Controller) GRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(&statsHandler{controller: c}),
+		grpc.ChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.ChainStreamInterceptor(c.streamInterceptor),
+	}
+}
+
+// refusedStatus returns the status of a refused RPC. The RetryInfo details let the clients, like the OTLP exporter,
+// retry the RPC after RetryDelay: they do not retry the RESOURCE_EXHAUSTED code without them.
+func refusedStatus(err error) error {
+	st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(RetryDelay),
+	})
+	if detailsErr != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return st.Err()
+}
+
+type rpcBytesKey struct{}
+
+// statsHandler tracks the size of the received messages until the end of their RPC.
+type statsHandler struct {
+	controller *Controller
+}
+
+func (h *statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcBytesKey{}, new(atomic.Int64))
+}
+
+func (h *statsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpcBytes, ok := ctx.Value(rpcBytesKey{}).(*atomic.Int64)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.InPayload:
+		rpcBytes.Add(int64(s.WireLength))
+		h.controller.inFlightBytes.Add(int64(s.WireLength))
+	case *stats.End:
+		h.controller.inFlightBytes.Add(-rpcBytes.Swap(0))
+	}
+}
+
+// unaryInterceptor refuses the RPC or, when a bytes limiter is set, acquires the size of the received message from
+// it before handling the RPC. The message is already read and decoded at this point.
+func (c *Controller) unaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var size int64
+	if rpcBytes, ok := ctx.Value(rpcBytesKey{}).(*atomic.Int64); ok {
+		size = rpcBytes.Load()
+	}
+	if err := c.admit(size); err != nil {
+		return nil, refusedStatus(err)
+	}
+	if c.bytesLimiter == nil {
+		return handler(ctx, req)
+	}
+	release, err := c.bytesLimiter.Acquire(ctx, size)
+	if err != nil {
+		return nil, refusedStatus(err)
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+// streamInterceptor refuses the streaming RPC before its messages are received.
+func (c *Controller) streamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := c.Admit(); err != nil {
+		return refusedStatus(err)
+	}
+	return handler(srv, ss)
+}
+
+func (h *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// HTTPHandler returns a handler refusing the requests before reading them with refuse, and tracking the size
+// of the requests being processed. maxBodySize is the maximum size of the body of the requests, acquired from the
+// bytes limiter for the requests of unknown size until their body is read.
+func (c *Controller) HTTPHandler(next http.Handler, maxBodySize int64, refuse func(http.ResponseWriter, *http.Request, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := c.Admit(); err != nil {
+			refuse(w, r, err)
+			return
+		}
+		if c.bytesLimiter != nil {
+			release, err := c.acquireHTTP(r, maxBodySize)
+			if err != nil {
+				refuse(w, r, err)
+				return
+			}
+			defer release()
+		}
+		body := &countingReader{ReadCloser: r.Body, controller: c}
+		defer func() { c.inFlightBytes.Add(-body.n) }()
+		r.Body = body
+		next.ServeHTTP(w, r)
+	})
+}
+
+// countingReader adds the bytes read from the body of a request to the in-flight bytes.
+type countingReader struct {
+	io.ReadCloser
+	controller *Controller
+	n          int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	r.controller.inFlightBytes.Add(int64(n))
+	return n, err
+}
+
+// acquireHTTP acquires the size of the request from the bytes limiter. When the size is unknown, for instance for
+// chunked or compressed requests, maxBodySize is acquired before reading the body, and released once the body is
+// read to acquire the size of the read body instead.
+func (c *Controller) acquireHTTP(r *http.Request, maxBodySize int64) (func(), error) {
+	if r.ContentLength >= 0 {
+		return c.bytesLimiter.Acquire(r.Context(), r.ContentLength)
+	}
+	releaseMax, err := c.bytesLimiter.Acquire(r.Context(), maxBodySize)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	releaseMax()
+	if err != nil {
+		// Let the handler fail reading the body.
+		r.Body = io.NopCloser(errorReader{err: err})
+		return func() {}, nil
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return c.bytesLimiter.Acquire(r.Context(), int64(len(body)))
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type fakeMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	refuse atomic.Bool
+}
+
+func (ml *fakeMemoryLimiter) MustRefuse() bool {
+	return ml.refuse.Load()
+}
+
+func TestAdmit(t *testing.T) {
+	memoryLimiter := &fakeMemoryLimiter{}
+	c := NewController(memoryLimiter, nil, 10)
+	require.NoError(t, c.Admit())
+
+	memoryLimiter.refuse.Store(true)
+	require.ErrorIs(t, c.Admit(), ErrMemoryLimit)
+	memoryLimiter.refuse.Store(false)
+
+	c.inFlightBytes.Store(10)
+	require.ErrorIs(t, c.Admit(), ErrInFlightBytesLimit)
+
+	// Without limits, the requests are always admitted.
+	c = NewController(nil, nil, 0)
+	c.inFlightBytes.Store(10)
+	require.NoError(t, c.Admit())
+}
+
+func TestHTTPHandler(t *testing.T) {
+	c := NewController(nil, nil, 10)
+	var inFlightBytes int64
+	handler := c.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// The bytes read from the body are in flight until the request is handled.
+		inFlightBytes = c.InFlightBytes()
+		w.WriteHeader(http.StatusOK)
+	}), 100, func(w http.ResponseWriter, _ *http.Request, err error) {
+		assert.ErrorIs(t, err, ErrInFlightBytesLimit)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789ab")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(12), inFlightBytes)
+	assert.Zero(t, c.InFlightBytes())
+
+	c.inFlightBytes.Store(10)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789ab")))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+// assertRefused asserts that the RPC is refused with a status the clients retry after RetryDelay.
+func assertRefused(t *testing.T, err error) {
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, RetryDelay, retryInfo.GetRetryDelay().AsDuration())
+}
+
+func TestGRPC(t *testing.T) {
+	memoryLimiter := &fakeMemoryLimiter{}
+	c := NewController(memoryLimiter, nil, 10)
+	h := &statsHandler{controller: c}
+	handled := func(context.Context, any) (any, error) { return nil, nil }
+	streamHandled := func(any, grpc.ServerStream) error { return nil }
+
+	// The size of the received messages is in flight until the end of the RPC, the own size of a request not
+	// counting towards its admission.
+	ctx := h.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	h.HandleRPC(ctx, &stats.InPayload{WireLength: 12})
+	assert.Equal(t, int64(12), c.InFlightBytes())
+	_, err := c.unaryInterceptor(ctx, nil, nil, handled)
+	require.NoError(t, err)
+	otherCtx := h.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	h.HandleRPC(otherCtx, &stats.InPayload{WireLength: 1})
+	_, err = c.unaryInterceptor(otherCtx, nil, nil, handled)
+	assertRefused(t, err)
+	assertRefused(t, c.streamInterceptor(nil, nil, nil, streamHandled))
+	h.HandleRPC(ctx, &stats.End{})
+	h.HandleRPC(otherCtx, &stats.End{})
+	assert.Zero(t, c.InFlightBytes())
+
+	require.NoError(t, c.streamInterceptor(nil, nil, nil, streamHandled))
+	memoryLimiter.refuse.Store(true)
+	_, err = c.unaryInterceptor(context.Background(), nil, nil, handled)
+	assertRefused(t, err)
+	assertRefused(t, c.streamInterceptor(nil, nil, nil, streamHandled))
+}
+
+type fakeBytesLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	limit    int64
+	inFlight atomic.Int64
+}
+
+func (bl *fakeBytesLimiter) Acquire(_ context.Context, n int64) (func(), error) {
+	if bl.inFlight.Add(n) > bl.limit {
+		bl.inFlight.Add(-n)
+		return nil, errors.New("in-flight bytes limit reached")
+	}
+	return func() { bl.inFlight.Add(-n) }, nil
+}
+
+// acquiredReader records the bytes acquired from the bytes limiter while the body is read.
+type acquiredReader struct {
+	io.Reader
+	bytesLimiter *fakeBytesLimiter
+	acquired     int64
+}
+
+func (r *acquiredReader) Read(p []byte) (int, error) {
+	r.acquired = r.bytesLimiter.inFlight.Load()
+	return r.Reader.Read(p)
+}
+
+func (r *acquiredReader) Close() error {
+	return nil
+}
+
+func TestHTTPHandlerBytesLimiter(t *testing.T) {
+	bytesLimiter := &fakeBytesLimiter{limit: 10}
+	c := NewController(nil, bytesLimiter, 0)
+	var acquired int64
+	handler := c.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(body))
+		// The size of the request is acquired until the request is handled.
+		acquired = bytesLimiter.inFlight.Load()
+		w.WriteHeader(http.StatusOK)
+	}), 10, func(w http.ResponseWriter, _ *http.Request, err error) {
+		assert.EqualError(t, err, "in-flight bytes limit reached")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(10), acquired)
+	assert.Zero(t, bytesLimiter.inFlight.Load())
+
+	// Without a content length, the maximum body size is acquired while reading the body, then the size of the body.
+	acquired = 0
+	body := &acquiredReader{Reader: strings.NewReader("0123456789"), bytesLimiter: bytesLimiter}
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(10), body.acquired)
+	assert.Equal(t, int64(10), acquired)
+	assert.Zero(t, bytesLimiter.inFlight.Load())
+
+	// The request of unknown size is refused before reading its body when the maximum body size cannot be acquired.
+	release, err := bytesLimiter.Acquire(context.Background(), 1)
+	require.NoError(t, err)
+	body = &acquiredReader{Reader: strings.NewReader("0123456789"), bytesLimiter: bytesLimiter}
+	req = httptest.NewRequest(http.MethodPost, "/", body)
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Zero(t, body.acquired)
+	release()
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789ab")))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestGRPCBytesLimiter(t *testing.T) {
+	bytesLimiter := &fakeBytesLimiter{limit: 10}
+	c := NewController(nil, bytesLimiter, 0)
+	require.Len(t, c.GRPCServerOptions(), 3)
+
+	h := &statsHandler{controller: c}
+	ctx := h.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	h.HandleRPC(ctx, &stats.InPayload{WireLength: 7})
+	var acquired int64
+	_, err := c.unaryInterceptor(ctx, nil, nil, func(context.Context, any) (any, error) {
+		acquired = bytesLimiter.inFlight.Load()
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), acquired)
+	assert.Zero(t, bytesLimiter.inFlight.Load())
+
+	h.HandleRPC(ctx, &stats.InPayload{WireLength: 7})
+	_, err = c.unaryInterceptor(ctx, nil, nil, func(context.Context, any) (any, error) {
+		t.Fatal("the request must be refused")
+		return nil, nil
+	})
+	assertRefused(t, err)
+}
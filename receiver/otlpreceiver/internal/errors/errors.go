@@ -4,6 +4,7 @@
 package errors // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 
 import (
+	"errors"
 	"net/http"
 
 	"google.golang.org/grpc/codes"
@@ -56,3 +57,60 @@ func GetHTTPStatusCodeFromStatus(s *status.Status) int {
 		return http.StatusInternalServerError
 	}
 }
+
+// GetRejectedSpans returns the number of rejected spans when the error reports that only part of the
+// numSpans spans were rejected, with consumererror.NewRejected or a permanent consumererror.NewTraces.
+// The data of a retryable consumererror.Traces is not rejected, the client must retry it.
+func GetRejectedSpans(err error, numSpans int) (int, bool) {
+	if rejected, ok := consumererror.RejectedCount(err); ok {
+		return partiallyRejected(rejected, numSpans)
+	}
+	var tracesErr consumererror.Traces
+	if consumererror.IsPermanent(err) && errors.As(err, &tracesErr) {
+		return partiallyRejected(tracesErr.Data().SpanCount(), numSpans)
+	}
+	return 0, false
+}
+
+// GetRejectedDataPoints returns the number of rejected data points when the error reports that only part of the
+// numDataPoints data points were rejected, with consumererror.NewRejected or a permanent consumererror.NewMetrics.
+// The data of a retryable consumererror.Metrics is not rejected, the client must retry it.
+func GetRejectedDataPoints(err error, numDataPoints int) (int, bool) {
+	if rejected, ok := consumererror.RejectedCount(err); ok {
+		return partiallyRejected(rejected, numDataPoints)
+	}
+	var metricsErr consumererror.Metrics
+	if consumererror.IsPermanent(err) && errors.As(err, &metricsErr) {
+		return partiallyRejected(metricsErr.Data().DataPointCount(), numDataPoints)
+	}
+	return 0, false
+}
+
+// GetRejectedLogRecords returns the number of rejected log records when the error reports that only part of the
+// numLogRecords log records were rejected, with consumererror.NewRejected or a permanent consumererror.NewLogs.
+// The data of a retryable consumererror.Logs is not rejected, the client must retry it.
+func GetRejectedLogRecords(err error, numLogRecords int) (int, bool) {
+	if rejected, ok := consumererror.RejectedCount(err); ok {
+		return partiallyRejected(rejected, numLogRecords)
+	}
+	var logsErr consumererror.Logs
+	if consumererror.IsPermanent(err) && errors.As(err, &logsErr) {
+		return partiallyRejected(logsErr.Data().LogRecordCount(), numLogRecords)
+	}
+	return 0, false
+}
+
+// GetRejectedProfiles returns the number of rejected profiles when the error reports that only part of the
+// numProfiles profiles were rejected, with consumererror.NewRejected.
+func GetRejectedProfiles(err error, numProfiles int) (int, bool) {
+	if rejected, ok := consumererror.RejectedCount(err); ok {
+		return partiallyRejected(rejected, numProfiles)
+	}
+	return 0, false
+}
+
+// partiallyRejected returns the number of rejected items, and whether only part of the items were rejected.
+// When all the items are rejected, the request fails instead of partially succeeding.
+func partiallyRejected(rejected int, numItems int) (int, bool) {
+	return rejected, rejected > 0 && rejected < numItems
+}
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 func Test_GetStatusFromError(t *testing.T) {
@@ -94,3 +95,75 @@ func Test_GetHTTPStatusCodeFromStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    error
+		expected int
+		partial  bool
+	}{
+		{
+			name:  "Error",
+			input: errors.New("test"),
+		},
+		{
+			name:     "Rejected",
+			input:    consumererror.NewRejected(errors.New("test"), 1),
+			expected: 1,
+			partial:  true,
+		},
+		{
+			name:     "Rejected all",
+			input:    consumererror.NewRejected(errors.New("test"), 2),
+			expected: 2,
+		},
+		{
+			name:  "Rejected none",
+			input: consumererror.NewRejected(errors.New("test"), 0),
+		},
+		{
+			name:     "Permanent Rejected",
+			input:    consumererror.NewPermanent(consumererror.NewRejected(errors.New("test"), 1)),
+			expected: 1,
+			partial:  true,
+		},
+	}
+	getters := map[string]func(error, int) (int, bool){
+		"spans":       GetRejectedSpans,
+		"data points": GetRejectedDataPoints,
+		"log records": GetRejectedLogRecords,
+		"profiles":    GetRejectedProfiles,
+	}
+	for _, tt := range tests {
+		for name, getter := range getters {
+			t.Run(tt.name+" "+name, func(t *testing.T) {
+				rejected, partial := getter(tt.input, 2)
+				assert.Equal(t, tt.expected, rejected)
+				assert.Equal(t, tt.partial, partial)
+			})
+		}
+	}
+}
+
+func Test_GetRejectedFromData(t *testing.T) {
+	rejected, partial := GetRejectedSpans(consumererror.NewPermanent(consumererror.NewTraces(errors.New("test"), testdata.GenerateTraces(1))), 2)
+	assert.Equal(t, 1, rejected)
+	assert.True(t, partial)
+
+	rejected, partial = GetRejectedDataPoints(consumererror.NewPermanent(consumererror.NewMetrics(errors.New("test"), testdata.GenerateMetrics(1))), 4)
+	assert.Equal(t, 2, rejected)
+	assert.True(t, partial)
+
+	rejected, partial = GetRejectedLogRecords(consumererror.NewPermanent(consumererror.NewLogs(errors.New("test"), testdata.GenerateLogs(1))), 1)
+	assert.Equal(t, 1, rejected)
+	assert.False(t, partial)
+
+	// The data of the retryable errors must be retried, it is not rejected.
+	_, partial = GetRejectedSpans(consumererror.NewTraces(errors.New("test"), testdata.GenerateTraces(1)), 2)
+	assert.False(t, partial)
+	_, partial = GetRejectedDataPoints(consumererror.NewMetrics(errors.New("test"), testdata.GenerateMetrics(1)), 4)
+	assert.False(t, partial)
+	_, partial = GetRejectedLogRecords(consumererror.NewLogs(errors.New("test"), testdata.GenerateLogs(1)), 2)
+	assert.False(t, partial)
+}
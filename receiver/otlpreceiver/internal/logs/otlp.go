@@ -48,6 +48,13 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// A partial failure succeeds, reporting the rejected items to the client which must not retry them.
+		if rejected, ok := errors.GetRejectedLogRecords(err, numSpans); ok {
+			resp := plogotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedLogRecords(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return plogotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
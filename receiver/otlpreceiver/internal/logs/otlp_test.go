@@ -72,6 +72,34 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, plogotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2))
+
+	// Only the data carried by the error is rejected.
+	logClient := makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewLogs(errors.New("my error"), testdata.GenerateLogs(1)))))
+	resp, err := logClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+
+	// The data carried by a retryable error must be retried, it is not rejected.
+	logClient = makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewLogs(errors.New("my error"), testdata.GenerateLogs(1))))
+	_, err = logClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = my error")
+
+	// The rejected count is reported by the error.
+	logClient = makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewRejected(errors.New("my error"), 1)))
+	resp, err = logClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "my error", resp.PartialSuccess().ErrorMessage())
+
+	// The request fails when all the data is rejected.
+	logClient = makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewRejected(errors.New("my error"), 2)))
+	_, err = logClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = my error")
+}
+
 func makeLogsServiceClient(t *testing.T, lc consumer.Logs) plogotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, lc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
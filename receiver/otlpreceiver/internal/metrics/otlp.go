@@ -48,6 +48,13 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// A partial failure succeeds, reporting the rejected items to the client which must not retry them.
+		if rejected, ok := errors.GetRejectedDataPoints(err, dataPointCount); ok {
+			resp := pmetricotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedDataPoints(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return pmetricotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
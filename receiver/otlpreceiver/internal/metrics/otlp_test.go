@@ -72,6 +72,34 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, pmetricotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(2))
+
+	// Only the data carried by the error is rejected.
+	metricsClient := makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewMetrics(errors.New("my error"), testdata.GenerateMetrics(1)))))
+	resp, err := metricsClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+
+	// The data carried by a retryable error must be retried, it is not rejected.
+	metricsClient = makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewMetrics(errors.New("my error"), testdata.GenerateMetrics(1))))
+	_, err = metricsClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = my error")
+
+	// The rejected count is reported by the error.
+	metricsClient = makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewRejected(errors.New("my error"), 1)))
+	resp, err = metricsClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "my error", resp.PartialSuccess().ErrorMessage())
+
+	// The request fails when all the data is rejected.
+	metricsClient = makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewRejected(errors.New("my error"), 4)))
+	_, err = metricsClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = my error")
+}
+
 func makeMetricsServiceClient(t *testing.T, mc consumer.Metrics) pmetricotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, mc)
 
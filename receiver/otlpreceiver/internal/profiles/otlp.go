@@ -41,6 +41,13 @@ func (r *Receiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// A partial failure succeeds, reporting the rejected items to the client which must not retry them.
+		if rejected, ok := errors.GetRejectedProfiles(err, numProfiles); ok {
+			resp := pprofileotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedProfiles(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return pprofileotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
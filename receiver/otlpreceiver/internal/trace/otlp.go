@@ -49,6 +49,13 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// A partial failure succeeds, reporting the rejected items to the client which must not retry them.
+		if rejected, ok := errors.GetRejectedSpans(err, numSpans); ok {
+			resp := ptraceotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedSpans(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return ptraceotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
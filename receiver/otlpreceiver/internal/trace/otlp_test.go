@@ -70,6 +70,34 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2))
+
+	// Only the data carried by the error is rejected.
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewTraces(errors.New("my error"), testdata.GenerateTraces(1)))))
+	resp, err := traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+
+	// The data carried by a retryable error must be retried, it is not rejected.
+	traceClient = makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewTraces(errors.New("my error"), testdata.GenerateTraces(1))))
+	_, err = traceClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = my error")
+
+	// The rejected count is reported by the error.
+	traceClient = makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewRejected(errors.New("my error"), 1)))
+	resp, err = traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "my error", resp.PartialSuccess().ErrorMessage())
+
+	// The request fails when all the data is rejected.
+	traceClient = makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewRejected(errors.New("my error"), 2)))
+	_, err = traceClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = my error")
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	otlperrors "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+)
+
+const (
+	ndjsonContentType = "application/x-ndjson"
+	// ndjsonRetryLineHeader is the header of the response to a NDJSON body which must be retried, giving the number
+	// of the first line to retry. The lines before it were exported and must not be sent again.
+	ndjsonRetryLineHeader = "X-Ndjson-Retry-Line"
+)
+
+// ndjsonResult is the result of the export of the lines of a NDJSON body.
+type ndjsonResult struct {
+	// rejected is the number of items rejected by the exports.
+	rejected int64
+	// errMsgs are the error messages of the lines, prefixed by the line number.
+	errMsgs []string
+	// lines and failed are the number of exported lines, and of the lines which failed entirely.
+	lines  int
+	failed int
+	// err is the error of the first line which failed entirely.
+	err error
+	// retryErr is the error of the line which failed with a retryable error, the lines after it are not exported.
+	retryErr error
+	// retryLine is the number of the line which failed with a retryable error.
+	retryLine int
+}
+
+// ndjsonExportFunc decodes and exports a line, returning the number of rejected items, the error message
+// of a partial success, and the error when the line failed entirely.
+type ndjsonExportFunc func(line []byte) (rejected int64, errMsg string, err error)
+
+func isNDJSONRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && getMimeTypeFromContentType(req.Header.Get("Content-Type")) == ndjsonContentType
+}
+
+// exportNDJSON exports the lines of the body one by one, skipping the empty lines. It stops at the first line failing
+// with a retryable error, so the client retries only this line and the next ones.
+func exportNDJSON(body io.ReadCloser, export ndjsonExportFunc) (ndjsonResult, error) {
+	defer func() { _ = body.Close() }()
+	var res ndjsonResult
+	r := bufio.NewReader(body)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := r.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return res, readErr
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			res.lines++
+			rejected, errMsg, err := export(line)
+			res.rejected += rejected
+			if err != nil {
+				res.failed++
+				errMsg = status.Convert(err).Message()
+				lineErr := status.Error(status.Code(err), fmt.Sprintf("line %d: %s", lineNum, errMsg))
+				if res.err == nil {
+					res.err = lineErr
+				}
+				if isRetryableStatus(status.Convert(err)) {
+					res.retryErr, res.retryLine = lineErr, lineNum
+					return res, nil
+				}
+			}
+			if errMsg != "" {
+				res.errMsgs = append(res.errMsgs, fmt.Sprintf("line %d: %s", lineNum, errMsg))
+			}
+		}
+		if readErr != nil {
+			return res, nil
+		}
+	}
+}
+
+// isRetryableStatus returns whether the client must retry a request failing with the status.
+func isRetryableStatus(s *status.Status) bool {
+	code := otlperrors.GetHTTPStatusCodeFromStatus(s)
+	return code == http.StatusServiceUnavailable || code == http.StatusTooManyRequests
+}
+
+// writeNDJSONResult writes the response of the export of a NDJSON body. The request fails when a line failed with
+// a retryable error, with the number of the line from which the client must retry, or when all the lines failed.
+// Otherwise, the rejected items and the errors of the lines are reported as a partial success.
+func writeNDJSONResult(resp http.ResponseWriter, res ndjsonResult, err error, marshalResponse func(rejected int64, errMsg string) ([]byte, error)) {
+	if err != nil {
+		writeError(resp, jsEncoder, err, http.StatusBadRequest)
+		return
+	}
+	if res.retryErr != nil {
+		resp.Header().Set(ndjsonRetryLineHeader, strconv.Itoa(res.retryLine))
+		writeError(resp, jsEncoder, res.retryErr, http.StatusServiceUnavailable)
+		return
+	}
+	if res.failed > 0 && res.failed == res.lines {
+		writeError(resp, jsEncoder, res.err, http.StatusInternalServerError)
+		return
+	}
+	msg, err := marshalResponse(res.rejected, strings.Join(res.errMsgs, "; "))
+	if err != nil {
+		writeError(resp, jsEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, jsonContentType, http.StatusOK, msg)
+}
+
+// decodeNDJSONLineError returns the error of a line which cannot be decoded.
+func decodeNDJSONLineError(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func handleTracesNDJSON(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
+	res, err := exportNDJSON(req.Body, func(line []byte) (int64, string, error) {
+		otlpReq := ptraceotlp.NewExportRequest()
+		if err := otlpReq.UnmarshalJSON(line); err != nil {
+			return 0, "", decodeNDJSONLineError(err)
+		}
+		otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return int64(otlpReq.Traces().SpanCount()), "", err
+		}
+		return otlpResp.PartialSuccess().RejectedSpans(), otlpResp.PartialSuccess().ErrorMessage(), nil
+	})
+	writeNDJSONResult(resp, res, err, func(rejected int64, errMsg string) ([]byte, error) {
+		otlpResp := ptraceotlp.NewExportResponse()
+		if errMsg != "" {
+			otlpResp.PartialSuccess().SetRejectedSpans(rejected)
+			otlpResp.PartialSuccess().SetErrorMessage(errMsg)
+		}
+		return jsEncoder.marshalTracesResponse(otlpResp)
+	})
+}
+
+func handleMetricsNDJSON(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
+	res, err := exportNDJSON(req.Body, func(line []byte) (int64, string, error) {
+		otlpReq := pmetricotlp.NewExportRequest()
+		if err := otlpReq.UnmarshalJSON(line); err != nil {
+			return 0, "", decodeNDJSONLineError(err)
+		}
+		otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return int64(otlpReq.Metrics().DataPointCount()), "", err
+		}
+		return otlpResp.PartialSuccess().RejectedDataPoints(), otlpResp.PartialSuccess().ErrorMessage(), nil
+	})
+	writeNDJSONResult(resp, res, err, func(rejected int64, errMsg string) ([]byte, error) {
+		otlpResp := pmetricotlp.NewExportResponse()
+		if errMsg != "" {
+			otlpResp.PartialSuccess().SetRejectedDataPoints(rejected)
+			otlpResp.PartialSuccess().SetErrorMessage(errMsg)
+		}
+		return jsEncoder.marshalMetricsResponse(otlpResp)
+	})
+}
+
+func handleLogsNDJSON(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
+	res, err := exportNDJSON(req.Body, func(line []byte) (int64, string, error) {
+		otlpReq := plogotlp.NewExportRequest()
+		if err := otlpReq.UnmarshalJSON(line); err != nil {
+			return 0, "", decodeNDJSONLineError(err)
+		}
+		otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return int64(otlpReq.Logs().LogRecordCount()), "", err
+		}
+		return otlpResp.PartialSuccess().RejectedLogRecords(), otlpResp.PartialSuccess().ErrorMessage(), nil
+	})
+	writeNDJSONResult(resp, res, err, func(rejected int64, errMsg string) ([]byte, error) {
+		otlpResp := plogotlp.NewExportResponse()
+		if errMsg != "" {
+			otlpResp.PartialSuccess().SetRejectedLogRecords(rejected)
+			otlpResp.PartialSuccess().SetErrorMessage(errMsg)
+		}
+		return jsEncoder.marshalLogsResponse(otlpResp)
+	})
+}
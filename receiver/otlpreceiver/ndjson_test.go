@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func newNDJSONReceiver(t *testing.T, enabled bool) (string, *errOrSinkConsumer) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.EnableNDJSON = enabled
+	cfg.GRPC = nil
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+	return addr, sink
+}
+
+func doNDJSONRequest(t *testing.T, url string, encoding string, body []byte) (int, []byte) {
+	req := createHTTPRequest(t, url, encoding, ndjsonContentType, body)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode, respBytes
+}
+
+func TestNDJSONHttp(t *testing.T) {
+	addr, sink := newNDJSONReceiver(t, true)
+
+	for _, encoding := range []string{"", "gzip", "zstd"} {
+		for _, dr := range []dataRequest{generateTracesRequest(t), generateMetricsRequests(t), generateLogsRequest(t)} {
+			t.Run(dr.path+encoding, func(t *testing.T) {
+				sink.Reset()
+				body := bytes.Join([][]byte{dr.jsonBytes, {}, dr.jsonBytes, {}}, []byte("\n"))
+				statusCode, respBytes := doNDJSONRequest(t, "http://"+addr+dr.path, encoding, body)
+				require.Equal(t, http.StatusOK, statusCode)
+				assert.NotContains(t, string(respBytes), "errorMessage")
+				sink.checkData(t, dr.data, 2)
+			})
+		}
+	}
+}
+
+func TestNDJSONHttpLineErrors(t *testing.T) {
+	addr, sink := newNDJSONReceiver(t, true)
+	dr := generateTracesRequest(t)
+	url := "http://" + addr + dr.path
+
+	// The lines which cannot be decoded are reported, the others are accepted.
+	body := bytes.Join([][]byte{dr.jsonBytes, []byte(`{"resourceSpans": [`), dr.jsonBytes}, []byte("\n"))
+	statusCode, respBytes := doNDJSONRequest(t, url, "", body)
+	require.Equal(t, http.StatusOK, statusCode)
+	tr := ptraceotlp.NewExportResponse()
+	require.NoError(t, tr.UnmarshalJSON(respBytes))
+	assert.EqualValues(t, 0, tr.PartialSuccess().RejectedSpans())
+	assert.True(t, strings.HasPrefix(tr.PartialSuccess().ErrorMessage(), "line 2: "), tr.PartialSuccess().ErrorMessage())
+	sink.checkData(t, dr.data, 2)
+
+	// The request fails with the error of the first line when all the lines fail.
+	sink.Reset()
+	sink.SetConsumeError(errors.New("my error"))
+	statusCode, respBytes = doNDJSONRequest(t, url, "", bytes.Join([][]byte{dr.jsonBytes, dr.jsonBytes}, []byte("\n")))
+	require.Equal(t, http.StatusServiceUnavailable, statusCode)
+	errStatus := &spb.Status{}
+	require.NoError(t, json.Unmarshal(respBytes, errStatus))
+	assert.EqualValues(t, codes.Unavailable, errStatus.Code)
+	assert.Equal(t, "line 1: my error", errStatus.Message)
+	sink.checkData(t, dr.data, 0)
+}
+
+func TestNDJSONRetryableLineError(t *testing.T) {
+	var exported []int
+	res, err := exportNDJSON(io.NopCloser(strings.NewReader("{}\n{}\n\n{}\n{}\n")), func([]byte) (int64, string, error) {
+		exported = append(exported, len(exported)+1)
+		switch len(exported) {
+		case 2:
+			return 1, "", status.Error(codes.InvalidArgument, "bad data")
+		case 3:
+			return 1, "", status.Error(codes.Unavailable, "try later")
+		}
+		return 0, "", nil
+	})
+	require.NoError(t, err)
+	// The lines after the line to retry are not exported.
+	assert.Equal(t, []int{1, 2, 3}, exported)
+
+	// The request fails when a line must be retried, even if other lines succeeded.
+	rec := httptest.NewRecorder()
+	writeNDJSONResult(rec, res, nil, func(int64, string) ([]byte, error) {
+		return nil, errors.New("the partial success must not be reported")
+	})
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "4", rec.Header().Get(ndjsonRetryLineHeader))
+	errStatus := &spb.Status{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), errStatus))
+	assert.EqualValues(t, codes.Unavailable, errStatus.Code)
+	assert.Equal(t, "line 4: try later", errStatus.Message)
+}
+
+func TestNDJSONRetryableLineBetweenSuccesses(t *testing.T) {
+	var exported []string
+	res, err := exportNDJSON(io.NopCloser(strings.NewReader("first\nsecond\nthird\n")), func(line []byte) (int64, string, error) {
+		exported = append(exported, string(line))
+		if string(line) == "second" {
+			return 1, "", status.Error(codes.ResourceExhausted, "slow down")
+		}
+		return 0, "", nil
+	})
+	require.NoError(t, err)
+	// The third line is not exported, the client retries the second and the third lines without the first one.
+	assert.Equal(t, []string{"first", "second"}, exported)
+
+	rec := httptest.NewRecorder()
+	writeNDJSONResult(rec, res, nil, func(int64, string) ([]byte, error) {
+		return nil, errors.New("the partial success must not be reported")
+	})
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(ndjsonRetryLineHeader))
+}
+
+func TestNDJSONHttpDisabled(t *testing.T) {
+	addr, sink := newNDJSONReceiver(t, false)
+	dr := generateTracesRequest(t)
+
+	statusCode, _ := doNDJSONRequest(t, "http://"+addr+dr.path, "", dr.jsonBytes)
+	assert.Equal(t, http.StatusUnsupportedMediaType, statusCode)
+	sink.checkData(t, dr.data, 0)
+}
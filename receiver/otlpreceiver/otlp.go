@@ -15,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
@@ -23,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
@@ -45,6 +47,9 @@ type otlpReceiver struct {
 	obsrepGRPC *receiverhelper.ObsReport
 	obsrepHTTP *receiverhelper.ObsReport
 
+	// admission is nil if the requests are admitted unconditionally.
+	admission *admission.Controller
+
 	settings *receiver.Settings
 }
 
@@ -88,8 +93,15 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 		return nil
 	}
 
+	var opts []configgrpc.ToServerOption
+	if r.admission != nil {
+		for _, opt := range r.admission.GRPCServerOptions() {
+			opts = append(opts, configgrpc.WithGrpcServerOption(opt))
+		}
+	}
+
 	var err error
-	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings); err != nil {
+	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings, opts...); err != nil {
 		return err
 	}
 
@@ -136,6 +148,10 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.nextTraces != nil {
 		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
+			if r.cfg.HTTP.EnableNDJSON && isNDJSONRequest(req) {
+				handleTracesNDJSON(resp, req, httpTracesReceiver)
+				return
+			}
 			handleTraces(resp, req, httpTracesReceiver)
 		})
 	}
@@ -143,6 +159,10 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.nextMetrics != nil {
 		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
+			if r.cfg.HTTP.EnableNDJSON && isNDJSONRequest(req) {
+				handleMetricsNDJSON(resp, req, httpMetricsReceiver)
+				return
+			}
 			handleMetrics(resp, req, httpMetricsReceiver)
 		})
 	}
@@ -150,6 +170,10 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.nextLogs != nil {
 		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
+			if r.cfg.HTTP.EnableNDJSON && isNDJSONRequest(req) {
+				handleLogsNDJSON(resp, req, httpLogsReceiver)
+				return
+			}
 			handleLogs(resp, req, httpLogsReceiver)
 		})
 	}
@@ -161,8 +185,13 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 		})
 	}
 
+	var handler http.Handler = httpMux
+	if r.admission != nil {
+		handler = r.admission.HTTPHandler(httpMux, r.cfg.HTTP.maxRequestBodySize(), refuseRequest)
+	}
+
 	var err error
-	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, httpMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
+	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, handler, confighttp.WithErrorHandler(errorHandler)); err != nil {
 		return err
 	}
 
@@ -186,6 +215,13 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.cfg.Admission.MemoryLimiterID != nil || r.cfg.Admission.MaxInFlightBytes > 0 {
+		memoryLimiter, bytesLimiter, err := r.cfg.Admission.getLimiters(host.GetExtensions())
+		if err != nil {
+			return err
+		}
+		r.admission = admission.NewController(memoryLimiter, bytesLimiter, r.cfg.Admission.MaxInFlightBytes)
+	}
 	if err := r.startGRPCServer(host); err != nil {
 		return err
 	}
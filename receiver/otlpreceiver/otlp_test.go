@@ -15,12 +15,14 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -749,6 +751,100 @@ func TestGRPCMaxRecvSize(t *testing.T) {
 	assert.Equal(t, td, sink.AllTraces()[0])
 }
 
+func TestAdmissionMemoryLimiter(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	memoryLimiterID := component.MustNewID("memory_limiter")
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.Admission.MemoryLimiterID = &memoryLimiterID
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.ErrorIs(t, recv.Start(context.Background(), componenttest.NewNopHost()), errMemoryLimiterNotFound)
+
+	memoryLimiter := &fakeMemoryLimiter{}
+	host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{memoryLimiterID: memoryLimiter}}
+	recv = newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	td := testdata.GenerateTraces(2)
+	tdProto, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	require.NoError(t, exportTraces(cc, td))
+	doHTTPRequest(t, "http://"+httpAddr+defaultTracesURLPath, "", pbContentType, tdProto, http.StatusOK)
+	assert.Len(t, sink.AllTraces(), 2)
+
+	// The requests are refused without being consumed once the memory limiter refuses data.
+	memoryLimiter.refuse.Store(true)
+	st := status.Convert(exportTraces(cc, td))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, time.Second, retryInfo.GetRetryDelay().AsDuration())
+
+	resp, err := http.Post("http://"+httpAddr+defaultTracesURLPath, pbContentType, bytes.NewReader(tdProto))
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	errStatus := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+	assert.Equal(t, int32(codes.ResourceExhausted), errStatus.Code)
+	assert.Equal(t, "memory limit reached, the request is refused", errStatus.Message)
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
+func TestAdmissionBytesLimiter(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	memoryLimiterID := component.MustNewID("memory_limiter")
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.Admission.MemoryLimiterID = &memoryLimiterID
+	sink := newErrOrSinkConsumer()
+
+	bytesLimiter := &fakeBytesLimiter{}
+	host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{memoryLimiterID: bytesLimiter}}
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	td := testdata.GenerateTraces(2)
+	tdProto, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	require.NoError(t, exportTraces(cc, td))
+	doHTTPRequest(t, "http://"+httpAddr+defaultTracesURLPath, "", pbContentType, tdProto, http.StatusOK)
+	assert.Len(t, sink.AllTraces(), 2)
+	// The size of the requests is acquired, and released once the pipeline returns.
+	bytesLimiter.mu.Lock()
+	assert.Len(t, bytesLimiter.acquired, 2)
+	assert.Equal(t, int64(len(tdProto)), bytesLimiter.acquired[1])
+	assert.Zero(t, bytesLimiter.inFlight)
+	bytesLimiter.refuse = true
+	bytesLimiter.mu.Unlock()
+
+	// The requests are refused without being consumed once the bytes limiter refuses them.
+	assert.Equal(t, codes.ResourceExhausted, status.Code(exportTraces(cc, td)))
+	doHTTPRequest(t, "http://"+httpAddr+defaultTracesURLPath, "", pbContentType, tdProto, http.StatusTooManyRequests)
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
 func TestHTTPInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		Protocols: Protocols{
@@ -1104,6 +1200,50 @@ loop:
 	close(doneSignal)
 }
 
+type fakeMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	refuse atomic.Bool
+}
+
+func (ml *fakeMemoryLimiter) MustRefuse() bool {
+	return ml.refuse.Load()
+}
+
+type fakeBytesLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu       sync.Mutex
+	refuse   bool
+	acquired []int64
+	inFlight int64
+}
+
+func (bl *fakeBytesLimiter) Acquire(_ context.Context, n int64) (func(), error) {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	if bl.refuse {
+		return nil, errors.New("in-flight bytes limit reached")
+	}
+	bl.acquired = append(bl.acquired, n)
+	bl.inFlight += n
+	return func() {
+		bl.mu.Lock()
+		defer bl.mu.Unlock()
+		bl.inFlight -= n
+	}, nil
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
 func exportTraces(cc *grpc.ClientConn, td ptrace.Traces) error {
 	acc := ptraceotlp.NewGRPCClient(cc)
 	req := ptraceotlp.NewExportRequestFromTraces(td)
@@ -8,11 +8,14 @@ import (
 	"io"
 	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/internal/httphelper"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
@@ -205,6 +208,17 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	writeResponse(w, fallbackContentType, http.StatusInternalServerError, fallbackMsg)
 }
 
+// refuseRequest answers the requests refused before being read with the 429 status code and a Retry-After header,
+// so that the clients retry them later.
+func refuseRequest(w http.ResponseWriter, r *http.Request, err error) {
+	var enc encoder = jsEncoder
+	if getMimeTypeFromContentType(r.Header.Get("Content-Type")) == pbContentType {
+		enc = pbEncoder
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(admission.RetryDelay/time.Second)))
+	writeStatusResponse(w, enc, http.StatusTooManyRequests, httphelper.NewStatusFromMsgAndHTTPCode(err.Error(), http.StatusTooManyRequests).Proto())
+}
+
 func writeStatusResponse(w http.ResponseWriter, enc encoder, statusCode int, rsp *spb.Status) {
 	msg, err := enc.marshalStatus(rsp)
 	if err != nil {
@@ -31,6 +31,7 @@ require (
 	go.opentelemetry.io/collector/extension/zpagesextension v0.117.0
 	go.opentelemetry.io/collector/featuregate v1.23.0
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.117.0
+	go.opentelemetry.io/collector/internal/sharedcomponent v0.117.0
 	go.opentelemetry.io/collector/pdata v1.23.0
 	go.opentelemetry.io/collector/pdata/pprofile v0.117.0
 	go.opentelemetry.io/collector/pdata/testdata v0.117.0
@@ -211,3 +212,5 @@ replace go.opentelemetry.io/collector/extension/extensiontest => ../extension/ex
 replace go.opentelemetry.io/collector/extension/auth/authtest => ../extension/auth/authtest
 
 replace go.opentelemetry.io/collector/extension/xextension => ../extension/xextension
+
+replace go.opentelemetry.io/collector/internal/sharedcomponent => ../internal/sharedcomponent
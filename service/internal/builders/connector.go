@@ -376,6 +376,11 @@ func (b *ConnectorBuilder) IsConfigured(componentID component.ID) bool {
 	return ok
 }
 
+// Config returns the configuration of the given component ID, or nil if the component is not configured.
+func (b *ConnectorBuilder) Config(componentID component.ID) component.Config {
+	return b.cfgs[componentID]
+}
+
 func (b *ConnectorBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -94,6 +94,11 @@ func (b *ExporterBuilder) CreateProfiles(ctx context.Context, set exporter.Setti
 	return f.CreateProfiles(ctx, set, cfg)
 }
 
+// Config returns the configuration of the given component ID, or nil if the component is not configured.
+func (b *ExporterBuilder) Config(componentID component.ID) component.Config {
+	return b.cfgs[componentID]
+}
+
 func (b *ExporterBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -52,6 +52,11 @@ func (b *ExtensionBuilder) Create(ctx context.Context, set extension.Settings) (
 	return f.Create(ctx, set, cfg)
 }
 
+// Config returns the configuration of the given component ID, or nil if the component is not configured.
+func (b *ExtensionBuilder) Config(componentID component.ID) component.Config {
+	return b.cfgs[componentID]
+}
+
 func (b *ExtensionBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -108,6 +108,11 @@ func (b *ProcessorBuilder) CreateProfiles(ctx context.Context, set processor.Set
 	return f.CreateProfiles(ctx, set, cfg, next)
 }
 
+// Config returns the configuration of the given component ID, or nil if the component is not configured.
+func (b *ProcessorBuilder) Config(componentID component.ID) component.Config {
+	return b.cfgs[componentID]
+}
+
 func (b *ProcessorBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -110,6 +110,11 @@ func (b *ReceiverBuilder) CreateProfiles(ctx context.Context, set receiver.Setti
 	return f.CreateProfiles(ctx, set, cfg, next)
 }
 
+// Config returns the configuration of the given component ID, or nil if the component is not configured.
+func (b *ReceiverBuilder) Config(componentID component.ID) component.Config {
+	return b.cfgs[componentID]
+}
+
 func (b *ReceiverBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -10,12 +10,17 @@
 // [Graph.StartAll] starts all components in each pipeline.
 //
 // [Graph.ShutdownAll] stops all components in each pipeline.
+//
+// [Graph.Reload] updates a running graph to a new configuration, only restarting the components
+// whose configuration or wiring changed.
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 
 	"go.uber.org/multierr"
@@ -66,16 +71,36 @@ type Graph struct {
 	instanceIDs map[int64]*componentstatus.InstanceID
 
 	telemetry component.TelemetrySettings
+
+	// Keep track of the settings used to build the graph, so that a reload can tell which components changed.
+	settings Settings
+
+	// stopped holds the nodes whose components were already shut down by a failed reload.
+	stopped map[int64]struct{}
 }
 
+// ErrPartialReload is returned by Graph.Reload when the reload failed after shutting down some of the running
+// components. Only the components kept from the previous graph are still running, the graph must be shut down.
+var ErrPartialReload = errors.New("the pipelines were partially reloaded")
+
 // Build builds a full pipeline graph.
 // Build also validates the configuration of the pipelines and does the actual initialization of each Component in the Graph.
 func Build(ctx context.Context, set Settings) (*Graph, error) {
+	pipelines, err := newGraph(set)
+	if err != nil {
+		return nil, err
+	}
+	return pipelines, pipelines.buildComponents(ctx, set, nil, nil)
+}
+
+// newGraph creates the nodes and edges of the graph described by set, without instantiating any component.
+func newGraph(set Settings) (*Graph, error) {
 	pipelines := &Graph{
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
 		telemetry:      set.Telemetry,
+		settings:       set,
 	}
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
@@ -87,7 +112,7 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		return nil, err
 	}
 	pipelines.createEdges()
-	return pipelines, pipelines.buildComponents(ctx, set)
+	return pipelines, nil
 }
 
 // Creates a node for each instance of a component and adds it to the graph.
@@ -277,7 +302,8 @@ func (g *Graph) createEdges() {
 // Uses the already built graph g to instantiate the actual components for each component of each pipeline.
 // Handles calling the factories for each component - and hooking up each component to the next.
 // Also calculates whether each pipeline mutates data so the receiver can know whether it needs to clone the data.
-func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
+// Nodes listed in reused are not instantiated, instead they take over the component of the same node in prev.
+func (g *Graph) buildComponents(ctx context.Context, set Settings, prev *Graph, reused map[int64]struct{}) error {
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return cycleErr(err, topo.DirectedCyclesIn(g.componentGraph))
@@ -286,6 +312,12 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
 
+		if _, ok := reused[node.ID()]; ok {
+			reuseNode(node, prev.componentGraph.Node(node.ID()))
+			g.instanceIDs[node.ID()] = prev.instanceIDs[node.ID()]
+			continue
+		}
+
 		switch n := node.(type) {
 		case *receiverNode:
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
@@ -398,6 +430,11 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 		return err
 	}
 
+	return g.startNodes(ctx, host, nodes, nil)
+}
+
+// startNodes starts the components of the given topologically sorted nodes, except the ones listed in skip.
+func (g *Graph) startNodes(ctx context.Context, host *Host, nodes []graph.Node, skip map[int64]struct{}) error {
 	// Start in reverse topological order so that downstream components
 	// are started before upstream components. This ensures that each
 	// component's consumer is ready to consume.
@@ -409,6 +446,9 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 			// Skip capabilities/fanout nodes
 			continue
 		}
+		if _, ok = skip[node.ID()]; ok {
+			continue
+		}
 
 		instanceID := g.instanceIDs[node.ID()]
 		host.Reporter.ReportStatus(
@@ -442,6 +482,11 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 		return err
 	}
 
+	return g.shutdownNodes(ctx, reporter, nodes, g.stopped)
+}
+
+// shutdownNodes stops the components of the given topologically sorted nodes, except the ones listed in skip.
+func (g *Graph) shutdownNodes(ctx context.Context, reporter status.Reporter, nodes []graph.Node, skip map[int64]struct{}) error {
 	// Stop in topological order so that upstream components
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
@@ -455,6 +500,9 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 			// Skip capabilities/fanout nodes
 			continue
 		}
+		if _, ok = skip[node.ID()]; ok {
+			continue
+		}
 
 		instanceID := g.instanceIDs[node.ID()]
 		reporter.ReportStatus(
@@ -479,6 +527,222 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 	return errs
 }
 
+// Reload updates the running graph g to match the given settings.
+//
+// Components of g whose configuration did not change, and whose downstream components are all kept as well,
+// continue running as part of the updated graph. All other components of g are shut down, then the new and
+// changed components are started. Since components hold a reference to their next consumer, a change to a
+// component also restarts every component upstream of it in the same pipelines.
+//
+// If the new graph cannot be built, g is left untouched and its components keep running. If the reload fails
+// afterwards, the new components are shut down, g is left with the components kept from the previous graph,
+// and an error wrapping ErrPartialReload is returned.
+func (g *Graph) Reload(ctx context.Context, set Settings, host *Host) error {
+	if host == nil {
+		return errors.New("host cannot be nil")
+	}
+
+	ng, err := newGraph(set)
+	if err != nil {
+		return err
+	}
+	nodes, err := topo.Sort(ng.componentGraph)
+	if err != nil {
+		return cycleErr(err, topo.DirectedCyclesIn(ng.componentGraph))
+	}
+	reused := ng.reusableNodes(g, nodes)
+	if err = ng.buildComponents(ctx, set, g, reused); err != nil {
+		return err
+	}
+
+	prevNodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return err
+	}
+	g.telemetry.Logger.Info("Reloading pipelines",
+		zap.Int("kept", countComponents(nodes, reused)),
+		zap.Int("started", countComponents(nodes, nil)-countComponents(nodes, reused)),
+	)
+	if err = g.shutdownNodes(ctx, host.Reporter, prevNodes, reused); err != nil {
+		err = fmt.Errorf("failed to shutdown the retiring components: %w", err)
+	} else if err = ng.startNodes(ctx, host, nodes, reused); err != nil {
+		err = fmt.Errorf("failed to start the new components: %w", err)
+	}
+	if err != nil {
+		// The components of the previous graph not kept are shut down, so ng takes over the kept ones, without
+		// the new ones.
+		if shutdownErr := ng.shutdownNodes(ctx, host.Reporter, nodes, reused); shutdownErr != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to shutdown the new components: %w", shutdownErr))
+		}
+		ng.stopped = make(map[int64]struct{})
+		for _, node := range nodes {
+			if _, ok := reused[node.ID()]; !ok {
+				ng.stopped[node.ID()] = struct{}{}
+			}
+		}
+		*g = *ng
+		return fmt.Errorf("%w: %w", ErrPartialReload, err)
+	}
+	*g = *ng
+	return nil
+}
+
+// reusableNodes returns the IDs of the nodes of g that can take over the running component of the same node in prev.
+// A node can be reused if its configuration did not change, and its downstream nodes are the same and all reused.
+// The nodes must be topologically sorted.
+//
+// Components such as the OTLP receiver share one instance between the nodes of all the signals or pipelines using
+// them, and the instance can only be shut down once. The nodes of a component are therefore reused together, only
+// if they are the same nodes as in prev and they can all be reused, otherwise they are all restarted.
+func (g *Graph) reusableNodes(prev *Graph, nodes []graph.Node) map[int64]struct{} {
+	components := componentNodes(g.componentGraph)
+	prevComponents := componentNodes(prev.componentGraph)
+	restarted := make(map[componentKey]struct{})
+	for key, ids := range components {
+		if !slices.Equal(ids, prevComponents[key]) {
+			restarted[key] = struct{}{}
+		}
+	}
+	for {
+		reused := g.reusableNodesExcept(prev, nodes, restarted)
+		done := true
+		for key, ids := range components {
+			if _, ok := restarted[key]; ok {
+				continue
+			}
+			for _, id := range ids {
+				if _, ok := reused[id]; !ok {
+					restarted[key] = struct{}{}
+					done = false
+					break
+				}
+			}
+		}
+		if done {
+			return reused
+		}
+	}
+}
+
+// reusableNodesExcept returns the nodes of g that can be reused, except the nodes of the restarted components.
+func (g *Graph) reusableNodesExcept(prev *Graph, nodes []graph.Node, restarted map[componentKey]struct{}) map[int64]struct{} {
+	reused := make(map[int64]struct{})
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		if key, ok := componentKeyOf(node); ok {
+			if _, ok = restarted[key]; ok {
+				continue
+			}
+		}
+		prevNode := prev.componentGraph.Node(node.ID())
+		if prevNode == nil || reflect.TypeOf(prevNode) != reflect.TypeOf(node) {
+			continue
+		}
+		if !reflect.DeepEqual(g.componentConfig(node), prev.componentConfig(prevNode)) {
+			continue
+		}
+
+		nexts := g.componentGraph.From(node.ID())
+		if nexts.Len() != prev.componentGraph.From(node.ID()).Len() {
+			continue
+		}
+		sameNexts := true
+		for nexts.Next() {
+			nextID := nexts.Node().ID()
+			_, nextReused := reused[nextID]
+			if !nextReused || !prev.componentGraph.HasEdgeFromTo(node.ID(), nextID) {
+				sameNexts = false
+				break
+			}
+		}
+		if sameNexts {
+			reused[node.ID()] = struct{}{}
+		}
+	}
+	return reused
+}
+
+// componentKey identifies a configured component, which can be used by the nodes of several pipelines or signals.
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
+}
+
+func componentKeyOf(node graph.Node) (componentKey, bool) {
+	switch n := node.(type) {
+	case *receiverNode:
+		return componentKey{kind: component.KindReceiver, id: n.componentID}, true
+	case *processorNode:
+		return componentKey{kind: component.KindProcessor, id: n.componentID}, true
+	case *exporterNode:
+		return componentKey{kind: component.KindExporter, id: n.componentID}, true
+	case *connectorNode:
+		return componentKey{kind: component.KindConnector, id: n.componentID}, true
+	}
+	return componentKey{}, false
+}
+
+// componentNodes returns the sorted IDs of the nodes of every component of the graph.
+func componentNodes(g *simple.DirectedGraph) map[componentKey][]int64 {
+	components := make(map[componentKey][]int64)
+	for it := g.Nodes(); it.Next(); {
+		if key, ok := componentKeyOf(it.Node()); ok {
+			components[key] = append(components[key], it.Node().ID())
+		}
+	}
+	for _, ids := range components {
+		slices.Sort(ids)
+	}
+	return components
+}
+
+// componentConfig returns the configuration used to build the component of the node, or nil for nodes without one.
+func (g *Graph) componentConfig(node graph.Node) component.Config {
+	switch n := node.(type) {
+	case *receiverNode:
+		return g.settings.ReceiverBuilder.Config(n.componentID)
+	case *processorNode:
+		return g.settings.ProcessorBuilder.Config(n.componentID)
+	case *exporterNode:
+		return g.settings.ExporterBuilder.Config(n.componentID)
+	case *connectorNode:
+		return g.settings.ConnectorBuilder.Config(n.componentID)
+	}
+	return nil
+}
+
+// reuseNode makes node take over the component or consumer built for prev.
+func reuseNode(node, prev graph.Node) {
+	switch n := node.(type) {
+	case *receiverNode:
+		n.Component = prev.(*receiverNode).Component
+	case *processorNode:
+		n.Component = prev.(*processorNode).Component
+	case *exporterNode:
+		n.Component = prev.(*exporterNode).Component
+	case *connectorNode:
+		n.Component = prev.(*connectorNode).Component
+	case *capabilitiesNode:
+		*n = *prev.(*capabilitiesNode)
+	case *fanOutNode:
+		*n = *prev.(*fanOutNode)
+	}
+}
+
+func countComponents(nodes []graph.Node, only map[int64]struct{}) int {
+	count := 0
+	for _, node := range nodes {
+		if _, ok := node.(component.Component); !ok {
+			continue
+		}
+		if _, ok := only[node.ID()]; only != nil && !ok {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
 func (g *Graph) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
 	exportersMap := make(map[pipeline.Signal]map[component.ID]component.Component)
 	exportersMap[pipeline.SignalTraces] = make(map[component.ID]component.Component)
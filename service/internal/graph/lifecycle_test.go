@@ -6,6 +6,7 @@ package graph
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,8 +18,11 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
@@ -27,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/status/statustest"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -438,3 +443,388 @@ func TestStatusReportedOnStartupShutdown(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphReload(t *testing.T) {
+	type exampleConfig struct {
+		Value string
+	}
+
+	rcvrAID := component.MustNewIDWithName("examplereceiver", "a")
+	rcvrBID := component.MustNewIDWithName("examplereceiver", "b")
+	rcvrCID := component.MustNewIDWithName("examplereceiver", "c")
+	procID := component.MustNewID("exampleprocessor")
+	expAID := component.MustNewIDWithName("exampleexporter", "a")
+	expBID := component.MustNewIDWithName("exampleexporter", "b")
+
+	tracesAID := pipeline.NewIDWithName(pipeline.SignalTraces, "a")
+	tracesBID := pipeline.NewIDWithName(pipeline.SignalTraces, "b")
+	tracesCID := pipeline.NewIDWithName(pipeline.SignalTraces, "c")
+
+	// Configs are created for each settings to make sure that equal configs are compared by value.
+	newSettings := func(expBValue string, pipelineConfigs pipelines.Config) Settings {
+		return Settings{
+			Telemetry: componenttest.NewNopTelemetrySettings(),
+			BuildInfo: component.NewDefaultBuildInfo(),
+			ReceiverBuilder: builders.NewReceiver(
+				map[component.ID]component.Config{
+					rcvrAID: &exampleConfig{Value: "a"},
+					rcvrBID: &exampleConfig{Value: "b"},
+					rcvrCID: &exampleConfig{Value: "c"},
+				},
+				map[component.Type]receiver.Factory{
+					testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+				}),
+			ProcessorBuilder: builders.NewProcessor(
+				map[component.ID]component.Config{
+					procID: &exampleConfig{},
+				},
+				map[component.Type]processor.Factory{
+					testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+				}),
+			ExporterBuilder: builders.NewExporter(
+				map[component.ID]component.Config{
+					expAID: &exampleConfig{Value: "a"},
+					expBID: &exampleConfig{Value: expBValue},
+				},
+				map[component.Type]exporter.Factory{
+					testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+				}),
+			ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+			PipelineConfigs:  pipelineConfigs,
+		}
+	}
+
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	pg, err := Build(context.Background(), newSettings("b", pipelines.Config{
+		tracesAID: {
+			Receivers:  []component.ID{rcvrAID},
+			Processors: []component.ID{procID},
+			Exporters:  []component.ID{expAID},
+		},
+		tracesBID: {
+			Receivers: []component.ID{rcvrBID},
+			Exporters: []component.ID{expBID},
+		},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	oldRcvrA := pg.getReceivers()[pipeline.SignalTraces][rcvrAID].(*testcomponents.ExampleReceiver)
+	oldRcvrB := pg.getReceivers()[pipeline.SignalTraces][rcvrBID].(*testcomponents.ExampleReceiver)
+	oldProc := pg.pipelines[tracesAID].processors[0].Component.(*testcomponents.ExampleProcessor)
+	oldExpA := pg.GetExporters()[pipeline.SignalTraces][expAID].(*testcomponents.ExampleExporter)
+	oldExpB := pg.GetExporters()[pipeline.SignalTraces][expBID].(*testcomponents.ExampleExporter)
+
+	// Change the configuration of exporter "b", and add a pipeline sharing exporter "a".
+	require.NoError(t, pg.Reload(context.Background(), newSettings("b2", pipelines.Config{
+		tracesAID: {
+			Receivers:  []component.ID{rcvrAID},
+			Processors: []component.ID{procID},
+			Exporters:  []component.ID{expAID},
+		},
+		tracesBID: {
+			Receivers: []component.ID{rcvrBID},
+			Exporters: []component.ID{expBID},
+		},
+		tracesCID: {
+			Receivers: []component.ID{rcvrCID},
+			Exporters: []component.ID{expAID},
+		},
+	}), host))
+
+	// Pipeline "a" is untouched.
+	assert.Same(t, oldRcvrA, pg.getReceivers()[pipeline.SignalTraces][rcvrAID])
+	assert.Same(t, oldProc, pg.pipelines[tracesAID].processors[0].Component)
+	assert.Same(t, oldExpA, pg.GetExporters()[pipeline.SignalTraces][expAID])
+	assert.False(t, oldRcvrA.Stopped())
+	assert.False(t, oldProc.Stopped())
+	assert.False(t, oldExpA.Stopped())
+
+	// Exporter "b" changed, so it is restarted along with the receiver emitting to it.
+	assert.True(t, oldExpB.Stopped())
+	assert.True(t, oldRcvrB.Stopped())
+	newExpB := pg.GetExporters()[pipeline.SignalTraces][expBID].(*testcomponents.ExampleExporter)
+	newRcvrB := pg.getReceivers()[pipeline.SignalTraces][rcvrBID].(*testcomponents.ExampleReceiver)
+	assert.NotSame(t, oldExpB, newExpB)
+	assert.NotSame(t, oldRcvrB, newRcvrB)
+	assert.True(t, newExpB.Started())
+	assert.True(t, newRcvrB.Started())
+
+	// Receiver "c" is new.
+	assert.True(t, pg.getReceivers()[pipeline.SignalTraces][rcvrCID].(*testcomponents.ExampleReceiver).Started())
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+	assert.True(t, oldRcvrA.Stopped())
+	assert.True(t, oldProc.Stopped())
+	assert.True(t, oldExpA.Stopped())
+}
+
+func TestGraphReloadBuildError(t *testing.T) {
+	nopReceiverFactory := receivertest.NewNopFactory()
+	nopExporterFactory := exportertest.NewNopFactory()
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				nopReceiverFactory.Type(): nopReceiverFactory,
+			}),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.NewID(nopExporterFactory.Type()): nopExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				nopExporterFactory.Type(): nopExporterFactory,
+			}),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{component.MustNewID("nop")},
+				Exporters: []component.ID{component.MustNewID("nop")},
+			},
+		},
+	}
+
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	oldExp := pg.GetExporters()[pipeline.SignalTraces][component.MustNewID("nop")]
+
+	set.PipelineConfigs = pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): {
+			Receivers:  []component.ID{component.MustNewID("nop")},
+			Processors: []component.ID{component.MustNewID("unknown")},
+			Exporters:  []component.ID{component.MustNewID("nop")},
+		},
+	}
+	require.Error(t, pg.Reload(context.Background(), set, host))
+	assert.Same(t, oldExp, pg.GetExporters()[pipeline.SignalTraces][component.MustNewID("nop")])
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+}
+
+// countingReceiver counts its starts and shutdowns, and fails to start if its configuration says so.
+type countingReceiver struct {
+	starts    atomic.Int32
+	shutdowns atomic.Int32
+	startErr  error
+}
+
+func (r *countingReceiver) Start(context.Context, component.Host) error {
+	r.starts.Add(1)
+	return r.startErr
+}
+
+func (r *countingReceiver) Shutdown(context.Context) error {
+	r.shutdowns.Add(1)
+	return nil
+}
+
+type countingReceiverConfig struct {
+	FailStart bool
+}
+
+func TestGraphReloadStartError(t *testing.T) {
+	type exampleConfig struct {
+		Value string
+	}
+
+	var created []*countingReceiver
+	countingFactory := receiver.NewFactory(
+		component.MustNewType("countingreceiver"),
+		func() component.Config { return &countingReceiverConfig{} },
+		receiver.WithTraces(func(_ context.Context, _ receiver.Settings, cfg component.Config, _ consumer.Traces) (receiver.Traces, error) {
+			r := &countingReceiver{}
+			if cfg.(*countingReceiverConfig).FailStart {
+				r.startErr = errors.New("start failed")
+			}
+			created = append(created, r)
+			return r, nil
+		}, component.StabilityLevelDevelopment),
+	)
+	countingID := component.NewID(countingFactory.Type())
+	rcvrID := component.MustNewID("examplereceiver")
+	expAID := component.MustNewIDWithName("exampleexporter", "a")
+	expBID := component.MustNewIDWithName("exampleexporter", "b")
+
+	newSettings := func(failStart bool) Settings {
+		return Settings{
+			Telemetry: componenttest.NewNopTelemetrySettings(),
+			BuildInfo: component.NewDefaultBuildInfo(),
+			ReceiverBuilder: builders.NewReceiver(
+				map[component.ID]component.Config{
+					rcvrID:     &exampleConfig{Value: "a"},
+					countingID: &countingReceiverConfig{FailStart: failStart},
+				},
+				map[component.Type]receiver.Factory{
+					testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+					countingFactory.Type():                       countingFactory,
+				}),
+			ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+			ExporterBuilder: builders.NewExporter(
+				map[component.ID]component.Config{
+					expAID: &exampleConfig{Value: "a"},
+					expBID: &exampleConfig{Value: "b"},
+				},
+				map[component.Type]exporter.Factory{
+					testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+				}),
+			ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+			PipelineConfigs: pipelines.Config{
+				pipeline.NewIDWithName(pipeline.SignalTraces, "a"): {
+					Receivers: []component.ID{rcvrID},
+					Exporters: []component.ID{expAID},
+				},
+				pipeline.NewIDWithName(pipeline.SignalTraces, "b"): {
+					Receivers: []component.ID{countingID},
+					Exporters: []component.ID{expBID},
+				},
+			},
+		}
+	}
+
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	pg, err := Build(context.Background(), newSettings(false))
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	keptRcvr := pg.getReceivers()[pipeline.SignalTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	keptExp := pg.GetExporters()[pipeline.SignalTraces][expAID].(*testcomponents.ExampleExporter)
+
+	// The new receiver fails to start: it is shut down along with the retired one, and only the kept components run.
+	require.ErrorIs(t, pg.Reload(context.Background(), newSettings(true), host), ErrPartialReload)
+	require.Len(t, created, 2)
+	assert.Equal(t, int32(1), created[0].shutdowns.Load())
+	assert.Equal(t, int32(1), created[1].starts.Load())
+	assert.Equal(t, int32(1), created[1].shutdowns.Load())
+	assert.False(t, keptRcvr.Stopped())
+	assert.False(t, keptExp.Stopped())
+
+	// Shutting down the graph only shuts down the kept components, the other ones being already shut down.
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+	assert.True(t, keptRcvr.Stopped())
+	assert.True(t, keptExp.Stopped())
+	assert.Equal(t, int32(1), created[0].shutdowns.Load())
+	assert.Equal(t, int32(1), created[1].shutdowns.Load())
+}
+
+// sharedReceiver mimics the receivers sharing one instance between the pipelines of all the signals, like the
+// OTLP receiver, and holding an exclusive resource, like a listening port, while running.
+type sharedReceiver struct {
+	resource *atomic.Bool
+	traces   consumer.Traces
+	metrics  consumer.Metrics
+}
+
+func (r *sharedReceiver) Start(context.Context, component.Host) error {
+	if !r.resource.CompareAndSwap(false, true) {
+		return errors.New("resource already in use")
+	}
+	return nil
+}
+
+func (r *sharedReceiver) Shutdown(context.Context) error {
+	r.resource.Store(false)
+	return nil
+}
+
+type sharedReceiverConfig struct {
+	Endpoint string
+}
+
+// newSharedReceiverFactory returns the factory of a shared receiver whose instances use the given resource.
+func newSharedReceiverFactory(resource *atomic.Bool) receiver.Factory {
+	receivers := sharedcomponent.NewMap[component.Config, *sharedReceiver]()
+	create := func(cfg component.Config) (*sharedcomponent.Component[*sharedReceiver], error) {
+		return receivers.LoadOrStore(cfg, func() (*sharedReceiver, error) {
+			return &sharedReceiver{resource: resource}, nil
+		})
+	}
+	return receiver.NewFactory(
+		component.MustNewType("sharedreceiver"),
+		func() component.Config { return &sharedReceiverConfig{Endpoint: "localhost:4317"} },
+		receiver.WithTraces(func(_ context.Context, _ receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+			r, err := create(cfg)
+			if err != nil {
+				return nil, err
+			}
+			r.Unwrap().traces = next
+			return r, nil
+		}, component.StabilityLevelDevelopment),
+		receiver.WithMetrics(func(_ context.Context, _ receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+			r, err := create(cfg)
+			if err != nil {
+				return nil, err
+			}
+			r.Unwrap().metrics = next
+			return r, nil
+		}, component.StabilityLevelDevelopment),
+	)
+}
+
+func TestGraphReloadSharedReceiver(t *testing.T) {
+	type exampleConfig struct {
+		Value string
+	}
+
+	resource := &atomic.Bool{}
+	sharedFactory := newSharedReceiverFactory(resource)
+	sharedID := component.NewID(sharedFactory.Type())
+	expAID := component.MustNewIDWithName("exampleexporter", "a")
+	expBID := component.MustNewIDWithName("exampleexporter", "b")
+
+	newSettings := func(expBValue string) Settings {
+		return Settings{
+			Telemetry: componenttest.NewNopTelemetrySettings(),
+			BuildInfo: component.NewDefaultBuildInfo(),
+			ReceiverBuilder: builders.NewReceiver(
+				map[component.ID]component.Config{sharedID: sharedFactory.CreateDefaultConfig()},
+				map[component.Type]receiver.Factory{sharedFactory.Type(): sharedFactory}),
+			ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+			ExporterBuilder: builders.NewExporter(
+				map[component.ID]component.Config{
+					expAID: &exampleConfig{Value: "a"},
+					expBID: &exampleConfig{Value: expBValue},
+				},
+				map[component.Type]exporter.Factory{
+					testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+				}),
+			ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+			PipelineConfigs: pipelines.Config{
+				pipeline.NewID(pipeline.SignalTraces): {
+					Receivers: []component.ID{sharedID},
+					Exporters: []component.ID{expAID},
+				},
+				pipeline.NewID(pipeline.SignalMetrics): {
+					Receivers: []component.ID{sharedID},
+					Exporters: []component.ID{expBID},
+				},
+			},
+		}
+	}
+
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	pg, err := Build(context.Background(), newSettings("b"))
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	oldRcvr := pg.getReceivers()[pipeline.SignalTraces][sharedID]
+
+	// Only the metrics pipeline changes, but the receiver instance shared by both pipelines must be restarted as a
+	// whole: the new instance could not start while the previous one holds the resource.
+	require.NoError(t, pg.Reload(context.Background(), newSettings("b2"), host))
+	tracesRcvr := pg.getReceivers()[pipeline.SignalTraces][sharedID]
+	metricsRcvr := pg.getReceivers()[pipeline.SignalMetrics][sharedID]
+	assert.NotSame(t, oldRcvr, tracesRcvr)
+	assert.Same(t, tracesRcvr, metricsRcvr)
+	rcvr := tracesRcvr.(*sharedcomponent.Component[*sharedReceiver]).Unwrap()
+	require.NoError(t, rcvr.traces.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, rcvr.metrics.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.True(t, resource.Load())
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+	assert.False(t, resource.Load())
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
 
 	"go.opentelemetry.io/contrib/config"
@@ -47,6 +48,19 @@ var _ = featuregate.GlobalRegistry().MustRegister(
 	featuregate.WithRegisterDescription("controls whether the collector supports extended OpenTelemetry"+
 		"configuration for internal telemetry"))
 
+// incrementalReloadFeatureGate is the feature gate that controls whether configuration changes are applied
+// by only restarting the pipeline components that changed, instead of restarting the whole service.
+var incrementalReloadFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"service.incrementalReload",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.118.0"),
+	featuregate.WithRegisterDescription("When enabled, configuration reloads only restart the pipeline components "+
+		"whose configuration or wiring changed"))
+
+// ErrReloadRequiresRestart is returned by Service.Reload when the configuration change cannot be
+// applied to the running Service, and the Service must be shut down and recreated instead.
+var ErrReloadRequiresRestart = errors.New("configuration change requires a service restart")
+
 // Settings holds configuration for building a new Service.
 type Settings struct {
 	// BuildInfo provides collector start information.
@@ -95,6 +109,7 @@ type Service struct {
 	host              *graph.Host
 	collectorConf     *confmap.Conf
 	loggerProvider    log.LoggerProvider
+	config            Config
 }
 
 // New creates a new Service, its telemetry, and Components.
@@ -113,6 +128,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			AsyncErrorChannel: set.AsyncErrorChannel,
 		},
 		collectorConf: set.CollectorConf,
+		config:        cfg,
 	}
 
 	// Fetch data for internal telemetry like instance id and sdk version to provide for internal telemetry.
@@ -331,7 +347,14 @@ func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) e
 // Creates the pipeline graph.
 func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
 	var err error
-	if srv.host.Pipelines, err = graph.Build(ctx, graph.Settings{
+	if srv.host.Pipelines, err = graph.Build(ctx, srv.graphSettings(cfg)); err != nil {
+		return fmt.Errorf("failed to build pipelines: %w", err)
+	}
+	return nil
+}
+
+func (srv *Service) graphSettings(cfg Config) graph.Settings {
+	return graph.Settings{
 		Telemetry:        srv.telemetrySettings,
 		BuildInfo:        srv.buildInfo,
 		ReceiverBuilder:  srv.host.Receivers,
@@ -340,8 +363,57 @@ func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
 		ConnectorBuilder: srv.host.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
 		ReportStatus:     srv.host.Reporter.ReportStatus,
-	}); err != nil {
-		return fmt.Errorf("failed to build pipelines: %w", err)
+	}
+}
+
+// Reload applies the given configuration to the running Service, only restarting the pipeline components
+// whose configuration or wiring changed, while all other components keep running.
+//
+// Changes to the service telemetry or to the extensions cannot be applied this way. In that case, or if the
+// "service.incrementalReload" feature gate is disabled, ErrReloadRequiresRestart is returned and the running
+// Service is left untouched. ErrReloadRequiresRestart is also returned if the reload fails after some components
+// were shut down: the Service then keeps its previous configuration, with only the unchanged components running,
+// and must be shut down and recreated.
+func (srv *Service) Reload(ctx context.Context, set Settings, cfg Config) error {
+	if !incrementalReloadFeatureGate.IsEnabled() {
+		return ErrReloadRequiresRestart
+	}
+	if !reflect.DeepEqual(srv.config.Telemetry, cfg.Telemetry) || !reflect.DeepEqual(srv.config.Extensions, cfg.Extensions) {
+		return ErrReloadRequiresRestart
+	}
+	for _, extID := range cfg.Extensions {
+		if !reflect.DeepEqual(srv.host.Extensions.Config(extID), set.ExtensionsConfigs[extID]) {
+			return ErrReloadRequiresRestart
+		}
+	}
+
+	graphSet := srv.graphSettings(cfg)
+	graphSet.ReceiverBuilder = builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories)
+	graphSet.ProcessorBuilder = builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories)
+	graphSet.ExporterBuilder = builders.NewExporter(set.ExportersConfigs, set.ExportersFactories)
+	graphSet.ConnectorBuilder = builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories)
+	if err := srv.host.Pipelines.Reload(ctx, graphSet, srv.host); err != nil {
+		if errors.Is(err, graph.ErrPartialReload) {
+			// Only the kept components are still running, and the host builders are not switched: the service
+			// must be restarted.
+			return fmt.Errorf("%w: failed to reload pipelines: %w", ErrReloadRequiresRestart, err)
+		}
+		return fmt.Errorf("failed to reload pipelines: %w", err)
+	}
+
+	// The host only switches to the new builders once the pipelines were built from them.
+	srv.host.Receivers = graphSet.ReceiverBuilder
+	srv.host.Processors = graphSet.ProcessorBuilder
+	srv.host.Exporters = graphSet.ExporterBuilder
+	srv.host.Connectors = graphSet.ConnectorBuilder
+	srv.host.Extensions = builders.NewExtension(set.ExtensionsConfigs, set.ExtensionsFactories)
+	srv.config = cfg
+
+	srv.collectorConf = set.CollectorConf
+	if srv.collectorConf != nil {
+		if err := srv.host.ServiceExtensions.NotifyConfig(ctx, srv.collectorConf); err != nil {
+			return err
+		}
 	}
 	return nil
 }
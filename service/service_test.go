@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pipeline"
@@ -252,6 +253,49 @@ func TestServiceGetExporters(t *testing.T) {
 	assert.Contains(t, expMap[xpipeline.SignalProfiles], component.NewID(nopType))
 }
 
+func TestServiceReload(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	cfg := newNopConfig()
+	delete(cfg.Pipelines, pipeline.NewID(pipeline.SignalMetrics))
+	require.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), cfg), ErrReloadRequiresRestart)
+
+	prev := incrementalReloadFeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(incrementalReloadFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(incrementalReloadFeatureGate.ID(), prev))
+	})
+
+	// nolint
+	tracesExp := srv.host.GetExporters()[pipeline.SignalTraces][component.NewID(nopType)]
+	require.NoError(t, srv.Reload(context.Background(), newNopSettings(), cfg))
+
+	// nolint
+	expMap := srv.host.GetExporters()
+	assert.Same(t, tracesExp, expMap[pipeline.SignalTraces][component.NewID(nopType)])
+	assert.Empty(t, expMap[pipeline.SignalMetrics])
+
+	// A failed reload leaves the host untouched.
+	receivers := srv.host.Receivers
+	invalidCfg := newNopConfig()
+	invalidCfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)].Processors = []component.ID{component.MustNewID("unknown")}
+	require.Error(t, srv.Reload(context.Background(), newNopSettings(), invalidCfg))
+	assert.Same(t, receivers, srv.host.Receivers)
+	// nolint
+	assert.Same(t, tracesExp, srv.host.GetExporters()[pipeline.SignalTraces][component.NewID(nopType)])
+
+	// Extensions can only be changed by restarting the service.
+	extCfg := newNopConfig()
+	extCfg.Extensions = nil
+	require.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), extCfg), ErrReloadRequiresRestart)
+}
+
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
 func TestServiceTelemetryCleanupOnError(t *testing.T) {